@@ -0,0 +1,22 @@
+// Command dsn prints the database connection string from the application
+// configuration. The Makefile uses it for the migrate targets.
+package main
+
+import (
+	"farmish/pkg/config"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := cfg.Database.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(cfg.Database.ConnectionString())
+}
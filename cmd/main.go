@@ -1,38 +1,148 @@
 package main
 
 import (
+	"context"
 	"farmish/internal/handlers"
+	"farmish/internal/models"
 	"farmish/internal/repository"
 	"farmish/internal/services"
 	"farmish/pkg/config"
+	"farmish/pkg/utils"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 func main() {
-	db, err := config.ConnectPostgres()
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	if cfg.Log.Level == "debug" {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	utils.ConfigureJWT(cfg.JWT.Secret, cfg.JWT.PreviousSecrets)
+
+	db, err := config.ConnectPostgres(cfg.Database)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer db.Close()
 
+	userRepo := repository.NewUserRepository(db)
+	farmRepo := repository.NewFarmRepository(db)
+	farmMemberRepo := repository.NewFarmMemberRepository(db)
 	animalRepo := repository.NewAnimalRepository(db)
 	foodRepo := repository.NewFoodRepository(db)
 	medicineRepo := repository.NewMedicineRepository(db)
+	medicineBatchRepo := repository.NewMedicineBatchRepository(db)
+	feedingRecordRepo := repository.NewFeedingRecordRepository(db)
+	wateringRecordRepo := repository.NewWateringRecordRepository(db)
+	feedingScheduleRepo := repository.NewFeedingScheduleRepository(db)
+	medicalRecordRepo := repository.NewMedicalRecordRepository(db)
+	alertRepo := repository.NewAlertRepository(db)
+	stockMovementRepo := repository.NewStockMovementRepository(db)
+	treatmentPlanRepo := repository.NewTreatmentPlanRepository(db)
+	weightMeasurementRepo := repository.NewWeightMeasurementRepository(db)
+	reportRepo := repository.NewReportRepository(db)
+	supplierRepo := repository.NewSupplierRepository(db)
+	purchaseOrderRepo := repository.NewPurchaseOrderRepository(db)
+	breedingRepo := repository.NewBreedingRepository(db)
+	productionRecordRepo := repository.NewProductionRecordRepository(db)
+	animalEventRepo := repository.NewAnimalEventRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+
+	alertService := services.NewAlertService(alertRepo)
+	accessService := services.NewAccessService(farmRepo, farmMemberRepo, animalRepo, foodRepo, medicineRepo, medicineBatchRepo, feedingRecordRepo, feedingScheduleRepo, wateringRecordRepo, medicalRecordRepo, treatmentPlanRepo, supplierRepo, purchaseOrderRepo, breedingRepo, productionRecordRepo, alertRepo, trashRepo, auditRepo)
 
-	userService := services.NewUserService(repository.NewUserRepository(db))
-	farmService := services.NewFarmService(repository.NewFarmRepository(db))
-	animalService := services.NewAnimalService(animalRepo)
+	userService := services.NewUserService(userRepo, repository.NewSessionRepository(db), cfg.JWT)
+	farmService := services.NewFarmService(farmRepo, farmMemberRepo)
+	farmMemberService := services.NewFarmMemberService(farmMemberRepo, farmRepo, userRepo)
+	animalService := services.NewAnimalService(animalRepo, cfg.Care)
 	foodService := services.NewFoodService(foodRepo)
 	medicineService := services.NewMedicineService(medicineRepo)
-	feedingRecordService := services.NewFeedingRecordService(repository.NewFeedingRecordRepository(db), animalRepo, foodRepo)
-	medicalRecordService := services.NewMedicalRecordService(repository.NewMedicalRecordRepository(db), animalRepo, medicineRepo)
+	feedingRecordService := services.NewFeedingRecordService(feedingRecordRepo, animalRepo, foodRepo, alertService)
+	medicalRecordService := services.NewMedicalRecordService(medicalRecordRepo, animalRepo, medicineRepo, alertService)
+	wateringRecordService := services.NewWateringRecordService(wateringRecordRepo, animalRepo)
+	feedingScheduleService := services.NewFeedingScheduleService(feedingScheduleRepo, animalRepo, foodRepo, feedingRecordService)
+	stockService := services.NewStockService(stockMovementRepo, foodRepo, medicineRepo, alertService)
+	treatmentPlanService := services.NewTreatmentPlanService(treatmentPlanRepo, animalRepo, medicineRepo, medicalRecordService, alertService)
+	weightMeasurementService := services.NewWeightMeasurementService(weightMeasurementRepo, animalRepo)
+	reportService := services.NewReportService(reportRepo)
+	supplierService := services.NewSupplierService(supplierRepo)
+	purchaseOrderService := services.NewPurchaseOrderService(purchaseOrderRepo, supplierRepo, foodRepo, medicineRepo)
+	breedingService := services.NewBreedingService(breedingRepo, animalRepo)
+	pedigreeService := services.NewPedigreeService(animalRepo)
+	productionRecordService := services.NewProductionRecordService(productionRecordRepo, animalRepo, medicalRecordService)
+	animalEventService := services.NewAnimalEventService(animalEventRepo, animalRepo, medicalRecordService)
+	medicineBatchService := services.NewMedicineBatchService(medicineBatchRepo, medicalRecordService, alertService, cfg.Stock)
+	trashService := services.NewTrashService(trashRepo, foodRepo, medicineRepo, alertService, cfg.Trash.Retention)
+	auditService := services.NewAuditService(auditRepo)
 
-	h := handlers.NewHandler(userService, farmService, animalService, foodService, medicineService, feedingRecordService, medicalRecordService)
+	h := handlers.NewHandler(userService, farmService, animalService, foodService, medicineService, feedingRecordService, medicalRecordService, alertService, accessService, farmMemberService, stockService, wateringRecordService, feedingScheduleService, treatmentPlanService, weightMeasurementService, reportService, supplierService, purchaseOrderService, medicineBatchService, breedingService, pedigreeService, productionRecordService, animalEventService, trashService, auditService)
 
-	r := handlers.Run(h)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	err = r.Run(":8080")
-	if err != nil {
-		log.Fatal(err)
+	services.RunJobs(ctx, services.Job{
+		Name:     "feeding tasks",
+		Interval: cfg.Scheduler.FeedingTasksInterval,
+		Run: func(now time.Time, actor *models.AuditActor) error {
+			_, err := feedingScheduleService.GenerateTasks(now, actor)
+			return err
+		},
+	}, services.Job{
+		Name:     "treatment events",
+		Interval: cfg.Scheduler.TreatmentEventsInterval,
+		Run: func(now time.Time, actor *models.AuditActor) error {
+			if _, err := treatmentPlanService.GenerateEvents(actor); err != nil {
+				return err
+			}
+			return treatmentPlanService.SendReminders(now)
+		},
+	}, services.Job{
+		Name:     "medicine batch expiry",
+		Interval: cfg.Scheduler.MedicineBatchesInterval,
+		Run: func(now time.Time, _ *models.AuditActor) error {
+			return medicineBatchService.SendExpiryAlerts(now)
+		},
+	}, services.Job{
+		Name:     "trash purge",
+		Interval: cfg.Scheduler.PurgeInterval,
+		Run:      trashService.Purge,
+	})
+
+	srv := &http.Server{Addr: cfg.Server.Addr, Handler: handlers.Run(h, cfg.Server)}
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		log.Printf("server stopped: %v", err)
+		return
+	case <-ctx.Done():
+	}
+	stop()
+
+	log.Println("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("failed to shut down server: %v", err)
 	}
 }
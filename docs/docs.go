@@ -19,61 +19,95 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/animals": {
+        "/alerts": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of animals for a specific farm",
+                "description": "Retrieve alerts, optionally filtered by farm, type and read status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "animals"
+                    "alerts"
                 ],
-                "summary": "Get animals by farm ID",
+                "summary": "Get alerts",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Farm ID",
                         "name": "farm_id",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alert type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only unread alerts",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at or type, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Animal"
-                            }
+                            "$ref": "#/definitions/models.Page-models_Alert"
                         }
                     },
                     "400": {
-                        "description": "Invalid farm ID",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/alerts/read": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Modify details of an existing animal",
+                "description": "Set the read status of several alerts at once",
                 "consumes": [
                     "application/json"
                 ],
@@ -81,17 +115,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "animals"
+                    "alerts"
                 ],
-                "summary": "Update an animal",
+                "summary": "Mark alerts as read or unread",
                 "parameters": [
                     {
-                        "description": "Updated animal data",
+                        "description": "Alert IDs and read status",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateAnimalReq"
+                            "$ref": "#/definitions/models.MarkAlertsReq"
                         }
                     }
                 ],
@@ -99,66 +133,81 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateAnimalResp"
+                            "$ref": "#/definitions/models.MarkAlertsResp"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/alerts/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new animal to a farm",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Remove an alert by its ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "animals"
+                    "alerts"
                 ],
-                "summary": "Create a new animal",
+                "summary": "Dismiss an alert",
                 "parameters": [
                     {
-                        "description": "Animal data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateAnimalReq"
-                        }
+                        "type": "string",
+                        "description": "Alert ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateAnimalResp"
+                            "$ref": "#/definitions/models.MessageResp"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -166,45 +215,93 @@ const docTemplate = `{
                 }
             }
         },
-        "/animals/{id}": {
+        "/animals": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve details of an animal using its unique ID",
+                "description": "Retrieve a list of animals for a specific farm. Only active animals are listed unless another status is asked for.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "animals"
                 ],
-                "summary": "Get an animal by ID",
+                "summary": "Get animals by farm ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Animal ID(UUID)",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "active (default), dead, sold, culled or all",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Health status",
+                        "name": "health_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum age in days",
+                        "name": "min_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum age in days",
+                        "name": "max_age",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of animals to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name (default), type, weight, health_status, date_of_birth or created_at, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Animal"
+                            "$ref": "#/definitions/models.Page-models_Animal"
                         }
                     },
                     "400": {
-                        "description": "Invalid animal ID",
+                        "description": "Invalid farm ID, filter, page or sort",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "404": {
-                        "description": "Animal not found",
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -217,48 +314,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove an animal from the system",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "animals"
-                ],
-                "summary": "Delete an animal",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Animal ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Animal deleted successfully",
-                        "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrResp"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate a user using their email and password.",
+                "description": "Modify details of an existing animal",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,35 +328,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "animals"
                 ],
-                "summary": "User login",
+                "summary": "Update an animal",
                 "parameters": [
                     {
-                        "description": "Login credentials",
+                        "description": "Updated animal data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.LoginRequest"
+                            "$ref": "#/definitions/models.UpdateAnimalReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful login response with token and user ID",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.LoginResponse"
+                            "$ref": "#/definitions/models.UpdateAnimalResp"
                         }
                     },
                     "400": {
-                        "description": "Invalid input format or missing fields",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "401": {
-                        "description": "Invalid email or password",
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -306,11 +368,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/signup": {
+            },
             "post": {
-                "description": "Creates a new user account.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new animal to a farm",
                 "consumes": [
                     "application/json"
                 ],
@@ -318,17 +383,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "animals"
                 ],
-                "summary": "User sign-up",
+                "summary": "Create a new animal",
                 "parameters": [
                     {
-                        "description": "Sign-up details",
+                        "description": "Animal data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.SignUpRequest"
+                            "$ref": "#/definitions/models.CreateAnimalReq"
                         }
                     }
                 ],
@@ -336,17 +401,17 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.SignUpResponse"
+                            "$ref": "#/definitions/models.CreateAnimalResp"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid input",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "409": {
-                        "description": "Email exist",
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -360,76 +425,122 @@ const docTemplate = `{
                 }
             }
         },
-        "/farms": {
+        "/animals/inbreeding": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a list of all existing farms.",
+                "description": "Compute the inbreeding coefficient the offspring of a proposed sire and dam would have, and list the ancestors they share",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "farms"
+                    "animals"
+                ],
+                "summary": "Compute the inbreeding coefficient of a mating",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sire ID",
+                        "name": "sire_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dam ID",
+                        "name": "dam_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Generations of ancestry to consider (default 6, at most 10)",
+                        "name": "generations",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all farms",
                 "responses": {
                     "200": {
-                        "description": "List of farms",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Farm"
-                            }
+                            "$ref": "#/definitions/models.InbreedingResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/animals/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create Farm for owner.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve details of an animal using its unique ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "farms"
+                    "animals"
                 ],
-                "summary": "Create Farm",
+                "summary": "Get an animal by ID",
                 "parameters": [
                     {
-                        "description": "Request body for creating a farm",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateFarmRequest"
-                        }
+                        "type": "string",
+                        "description": "Animal ID(UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateFarmResponse"
+                            "$ref": "#/definitions/models.Animal"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid animal ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Animal not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -441,27 +552,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/farms/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve farm details by their UUID.",
+                "description": "Move an animal and all of its records to the trash, from where they can be restored until they are purged. Record a lifecycle event instead to keep its history.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "farms"
+                    "animals"
                 ],
-                "summary": "Get a farm by ID",
+                "summary": "Delete an animal",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Farm ID (UUID)",
+                        "description": "Animal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -469,19 +578,13 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Farm details",
-                        "schema": {
-                            "$ref": "#/definitions/models.Farm"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid farm ID format",
+                        "description": "Animal deleted successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.ErrResp"
+                            "$ref": "#/definitions/models.MessageResp"
                         }
                     },
-                    "404": {
-                        "description": "Farm not found",
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -493,115 +596,157 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/animals/{id}/descendants": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update details of a farm by their UUID.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the offspring of an animal down to the given generation; generation 1 are its children",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "farms"
+                    "animals"
                 ],
-                "summary": "Update a farm",
+                "summary": "Get the descendants of an animal",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Farm ID (UUID)",
+                        "description": "Animal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Farm update payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateFarmRequest"
-                        }
+                        "type": "integer",
+                        "description": "Generations down (default 3, at most 10)",
+                        "name": "generations",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Farm updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateFarmResp"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Descendant"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Invalid input or ID format",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/animals/{id}/events": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a farm by their UUID.",
+                "description": "Retrieve the sales, death, culling and transfers of an animal in the order they occurred",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "farms"
+                    "animals"
                 ],
-                "summary": "Delete a farm",
+                "summary": "Get the lifecycle events of an animal",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Farm ID (UUID)",
+                        "description": "Animal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "occurred_at, event_type or created_at, prefixed with - for descending order; oldest first by default",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Farm deleted successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
+                            "$ref": "#/definitions/models.Page-models_AnimalEvent"
                         }
                     },
                     "400": {
-                        "description": "Invalid Farm ID format",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Animal not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     }
                 }
-            }
-        },
-        "/feeding_records": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Record that an active animal was sold, died, was culled or was transferred to another farm. Sold, dead and culled animals leave the active herd; transferred animals stay active on their new farm, which the caller must also manage. The animal's records are kept. Sales and cullings are rejected while a meat withdrawal period runs.",
                 "consumes": [
                     "application/json"
                 ],
@@ -609,17 +754,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "feeding_records"
+                    "animals"
                 ],
-                "summary": "Create a new feeding record",
+                "summary": "Record a lifecycle event",
                 "parameters": [
                     {
-                        "description": "Feeding Record request body",
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Lifecycle event",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.FeedingRecordReq"
+                            "$ref": "#/definitions/models.AnimalEventReq"
                         }
                     }
                 ],
@@ -627,7 +779,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.FeedingRecordResp"
+                            "$ref": "#/definitions/models.AnimalEventResp"
                         }
                     },
                     "400": {
@@ -636,8 +788,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Animal or farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Animal not active or under withdrawal",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -651,37 +815,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/feeding_records/animal/{animal_id}": {
+        "/animals/{id}/pedigree": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Retrieve the ancestry tree of an animal through its sires and dams, and its inbreeding coefficient over that tree",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feeding_records"
+                    "animals"
                 ],
-                "summary": "Get all feeding records for a specific animal",
+                "summary": "Get the pedigree of an animal",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Animal ID",
-                        "name": "animal_id",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Generations back (default 3, at most 10)",
+                        "name": "generations",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.FeedingRecordDetailed"
-                            }
+                            "$ref": "#/definitions/models.Pedigree"
                         }
                     },
                     "400": {
@@ -690,6 +858,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -699,24 +879,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/feeding_records/{id}": {
+        "/animals/{id}/reproduction": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Retrieve every breeding event of a female with its pregnancy checks, birth and offspring",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feeding_records"
+                    "animals"
                 ],
-                "summary": "Get a feeding record by its ID",
+                "summary": "Get the reproductive history of an animal",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Feeding Record ID",
+                        "description": "Animal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -726,7 +907,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.FeedingRecordDetailed"
+                            "$ref": "#/definitions/models.ReproductiveHistory"
                         }
                     },
                     "400": {
@@ -735,6 +916,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -748,46 +935,55 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/animals/{id}/treatments/upcoming": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve the pending treatment events of an animal, overdue ones first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feeding_records"
+                    "treatment_events"
                 ],
-                "summary": "Update a feeding record by its ID",
+                "summary": "Get the upcoming treatments of an animal",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Feeding Record ID",
+                        "description": "Animal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Feeding Record Input",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateFeedRecordReq"
-                        }
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "due_date, dose_number or created_at, prefixed with - for descending order; overdue first by default",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
+                            "$ref": "#/definitions/models.Page-models_TreatmentEvent"
                         }
                     },
                     "400": {
@@ -796,6 +992,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -809,34 +1011,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/animals/{id}/weights": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Retrieve the measurements of an animal with its average daily gain over the period",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "feeding_records"
+                    "weights"
                 ],
-                "summary": "Delete a feeding record by its ID",
+                "summary": "Get the weight history of an animal",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Feeding Record ID",
+                        "description": "Animal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
+                            "$ref": "#/definitions/models.AnimalGrowth"
                         }
                     },
                     "400": {
@@ -845,8 +1062,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Animal not found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -858,16 +1081,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/foods": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing food item in the warehouse",
+                "description": "Append a measurement to the weight history of an animal. The animal's weight is set to its latest measurement.",
                 "consumes": [
                     "application/json"
                 ],
@@ -875,66 +1096,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "weights"
                 ],
-                "summary": "Update a food item in the warehouse",
+                "summary": "Record a weight measurement",
                 "parameters": [
                     {
-                        "description": "Warehouse Food",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateFoodReq"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateFoodResp"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrResp"
-                        }
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrResp"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new food item and associate it with a specific farm",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "foods"
-                ],
-                "summary": "Create a food item and add it to the warehouse",
-                "parameters": [
                     {
-                        "description": "Warehouse Food",
+                        "description": "Weight measurement",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AddFoodReq"
+                            "$ref": "#/definitions/models.WeightMeasurementReq"
                         }
                     }
                 ],
@@ -942,7 +1121,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.AddFoodResp"
+                            "$ref": "#/definitions/models.WeightMeasurementResp"
                         }
                     },
                     "400": {
@@ -951,6 +1130,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Animal not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -960,26 +1151,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/foods/food/{food_id}": {
-            "get": {
+        "/animals/{id}/weights/{measurement_id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve details of a food using its unique ID",
+                "description": "Delete a measurement of an animal. The animal's weight falls back to its latest remaining measurement.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "weights"
                 ],
-                "summary": "Get a food by ID",
+                "summary": "Delete a weight measurement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Food ID(UUID)",
-                        "name": "food_id",
+                        "description": "Animal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Weight Measurement ID",
+                        "name": "measurement_id",
                         "in": "path",
                         "required": true
                     }
@@ -988,23 +1186,29 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Food"
+                            "$ref": "#/definitions/models.MessageResp"
                         }
                     },
                     "400": {
-                        "description": "Invalid food ID",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "404": {
-                        "description": "Food not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1012,38 +1216,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/foods/{farm_id}": {
+        "/audit": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all foods associated with a specific farm",
+                "description": "List who created, changed, deleted or restored an entity and which fields changed, latest first. Needs the view_audit permission on the farm the entity belonged to last; users can only see the history of their own account.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "foods"
+                    "audit"
                 ],
-                "summary": "Get all food items for a specific farm",
+                "summary": "Get the audit history of an entity",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Farm ID",
-                        "name": "farm_id",
-                        "in": "path",
+                        "description": "Entity type, e.g. animal, food or feeding_record",
+                        "name": "entity",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "id",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, entity_type or action, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Food"
-                            }
+                            "$ref": "#/definitions/models.Page-models_AuditEntry"
                         }
                     },
                     "400": {
@@ -1052,48 +1278,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
-                    }
-                }
-            }
-        },
-        "/foods/{food_id}": {
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a food item from the warehouse",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "foods"
-                ],
-                "summary": "Remove a food item from the warehouse",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Food ID",
-                        "name": "food_id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "No audit history found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1107,13 +1299,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/medical_records": {
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
+                "description": "Authenticate a user using their email and password.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1121,35 +1309,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "medical_records"
+                    "auth"
                 ],
-                "summary": "Create a new medical record",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "description": "Medical Record",
+                        "description": "Login credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MedicalRecordReq"
+                            "$ref": "#/definitions/models.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created successfully",
+                    "200": {
+                        "description": "Successful login response with token and user ID",
                         "schema": {
-                            "$ref": "#/definitions/models.MedicalRecordResp"
+                            "$ref": "#/definitions/models.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid input",
+                        "description": "Invalid input format or missing fields",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "404": {
-                        "description": "Animal or Medicine Not Found",
+                    "401": {
+                        "description": "Invalid email or password",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1163,47 +1351,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/medical_records/animals/{animal_id}": {
-            "get": {
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Revoke the current session so its access and refresh tokens stop working.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medical_records"
-                ],
-                "summary": "Get medical records by Animal ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Animal ID",
-                        "name": "animal_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Logout",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.MedicalRecordDetailed"
-                            }
+                            "$ref": "#/definitions/models.MessageResp"
                         }
                     },
-                    "400": {
-                        "description": "Invalid animal ID",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1211,62 +1388,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/medical_records/{id}": {
-            "get": {
+        "/auth/logout-all": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Revoke every session of the authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medical_records"
-                ],
-                "summary": "Get a medical record by ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Medical Record ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Logout from all devices",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.MedicalRecordDetailed"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid record ID",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrResp"
+                            "$ref": "#/definitions/models.MessageResp"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing one revokes the whole session.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1274,32 +1435,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "medical_records"
+                    "auth"
                 ],
-                "summary": "Update a medical record",
+                "summary": "Refresh tokens",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Medical Record ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Medical Record",
+                        "description": "Refresh token",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateMedicalRecordReq"
+                            "$ref": "#/definitions/models.RefreshTokenReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
+                            "$ref": "#/definitions/models.LoginResponse"
                         }
                     },
                     "400": {
@@ -1308,8 +1462,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Invalid, expired or reused refresh token",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1321,44 +1475,47 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/auth/signup": {
+            "post": {
+                "description": "Creates a new user account.",
+                "consumes": [
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medical_records"
+                    "auth"
                 ],
-                "summary": "Delete a medical record by ID",
+                "summary": "User sign-up",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Medical Record ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Sign-up details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SignUpRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Deleted successfully",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
+                            "$ref": "#/definitions/models.SignUpResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid record ID",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "404": {
-                        "description": "Not found",
+                    "409": {
+                        "description": "Email exist",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1372,35 +1529,40 @@ const docTemplate = `{
                 }
             }
         },
-        "/medicines": {
-            "get": {
+        "/breeding_events": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all medicines for a specific farm",
+                "description": "Record a mating or insemination of a female. Without an expected due date, it is computed from the gestation length of the animal type.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "medicines"
+                    "breeding"
                 ],
-                "summary": "Get all medicines",
+                "summary": "Record a breeding event",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Farm ID",
-                        "name": "farm_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Breeding event",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BreedingEventReq"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Medicine"
-                            }
+                            "$ref": "#/definitions/models.BreedingEventResp"
                         }
                     },
                     "400": {
@@ -1409,6 +1571,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Dam not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1416,40 +1590,37 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/breeding_events/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a new medicine to the farm",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve a breeding event with its pregnancy checks and birth",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "medicines"
+                    "breeding"
                 ],
-                "summary": "Create a new medicine",
+                "summary": "Get a breeding event by ID",
                 "parameters": [
                     {
-                        "description": "Medicine Details",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.MedicineReq"
-                        }
+                        "type": "string",
+                        "description": "Breeding Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.MedicineResp"
+                            "$ref": "#/definitions/models.BreedingEvent"
                         }
                     },
                     "400": {
@@ -1458,6 +1629,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1465,24 +1648,25 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/medicines/{id}": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a specific medicine by its ID",
+                "description": "Delete a breeding event whose birth was not recorded yet",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "medicines"
+                    "breeding"
                 ],
-                "summary": "Get a medicine by ID",
+                "summary": "Delete a breeding event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Medicine ID",
+                        "description": "Breeding Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1492,7 +1676,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Medicine"
+                            "$ref": "#/definitions/models.MessageResp"
                         }
                     },
                     "400": {
@@ -1501,12 +1685,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "409": {
+                        "description": "Birth already recorded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1514,14 +1710,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/breeding_events/{id}/birth": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update details of a specific medicine",
+                "description": "Close a breeding event with its birth. Every live offspring is added to the farm as an animal linked to its sire and dam.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1529,32 +1727,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "medicines"
+                    "breeding"
                 ],
-                "summary": "Update an existing medicine",
+                "summary": "Record a birth",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Medicine ID",
+                        "description": "Breeding Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated Medicine Details",
+                        "description": "Birth",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.MedicineReq"
+                            "$ref": "#/definitions/models.BirthReq"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.MedicineResp"
+                            "$ref": "#/definitions/models.BirthResp"
                         }
                     },
                     "400": {
@@ -1563,12 +1761,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
+                    "409": {
+                        "description": "Birth already recorded",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1576,32 +1786,49 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/breeding_events/{id}/pregnancy_checks": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove a specific medicine by its ID",
+                "description": "Add a pregnancy check to a breeding event. The latest check marks the dam as pregnant or open.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "medicines"
+                    "breeding"
                 ],
-                "summary": "Delete a medicine",
+                "summary": "Record a pregnancy check",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Medicine ID",
+                        "description": "Breeding Event ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Pregnancy check",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PregnancyCheckReq"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.MessageResp"
+                            "$ref": "#/definitions/models.PregnancyCheckResp"
                         }
                     },
                     "400": {
@@ -1610,48 +1837,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
-                    }
-                }
-            }
-        },
-        "/users": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Retrieve a list of all registered users.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "users"
-                ],
-                "summary": "Get all users",
-                "responses": {
-                    "200": {
-                        "description": "List of users",
+                    },
+                    "409": {
+                        "description": "Birth already recorded",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.User"
-                            }
+                            "$ref": "#/definitions/models.ErrResp"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1659,45 +1864,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/farms": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve user details by their UUID.",
+                "description": "Retrieve the farms the authenticated user owns or works on.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "farms"
                 ],
-                "summary": "Get a user by ID",
+                "summary": "Get all farms",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of farms to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "name (default), location or created_at, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User details",
+                        "description": "Page of farms",
                         "schema": {
-                            "$ref": "#/definitions/models.User"
+                            "$ref": "#/definitions/models.Page-models_Farm"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID format",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrResp"
-                        }
-                    },
-                    "404": {
-                        "description": "User not found",
+                        "description": "Invalid page or sort",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1710,13 +1920,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update details of a user by their UUID.",
+                "description": "Create Farm for owner.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1724,36 +1934,155 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "farms"
                 ],
-                "summary": "Update a user",
+                "summary": "Create Farm",
+                "parameters": [
+                    {
+                        "description": "Request body for creating a farm",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateFarmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateFarmResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve farm details by their UUID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Get a farm by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Farm ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Farm details",
+                        "schema": {
+                            "$ref": "#/definitions/models.Farm"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid farm ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update details of a farm by their UUID.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Update a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User update payload, provide password only if it is updated",
+                        "description": "Farm update payload",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateUserSwag"
+                            "$ref": "#/definitions/models.UpdateFarmRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Farm updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateUserResp"
+                            "$ref": "#/definitions/models.UpdateFarmResp"
                         }
                     },
                     "400": {
-                        "description": "Invalid input or user ID format",
+                        "description": "Invalid input or ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1772,18 +2101,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a user by their UUID.",
+                "description": "Move a farm with its animals, stock and records to the trash. The owner can restore it until it is purged.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "farms"
                 ],
-                "summary": "Delete a user",
+                "summary": "Delete a farm",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Farm ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1791,19 +2120,19 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Farm deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/models.MessageResp"
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID format",
+                        "description": "Invalid Farm ID format",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "403": {
+                        "description": "Access denied",
                         "schema": {
                             "$ref": "#/definitions/models.ErrResp"
                         }
@@ -1816,753 +2145,10311 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AddFoodReq": {
-            "type": "object",
-            "required": [
-                "farm_id",
-                "min_threshold",
-                "name",
-                "quantity",
-                "suitable_for",
-                "unit_of_measure"
-            ],
-            "properties": {
-                "farm_id": {
-                    "type": "string"
-                },
-                "min_threshold": {
-                    "type": "number"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "quantity": {
-                    "type": "number"
-                },
-                "suitable_for": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "unit_of_measure": {
-                    "type": "string"
-                }
-            }
         },
-        "models.AddFoodResp": {
-            "type": "object",
-            "properties": {
-                "food": {
-                    "$ref": "#/definitions/models.FoodWithoutTime"
-                },
-                "message": {
-                    "type": "string"
+        "/farms/{id}/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the changes made on a farm, latest first, optionally narrowed down to one entity type, one user and a date range.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Get the audit timeline of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type, e.g. animal, food or feeding_record",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user who made the changes",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, entity_type or action, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_AuditEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
                 }
             }
         },
-        "models.Animal": {
-            "type": "object",
-            "required": [
-                "farm_id",
-                "type",
-                "weight"
-            ],
-            "properties": {
+        "/farms/{id}/births/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the pregnancies due within the given days, including overdue ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Get the upcoming births of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days ahead to look (default 30)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.UpcomingBirth"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/feeding_records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the feeding records of all animals on a farm, newest first, optionally narrowed down to a date range, an animal type, a food and the user who recorded them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_records"
+                ],
+                "summary": "Get the feeding records of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "animal_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user who recorded it",
+                        "name": "recorded_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "fed_at, quantity or created_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_FeedingRecordDetailed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/growth": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve, per animal type, the average daily gain and the average weight per period",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "weights"
+                ],
+                "summary": "Get the growth curves of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Curve period: day, week (default) or month",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TypeGrowth"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/growth/below_average": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the animals whose average daily gain over the period is lower than the average of the animals of the same type on the farm, furthest behind first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "weights"
+                ],
+                "summary": "Get animals growing below the herd average",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AnimalGrowth"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all invitations sent for a farm",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Get farm invitations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "email, role, status or created_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_FarmInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Invite a user by email to join the farm with the given role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Invite a member to a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invitation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.InviteMemberReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.FarmInvitationResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/invitations/{invitation_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Revoke a farm invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "invitation_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/medical_records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the medical records of all animals on a farm, newest first, optionally narrowed down to a date range, an animal type, a medicine and the user who recorded them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medical_records"
+                ],
+                "summary": "Get the medical records of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "medicine_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "animal_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the user who recorded it",
+                        "name": "recorded_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "treatment_date, quantity or created_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_MedicalRecordDetailed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/medicine_batches/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the batches with stock left that expire within the given days, including those already expired",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Get the expiring medicine batches of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days ahead to look (default 30)",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only return expired batches",
+                        "name": "expired",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expiry_date, lot_number, quantity or created_at, prefixed with - for descending order; first expiring first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_ExpiringBatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the owner and staff of a farm with their roles",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Get farm members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, email, role or created_at, prefixed with - for descending order; oldest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_FarmMember"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/members/{user_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Change a member's role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMemberRoleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMemberRoleResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Owners can remove any staff member; staff can remove themselves to leave the farm",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Remove a member from a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/overdue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List animals that were not fed or watered within the expected interval. The intervals default to the server configuration.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Get overdue animals of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Feeding interval, e.g. 24h",
+                        "name": "feeding_interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Watering interval, e.g. 12h",
+                        "name": "watering_interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.OverdueAnimal"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid farm ID or interval",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/stock/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Estimate the daily use of every food and medicine from its consumption over the window and from active feeding schedules and upcoming treatments, and the days left until it falls below its threshold and until it runs out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Forecast the stock of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of consumption history to average (default 30)",
+                        "name": "window",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StockForecast"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/stock/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the quantity of every food and medicine of the farm with the sum of its stock movements",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Reconcile the stock of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StockReconciliation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/stock/reorder": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the foods and medicines expected to fall below their threshold within the lead time, soonest first, with the quantity to order to cover the following days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Get the reorder list of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days of consumption history to average (default 30)",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days until an order arrives (default 7)",
+                        "name": "lead_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Days an order should last after it arrives (default 30)",
+                        "name": "cover_days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ReorderSuggestion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/treatments/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the pending treatment events of every animal on the farm, overdue ones first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_events"
+                ],
+                "summary": "Get the upcoming treatments of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only events due within this many days",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only overdue events",
+                        "name": "overdue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "due_date, dose_number or created_at, prefixed with - for descending order; overdue first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_TreatmentEvent"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/farms/{id}/withdrawals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the animals of a farm whose meat, milk or eggs may not be used yet because of a recent treatment, with the day each withdrawal period ends",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farms"
+                ],
+                "summary": "Get animals under withdrawal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.AnimalWithdrawal"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid farm ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_records": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_records"
+                ],
+                "summary": "Create a new feeding record",
+                "parameters": [
+                    {
+                        "description": "Feeding Record request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedingRecordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedingRecordResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, food of another farm or insufficient quantity",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_records/animal/{animal_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_records"
+                ],
+                "summary": "Get all feeding records for a specific animal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "animal_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "fed_at, quantity or created_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_FeedingRecordDetailed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_records/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_records"
+                ],
+                "summary": "Get a feeding record by its ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedingRecordDetailed"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_records"
+                ],
+                "summary": "Update a feeding record by its ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Feeding Record Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFeedRecordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a feeding record to the trash and return its quantity to the food stock",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_records"
+                ],
+                "summary": "Delete a feeding record by its ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_schedules"
+                ],
+                "summary": "Get the feeding schedules of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at or quantity, prefixed with - for descending order; oldest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_FeedingSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Plan recurring feedings for a single animal or for every animal of a type on the farm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_schedules"
+                ],
+                "summary": "Create a feeding schedule",
+                "parameters": [
+                    {
+                        "description": "Feeding schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedingScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedingScheduleResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Animal or food not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_schedules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_schedules"
+                ],
+                "summary": "Get a feeding schedule by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FeedingSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the food, quantity or recurrence of a schedule, or pause it. Already generated tasks are kept.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_schedules"
+                ],
+                "summary": "Update a feeding schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Feeding schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFeedingScheduleReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a schedule together with its feeding tasks",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_schedules"
+                ],
+                "summary": "Delete a feeding schedule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Schedule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the feeding tasks of a farm, optionally for a single day and status",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_tasks"
+                ],
+                "summary": "Get feeding tasks",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Day in YYYY-MM-DD format",
+                        "name": "date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Task status (pending, completed, skipped)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "due_at, status, quantity or created_at, prefixed with - for descending order; earliest due first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_FeedingTask"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_tasks/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create the feeding record of a pending task, decrementing the food stock",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_tasks"
+                ],
+                "summary": "Complete a feeding task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Overrides for the planned feeding",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CompleteFeedingTaskReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CompleteFeedingTaskResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Task is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeding_tasks/{id}/skip": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feeding_tasks"
+                ],
+                "summary": "Skip a feeding task",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feeding Task ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Task is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/foods": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing food item in the warehouse",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Update a food item in the warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse Food",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFoodReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateFoodResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new food item and associate it with a specific farm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Create a food item and add it to the warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse Food",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AddFoodReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.AddFoodResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/food/{food_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve details of a food using its unique ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get a food by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID(UUID)",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Food"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid food ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Food not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/food/{food_id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the inventory ledger of a food, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get the stock movements of a food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, movement_type or quantity, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Food not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book a purchase, waste, adjustment or transfer of a food. Purchases add and waste removes stock; adjustments and transfers use the sign of the quantity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Record a stock movement for a food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock movement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovementReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovementResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Food not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/food/{food_id}/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the current quantity of a food with the sum of its stock movements",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Reconcile the stock of a food",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockReconciliation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Food not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/{farm_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all foods associated with a specific farm",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Get all food items for a specific farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type the item must be suitable for",
+                        "name": "suitable_for",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only list items below their minimum threshold",
+                        "name": "below_threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name (default), quantity, created_at or updated_at, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_Food"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/foods/{food_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a food item of the warehouse to the trash, from where it can be restored until it is purged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "foods"
+                ],
+                "summary": "Remove a food item from the warehouse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the pending farm invitations sent to the authenticated user's email",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Get my invitations",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "email, role, status or created_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_FarmInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Accept an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/invitations/{id}/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "farm_members"
+                ],
+                "summary": "Decline an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medical_records": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medical_records"
+                ],
+                "summary": "Create a new medical record",
+                "parameters": [
+                    {
+                        "description": "Medical Record",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicalRecordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicalRecordResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input, or medicine of another farm",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Animal or Medicine Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medical_records/animals/{animal_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medical_records"
+                ],
+                "summary": "Get medical records by Animal ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "animal_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "treatment_date, quantity or created_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_MedicalRecordDetailed"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid animal ID, date range, page or sort",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medical_records/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medical_records"
+                ],
+                "summary": "Get a medical record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicalRecordDetailed"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid record ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medical_records"
+                ],
+                "summary": "Update a medical record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Medical Record",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateMedicalRecordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a medical record to the trash and return its quantity to the medicine stock and batches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medical_records"
+                ],
+                "summary": "Delete a medical record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid record ID",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicine_batches/{id}/discard": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Write off what is left of the batch as waste, e.g. once it has expired",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Discard a medicine batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Batch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Batch is already empty",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicines": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all medicines for a specific farm",
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Get all medicines",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type the item must be suitable for",
+                        "name": "suitable_for",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only list items below their minimum threshold",
+                        "name": "below_threshold",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name (default), quantity, created_at or updated_at, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_Medicine"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new medicine to the farm",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Create a new medicine",
+                "parameters": [
+                    {
+                        "description": "Medicine Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicineReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicineResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicines/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific medicine by its ID",
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Get a medicine by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Medicine"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update details of a specific medicine",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Update an existing medicine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated Medicine Details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicineReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicineResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a medicine to the trash, from where it can be restored until it is purged",
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Delete a medicine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicines/{id}/batches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the batches in the order they are used, first expiring first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Get the batches of a medicine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include used up batches",
+                        "name": "include_empty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expiry_date, lot_number, quantity or created_at, prefixed with - for descending order; first expiring first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_MedicineBatch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stock a lot of the medicine with its expiry date. The quantity is booked as a purchase.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Add a medicine batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Batch",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicineBatchReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.MedicineBatchResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicines/{id}/movements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the inventory ledger of a medicine, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Get the stock movements of a medicine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, movement_type or quantity, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_StockMovement"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Book a purchase, waste, adjustment or transfer of a medicine. Purchases add and waste removes stock; adjustments and transfers use the sign of the quantity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Record a stock movement for a medicine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stock movement",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovementReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockMovementResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicines/{id}/reconciliation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compare the current quantity of a medicine with the sum of its stock movements",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicines"
+                ],
+                "summary": "Reconcile the stock of a medicine",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medicine ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockReconciliation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/production_records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the production records of a farm in a date range, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "production"
+                ],
+                "summary": "Get production records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format, defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "animal_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "milk, eggs or wool",
+                        "name": "product",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "produced_at, product, quantity or created_at, prefixed with - for descending order; latest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_ProductionRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record the milk, eggs or wool produced by an animal, or by the animals of a type on a farm when farm_id and animal_type are given instead. Production is rejected for dead or sold animals and, for milk and eggs, while a drug withdrawal period runs for the animal or any active animal of the group.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "production"
+                ],
+                "summary": "Record production",
+                "parameters": [
+                    {
+                        "description": "Production record",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductionRecordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductionRecordResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Animal or farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Animal not active or under withdrawal",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/production_records/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a production record",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "production"
+                ],
+                "summary": "Get a production record by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Production Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductionRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a production record to the trash, from where it can be restored until it is purged",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "production"
+                ],
+                "summary": "Delete a production record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Production Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Get the purchase orders of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Status (draft, ordered, partially_received, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, status, expected_delivery, ordered_at or received_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a draft order of foods and medicines from a supplier",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Create a purchase order",
+                "parameters": [
+                    {
+                        "description": "Purchase order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrderReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrderResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Supplier, food or medicine not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve an order with its items and the quantities received so far",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Get a purchase order by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the supplier, expected delivery, notes and items of a draft order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Update a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Purchase order",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrderDetails"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is no longer a draft",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a draft order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Delete a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is no longer a draft",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cancel an order that was not fully received. Quantities already received stay in stock.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Cancel a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is already received or cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_orders/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add delivered quantities to stock at the unit cost of their order line, in a single transaction. Without items, everything still outstanding is received.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Receive a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivered quantities",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReceivePurchaseOrderReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrderResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not awaiting delivery",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_orders/{id}/submit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a draft order as sent to the supplier. It can no longer be edited.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase_orders"
+                ],
+                "summary": "Submit a purchase order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Purchase Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Order is not a draft",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/feeding": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sum the feed consumed on a farm per day, week or month, for the whole farm or per animal, animal type or food. Quantities of foods with different units are reported separately.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get feed consumption",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format, defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket: day, week (default) or month",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "farm (default), animal, type or food",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "animal_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FeedingReportRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/feeding/conversion": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Relate the feed consumed to the weight gained over the period, for the whole farm or per animal or animal type. Only animals weighed at least twice in the period are counted. Use food_id to restrict the ratio to foods measured in the same unit as the weights.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get feed conversion ratios",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format, defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "farm (default), animal or type",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "animal_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Food ID",
+                        "name": "food_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.FeedConversion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/mortality": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count the animals that were on a farm during the period and how many of them died, were culled, sold or transferred out, for the whole farm or per animal type. The mortality rate is the percentage of these animals that died.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get mortality rates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format, defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "farm (default) or type",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.MortalityReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/production": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sum the milk, eggs and wool produced on a farm per day, week or month, for the whole farm or per animal or animal type. Quantities in different units are reported separately. Per animal, records of a group are reported under their animal type. Per type, the yield is also divided among the type's active animals.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get production yields",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format, defaults to 30 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format, defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Bucket: day, week (default) or month",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "farm (default), animal or type",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "animal_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Animal type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "milk, eggs or wool",
+                        "name": "product",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ProductionYield"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get the suppliers of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name (default) or created_at, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Create a supplier",
+                "parameters": [
+                    {
+                        "description": "Supplier",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Get a supplier by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Update a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Supplier",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierDetails"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a supplier that has no purchase orders",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "summary": "Delete a supplier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Supplier has purchase orders",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the deleted animals, foods, medicines and records of a farm, latest first, with the time each one is purged for good. Records deleted together with their animal are not listed; they come back when the animal is restored. Without farm_id, list the deleted farms the caller owns.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deleted_at, type or name, prefixed with - for descending order; latest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_TrashItem"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Farm not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/{type}/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a farm, animal, food, medicine or record out of the trash. A restored farm or animal brings back everything deleted with it. Restored feeding and medical records consume their quantity from the stock again. Restoring needs the permission that allows deleting the item; farms can only be restored by their owner.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a deleted item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "farm, animal, food, medicine, feeding_record, watering_record, medical_record or production_record",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Item not found in trash",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Animal of the record is deleted or stock is insufficient",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/treatment_events/{id}/complete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create the medical record of a pending event, decrementing the medicine stock, and schedule the next dose",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_events"
+                ],
+                "summary": "Complete a treatment event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Treatment Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Overrides for the planned treatment",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/models.CompleteTreatmentEventReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CompleteTreatmentEventResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/treatment_events/{id}/skip": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a pending event as skipped and schedule the next dose",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_events"
+                ],
+                "summary": "Skip a treatment event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Treatment Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is no longer pending",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/treatment_plans": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_plans"
+                ],
+                "summary": "Get the treatment plans of a farm",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Farm ID",
+                        "name": "farm_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of items to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name, animal_type or created_at, prefixed with - for descending order; oldest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_TreatmentPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Define a vaccination or treatment protocol applied to every animal of a type on the farm. The first dose of each animal is scheduled right away.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_plans"
+                ],
+                "summary": "Create a treatment plan",
+                "parameters": [
+                    {
+                        "description": "Treatment plan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.TreatmentPlanReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.TreatmentPlanResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Medicine not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/treatment_plans/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_plans"
+                ],
+                "summary": "Get a treatment plan by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Treatment Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TreatmentPlan"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the dosage or intervals of a plan, or pause it. Pending events keep their due date; later doses follow the new intervals.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_plans"
+                ],
+                "summary": "Update a treatment plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Treatment Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Treatment plan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateTreatmentPlanReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a plan together with its treatment events. Medical records created from completed events are kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "treatment_plans"
+                ],
+                "summary": "Delete a treatment plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Treatment Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the users visible to the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name (default), email or created_at, prefixed with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of users",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid page or sort",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve user details by their UUID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details",
+                        "schema": {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update details of a user by their UUID. Changing the password signs the user out of every session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update payload, provide password only if it is updated",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserSwag"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateUserResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid input or user ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a user by their UUID. Users who still own farms, including deleted farms that have not been purged yet, must transfer them first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID format",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "409": {
+                        "description": "User still owns farms",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/watering_records": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Record that an animal was watered and update its last watered time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watering_records"
+                ],
+                "summary": "Create a new watering record",
+                "parameters": [
+                    {
+                        "description": "Watering Record request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WateringRecordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WateringRecordResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/watering_records/animal/{animal_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watering_records"
+                ],
+                "summary": "Get all watering records for a specific animal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Animal ID",
+                        "name": "animal_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default and 200 at most",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "watered_at, quantity or created_at, prefixed with - for descending order; newest first by default",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Page-models_WateringRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/watering_records/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watering_records"
+                ],
+                "summary": "Get a watering record by its ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watering Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WateringRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watering_records"
+                ],
+                "summary": "Update a watering record by its ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watering Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Watering Record Input",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateWateringRecordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a watering record to the trash",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "watering_records"
+                ],
+                "summary": "Delete a watering record by its ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Watering Record ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MessageResp"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "403": {
+                        "description": "Access denied",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrResp"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AddFoodReq": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "min_threshold",
+                "name",
+                "quantity",
+                "suitable_for",
+                "unit_of_measure"
+            ],
+            "properties": {
+                "farm_id": {
+                    "type": "string"
+                },
+                "min_threshold": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AddFoodResp": {
+            "type": "object",
+            "properties": {
+                "food": {
+                    "$ref": "#/definitions/models.FoodWithoutTime"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Alert": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Animal": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "type",
+                "weight"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "dam_id": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "health_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_fed": {
+                    "type": "string"
+                },
+                "last_watered": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "unknown"
+                    ]
+                },
+                "sire_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.AnimalDetail": {
+            "type": "object",
+            "properties": {
+                "health_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.AnimalEvent": {
+            "type": "object",
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "buyer": {
+                    "type": "string"
+                },
+                "cause": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "from_farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "recorded_by": {
+                    "type": "string"
+                },
+                "to_farm_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AnimalEventReq": {
+            "type": "object",
+            "required": [
+                "event_type"
+            ],
+            "properties": {
+                "buyer": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "cause": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "event_type": {
+                    "type": "string",
+                    "enum": [
+                        "sold",
+                        "died",
+                        "culled",
+                        "transferred"
+                    ]
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "to_farm_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AnimalEventResp": {
+            "type": "object",
+            "properties": {
+                "animal_event": {
+                    "$ref": "#/definitions/models.AnimalEvent"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AnimalGrowth": {
+            "type": "object",
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "animal_name": {
+                    "type": "string"
+                },
+                "animal_type": {
+                    "type": "string"
+                },
+                "average_daily_gain": {
+                    "type": "number"
+                },
+                "days": {
+                    "type": "number"
+                },
+                "first_weight": {
+                    "type": "number"
+                },
+                "herd_average_daily_gain": {
+                    "type": "number"
+                },
+                "last_weight": {
+                    "type": "number"
+                },
+                "measurements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WeightMeasurement"
+                    }
+                }
+            }
+        },
+        "models.AnimalWithdrawal": {
+            "type": "object",
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "animal_name": {
+                    "type": "string"
+                },
+                "animal_type": {
+                    "type": "string"
+                },
+                "egg_withdrawal_until": {
+                    "type": "string"
+                },
+                "meat_withdrawal_until": {
+                    "type": "string"
+                },
+                "milk_withdrawal_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AnimalWithoutTime": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "type",
+                "weight"
+            ],
+            "properties": {
+                "dam_id": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "health_status": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "unknown"
+                    ]
+                },
+                "sire_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BatchUsage": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Birth": {
+            "type": "object",
+            "properties": {
+                "born_at": {
+                    "type": "string"
+                },
+                "breeding_event_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "live_count": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "offspring": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AnimalWithoutTime"
+                    }
+                },
+                "stillborn_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BirthReq": {
+            "type": "object",
+            "required": [
+                "born_at"
+            ],
+            "properties": {
+                "born_at": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "offspring": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OffspringReq"
+                    }
+                },
+                "stillborn_count": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.BirthResp": {
+            "type": "object",
+            "properties": {
+                "birth": {
+                    "$ref": "#/definitions/models.Birth"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BreedingEvent": {
+            "type": "object",
+            "properties": {
+                "birth": {
+                    "$ref": "#/definitions/models.Birth"
+                },
+                "bred_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dam_id": {
+                    "type": "string"
+                },
+                "dam_name": {
+                    "type": "string"
+                },
+                "expected_due_date": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pregnancy_checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PregnancyCheck"
+                    }
+                },
+                "sire_description": {
+                    "type": "string"
+                },
+                "sire_id": {
+                    "type": "string"
+                },
+                "sire_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BreedingEventReq": {
+            "type": "object",
+            "required": [
+                "bred_at",
+                "dam_id",
+                "method"
+            ],
+            "properties": {
+                "bred_at": {
+                    "type": "string"
+                },
+                "dam_id": {
+                    "type": "string"
+                },
+                "expected_due_date": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "natural",
+                        "artificial_insemination"
+                    ]
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "sire_description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "sire_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BreedingEventResp": {
+            "type": "object",
+            "properties": {
+                "breeding_event": {
+                    "$ref": "#/definitions/models.BreedingEvent"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompleteFeedingTaskReq": {
+            "type": "object",
+            "properties": {
+                "fed_at": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CompleteFeedingTaskResp": {
+            "type": "object",
+            "properties": {
+                "feeding_record_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompleteTreatmentEventReq": {
+            "type": "object",
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "treatment_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CompleteTreatmentEventResp": {
+            "type": "object",
+            "properties": {
+                "medical_record_id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateAnimalReq": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "type",
+                "weight"
+            ],
+            "properties": {
+                "dam_id": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "health_status": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "unknown"
+                    ]
+                },
+                "sire_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateAnimalResp": {
+            "type": "object",
+            "properties": {
+                "animal": {
+                    "$ref": "#/definitions/models.AnimalWithoutTime"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateFarmRequest": {
+            "type": "object",
+            "required": [
+                "location",
+                "name",
+                "owner_id"
+            ],
+            "properties": {
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateFarmResponse": {
+            "type": "object",
+            "properties": {
+                "farm": {
+                    "$ref": "#/definitions/models.Farm"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Descendant": {
+            "type": "object",
+            "properties": {
+                "dam_id": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "generation": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "sire_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrResp": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExpiringBatch": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "days_left": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "initial_quantity": {
+                    "type": "number"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "medicine_id": {
+                    "type": "string"
+                },
+                "medicine_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Farm": {
+            "type": "object",
+            "required": [
+                "location",
+                "name",
+                "owner_id"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FarmInvitation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "farm_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "responded_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FarmInvitationResp": {
+            "type": "object",
+            "properties": {
+                "invitation": {
+                    "$ref": "#/definitions/models.FarmInvitation"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FarmMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedConversion": {
+            "type": "object",
+            "properties": {
+                "animals": {
+                    "type": "integer"
+                },
+                "feed_consumed": {
+                    "type": "number"
+                },
+                "feed_conversion_ratio": {
+                    "type": "number"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "weight_gained": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.FeedingRecordDetailed": {
+            "type": "object",
+            "properties": {
+                "animal": {
+                    "$ref": "#/definitions/models.AnimalDetail"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fed_at": {
+                    "type": "string"
+                },
+                "feeding_record_id": {
+                    "type": "string"
+                },
+                "food": {
+                    "$ref": "#/definitions/models.FoodDetail"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "recorded_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedingRecordReq": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "fed_at",
+                "food_id",
+                "quantity"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "fed_at": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.FeedingRecordResp": {
+            "type": "object",
+            "properties": {
+                "feeding_record": {
+                    "$ref": "#/definitions/models.FeedingRecordWithoutTime"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedingRecordWithoutTime": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "fed_at",
+                "food_id",
+                "quantity"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "fed_at": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "recorded_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedingReportRow": {
+            "type": "object",
+            "properties": {
+                "feedings": {
+                    "type": "integer"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedingSchedule": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "food_id",
+                "quantity",
+                "times_of_day"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "animal_id": {
+                    "type": "string"
+                },
+                "animal_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "days_of_week": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "times_of_day": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.FeedingScheduleReq": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "food_id",
+                "quantity",
+                "times_of_day"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "animal_type": {
+                    "type": "string"
+                },
+                "days_of_week": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "times_of_day": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.FeedingScheduleResp": {
+            "type": "object",
+            "properties": {
+                "feeding_schedule": {
+                    "$ref": "#/definitions/models.FeedingSchedule"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FeedingTask": {
+            "type": "object",
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "animal_name": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "completed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "feeding_record_id": {
+                    "type": "string"
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "food_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "schedule_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Food": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "min_threshold",
+                "name",
+                "quantity",
+                "suitable_for",
+                "unit_of_measure"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "min_threshold": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FoodDetail": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FoodWithoutTime": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "min_threshold",
+                "name",
+                "quantity",
+                "suitable_for",
+                "unit_of_measure"
+            ],
+            "properties": {
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "min_threshold": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GrowthPoint": {
+            "type": "object",
+            "properties": {
+                "average_weight": {
+                    "type": "number"
+                },
+                "measurements": {
+                    "type": "integer"
+                },
+                "period_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InbreedingResult": {
+            "type": "object",
+            "properties": {
+                "coefficient": {
+                    "type": "number"
+                },
+                "common_ancestors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PedigreeAnimal"
+                    }
+                },
+                "dam_id": {
+                    "type": "string"
+                },
+                "generations": {
+                    "type": "integer"
+                },
+                "sire_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InviteMemberReq": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "manager",
+                        "worker",
+                        "veterinarian"
+                    ]
+                }
+            }
+        },
+        "models.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "models.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MarkAlertsReq": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_read": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.MarkAlertsResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.MedicalRecordDetailed": {
+            "type": "object",
+            "properties": {
+                "animal": {
+                    "$ref": "#/definitions/models.AnimalDetail"
+                },
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchUsage"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "egg_withdrawal_until": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meat_withdrawal_until": {
+                    "type": "string"
+                },
+                "medicine": {
+                    "$ref": "#/definitions/models.MedicineDetail"
+                },
+                "milk_withdrawal_until": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "recorded_by": {
+                    "type": "string"
+                },
+                "treatment_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicalRecordReq": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "medicine_id",
+                "quantity",
+                "treatment_date"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "medicine_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "treatment_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicalRecordResp": {
+            "type": "object",
+            "properties": {
+                "medical_record": {
+                    "$ref": "#/definitions/models.MedicalRecordWithoutTime"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicalRecordWithoutTime": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "medicine_id",
+                "quantity",
+                "treatment_date"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BatchUsage"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "medicine_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "recorded_by": {
+                    "type": "string"
+                },
+                "treatment_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Medicine": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "min_threshold",
+                "name",
+                "quantity",
+                "suitable_for",
+                "unit_of_measure"
+            ],
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "egg_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meat_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "milk_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_threshold": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicineBatch": {
+            "type": "object",
+            "properties": {
                 "created_at": {
                     "type": "string"
                 },
+                "expired": {
+                    "type": "boolean"
+                },
+                "expiry_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "initial_quantity": {
+                    "type": "number"
+                },
+                "lot_number": {
+                    "type": "string"
+                },
+                "medicine_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.MedicineBatchReq": {
+            "type": "object",
+            "required": [
+                "expiry_date",
+                "lot_number",
+                "quantity"
+            ],
+            "properties": {
+                "expiry_date": {
+                    "type": "string"
+                },
+                "lot_number": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "models.MedicineBatchResp": {
+            "type": "object",
+            "properties": {
+                "batch": {
+                    "$ref": "#/definitions/models.MedicineBatch"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicineDetail": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicineReq": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "min_threshold",
+                "name",
+                "quantity",
+                "suitable_for",
+                "unit_of_measure"
+            ],
+            "properties": {
+                "egg_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "meat_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "milk_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_threshold": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicineResp": {
+            "type": "object",
+            "properties": {
+                "medicine": {
+                    "$ref": "#/definitions/models.MedicineWithoutTime"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MedicineWithoutTime": {
+            "type": "object",
+            "required": [
+                "farm_id",
+                "min_threshold",
+                "name",
+                "quantity",
+                "suitable_for",
+                "unit_of_measure"
+            ],
+            "properties": {
+                "egg_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "meat_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "milk_withdrawal_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_threshold": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "suitable_for": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MessageResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MortalityReport": {
+            "type": "object",
+            "properties": {
+                "animals": {
+                    "type": "integer"
+                },
+                "culls": {
+                    "type": "integer"
+                },
+                "deaths": {
+                    "type": "integer"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "mortality_rate": {
+                    "type": "number"
+                },
+                "sales": {
+                    "type": "integer"
+                },
+                "transfers_out": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OffspringReq": {
+            "type": "object",
+            "required": [
+                "weight"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "unknown"
+                    ]
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.OverdueAnimal": {
+            "type": "object",
+            "properties": {
+                "feeding_overdue": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_fed": {
+                    "type": "string"
+                },
+                "last_watered": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "watering_overdue": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.Page-models_Alert": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Alert"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_Animal": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Animal"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_AnimalEvent": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AnimalEvent"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_AuditEntry": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuditEntry"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_ExpiringBatch": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ExpiringBatch"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_Farm": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Farm"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_FarmInvitation": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FarmInvitation"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_FarmMember": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FarmMember"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_FeedingRecordDetailed": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeedingRecordDetailed"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_FeedingSchedule": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeedingSchedule"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_FeedingTask": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeedingTask"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_Food": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Food"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_MedicalRecordDetailed": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MedicalRecordDetailed"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_Medicine": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Medicine"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_MedicineBatch": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MedicineBatch"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_ProductionRecord": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductionRecord"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrder"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_StockMovement": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockMovement"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_Supplier": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Supplier"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_TrashItem": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TrashItem"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_TreatmentEvent": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TreatmentEvent"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_TreatmentPlan": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TreatmentPlan"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_User": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.User"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Page-models_WateringRecord": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WateringRecord"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Pedigree": {
+            "type": "object",
+            "properties": {
+                "generations": {
+                    "type": "integer"
+                },
+                "inbreeding_coefficient": {
+                    "type": "number"
+                },
+                "tree": {
+                    "$ref": "#/definitions/models.PedigreeNode"
+                }
+            }
+        },
+        "models.PedigreeAnimal": {
+            "type": "object",
+            "properties": {
+                "dam_id": {
+                    "type": "string"
+                },
+                "date_of_birth": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "sire_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PedigreeNode": {
+            "type": "object",
+            "properties": {
+                "dam": {
+                    "$ref": "#/definitions/models.PedigreeNode"
+                },
+                "dam_id": {
+                    "type": "string"
+                },
                 "date_of_birth": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sex": {
+                    "type": "string"
+                },
+                "sire": {
+                    "$ref": "#/definitions/models.PedigreeNode"
+                },
+                "sire_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PregnancyCheck": {
+            "type": "object",
+            "required": [
+                "checked_at",
+                "result"
+            ],
+            "properties": {
+                "breeding_event_id": {
+                    "type": "string"
+                },
+                "checked_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "pregnant",
+                        "not_pregnant"
+                    ]
+                }
+            }
+        },
+        "models.PregnancyCheckReq": {
+            "type": "object",
+            "required": [
+                "checked_at",
+                "result"
+            ],
+            "properties": {
+                "checked_at": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "result": {
+                    "type": "string",
+                    "enum": [
+                        "pregnant",
+                        "not_pregnant"
+                    ]
+                }
+            }
+        },
+        "models.PregnancyCheckResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "pregnancy_check": {
+                    "$ref": "#/definitions/models.PregnancyCheck"
+                }
+            }
+        },
+        "models.ProductionRecord": {
+            "type": "object",
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "animal_name": {
+                    "type": "string"
+                },
+                "animal_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "farm_id": {
                     "type": "string"
                 },
-                "health_status": {
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "produced_at": {
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "recorded_by": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductionRecordReq": {
+            "type": "object",
+            "required": [
+                "product",
+                "quantity",
+                "unit"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "animal_type": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "produced_at": {
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string",
+                    "enum": [
+                        "milk",
+                        "eggs",
+                        "wool"
+                    ]
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
+        "models.ProductionRecordResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "production_record": {
+                    "$ref": "#/definitions/models.ProductionRecord"
+                }
+            }
+        },
+        "models.ProductionYield": {
+            "type": "object",
+            "properties": {
+                "animals": {
+                    "type": "integer"
+                },
+                "group_id": {
+                    "type": "string"
+                },
+                "group_name": {
+                    "type": "string"
+                },
+                "per_head": {
+                    "type": "number"
+                },
+                "period_start": {
+                    "type": "string"
+                },
+                "product": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "records": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "expected_delivery": {
+                    "type": "string"
+                },
+                "farm_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "last_fed": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderItem"
+                    }
+                },
+                "notes": {
                     "type": "string"
                 },
-                "last_watered": {
+                "ordered_at": {
+                    "type": "string"
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "supplier_name": {
+                    "type": "string"
+                },
+                "total_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PurchaseOrderDetails": {
+            "type": "object",
+            "required": [
+                "items",
+                "supplier_id"
+            ],
+            "properties": {
+                "expected_delivery": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderItemReq"
+                    }
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "supplier_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PurchaseOrderItem": {
+            "type": "object",
+            "properties": {
+                "food_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_type": {
+                    "type": "string"
+                },
+                "medicine_id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "type": {
+                "quantity": {
+                    "type": "number"
+                },
+                "received_quantity": {
+                    "type": "number"
+                },
+                "unit_cost": {
+                    "type": "number"
+                },
+                "unit_of_measure": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PurchaseOrderItemReq": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "food_id": {
                     "type": "string"
                 },
-                "updated_at": {
+                "medicine_id": {
                     "type": "string"
                 },
-                "weight": {
+                "quantity": {
                     "type": "number"
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
-        "models.AnimalDetail": {
+        "models.PurchaseOrderReq": {
             "type": "object",
+            "required": [
+                "farm_id",
+                "items",
+                "supplier_id"
+            ],
             "properties": {
-                "health_status": {
+                "expected_delivery": {
                     "type": "string"
                 },
-                "id": {
+                "farm_id": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderItemReq"
+                    }
                 },
-                "type": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "supplier_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PurchaseOrderResp": {
+            "type": "object",
+            "properties": {
+                "message": {
                     "type": "string"
                 },
-                "weight": {
-                    "type": "number"
+                "purchase_order": {
+                    "$ref": "#/definitions/models.PurchaseOrder"
                 }
             }
         },
-        "models.AnimalWithoutTime": {
+        "models.ReceiveOrderItemReq": {
             "type": "object",
             "required": [
-                "farm_id",
-                "type",
-                "weight"
+                "item_id",
+                "quantity"
             ],
             "properties": {
-                "date_of_birth": {
-                    "type": "string"
-                },
-                "farm_id": {
-                    "type": "string"
-                },
-                "health_status": {
-                    "type": "string"
-                },
-                "id": {
+                "expiry_date": {
                     "type": "string"
                 },
-                "name": {
+                "item_id": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "lot_number": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "weight": {
+                "quantity": {
                     "type": "number"
                 }
             }
         },
-        "models.CreateAnimalReq": {
+        "models.ReceivePurchaseOrderReq": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ReceiveOrderItemReq"
+                    }
+                }
+            }
+        },
+        "models.RefreshTokenReq": {
             "type": "object",
             "required": [
-                "farm_id",
-                "type",
-                "weight"
+                "refresh_token"
             ],
             "properties": {
-                "date_of_birth": {
+                "refresh_token": {
                     "type": "string"
+                }
+            }
+        },
+        "models.ReorderSuggestion": {
+            "type": "object",
+            "properties": {
+                "days_until_empty": {
+                    "type": "number"
                 },
-                "farm_id": {
+                "days_until_threshold": {
+                    "type": "number"
+                },
+                "estimated_daily_use": {
+                    "type": "number"
+                },
+                "historical_daily_use": {
+                    "type": "number"
+                },
+                "item_id": {
                     "type": "string"
                 },
-                "health_status": {
+                "item_type": {
                     "type": "string"
                 },
+                "min_threshold": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
-                "type": {
-                    "type": "string"
+                "planned_daily_use": {
+                    "type": "number"
                 },
-                "weight": {
+                "quantity": {
+                    "type": "number"
+                },
+                "suggested_quantity": {
                     "type": "number"
+                },
+                "unit_of_measure": {
+                    "type": "string"
                 }
             }
         },
-        "models.CreateAnimalResp": {
+        "models.ReproductiveHistory": {
             "type": "object",
             "properties": {
-                "animal": {
-                    "$ref": "#/definitions/models.AnimalWithoutTime"
-                },
-                "message": {
+                "animal_id": {
                     "type": "string"
+                },
+                "births": {
+                    "type": "integer"
+                },
+                "breeding_events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BreedingEvent"
+                    }
+                },
+                "live_offspring": {
+                    "type": "integer"
+                },
+                "stillborn": {
+                    "type": "integer"
                 }
             }
         },
-        "models.CreateFarmRequest": {
+        "models.SignUpRequest": {
             "type": "object",
             "required": [
-                "location",
+                "email",
                 "name",
-                "owner_id"
+                "password",
+                "phone_number"
             ],
             "properties": {
-                "location": {
+                "email": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "owner_id": {
-                    "type": "string"
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "phone_number": {
+                    "type": "string",
+                    "minLength": 9
                 }
             }
         },
-        "models.CreateFarmResponse": {
+        "models.SignUpResponse": {
             "type": "object",
             "properties": {
-                "farm": {
-                    "$ref": "#/definitions/models.Farm"
+                "expires_in": {
+                    "type": "integer"
                 },
                 "message": {
                     "type": "string"
-                }
-            }
-        },
-        "models.ErrResp": {
-            "type": "object",
-            "properties": {
-                "error": {
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "models.Farm": {
+        "models.StockForecast": {
             "type": "object",
-            "required": [
-                "location",
-                "name",
-                "owner_id"
-            ],
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "days_until_empty": {
+                    "type": "number"
                 },
-                "id": {
+                "days_until_threshold": {
+                    "type": "number"
+                },
+                "estimated_daily_use": {
+                    "type": "number"
+                },
+                "historical_daily_use": {
+                    "type": "number"
+                },
+                "item_id": {
                     "type": "string"
                 },
-                "location": {
+                "item_type": {
                     "type": "string"
                 },
+                "min_threshold": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
-                "owner_id": {
+                "planned_daily_use": {
+                    "type": "number"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "unit_of_measure": {
                     "type": "string"
                 }
             }
         },
-        "models.FeedingRecordDetailed": {
+        "models.StockMovement": {
             "type": "object",
             "properties": {
-                "animal": {
-                    "$ref": "#/definitions/models.AnimalDetail"
+                "balance_after": {
+                    "type": "number"
                 },
                 "created_at": {
                     "type": "string"
                 },
-                "fed_at": {
+                "created_by": {
                     "type": "string"
                 },
-                "feeding_record_id": {
+                "farm_id": {
                     "type": "string"
                 },
-                "food": {
-                    "$ref": "#/definitions/models.FoodDetail"
+                "id": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "string"
+                },
+                "item_type": {
+                    "type": "string"
+                },
+                "movement_type": {
+                    "type": "string"
                 },
                 "notes": {
                     "type": "string"
                 },
                 "quantity": {
                     "type": "number"
+                },
+                "reference_id": {
+                    "type": "string"
+                },
+                "unit_cost": {
+                    "type": "number"
                 }
             }
         },
-        "models.FeedingRecordReq": {
+        "models.StockMovementReq": {
             "type": "object",
             "required": [
-                "animal_id",
-                "fed_at",
-                "food_id",
+                "movement_type",
                 "quantity"
             ],
             "properties": {
-                "animal_id": {
-                    "type": "string"
-                },
-                "fed_at": {
-                    "type": "string"
-                },
-                "food_id": {
-                    "type": "string"
+                "movement_type": {
+                    "type": "string",
+                    "enum": [
+                        "purchase",
+                        "adjustment",
+                        "waste",
+                        "transfer"
+                    ]
                 },
                 "notes": {
-                    "type": "string",
-                    "maxLength": 500
+                    "type": "string"
                 },
                 "quantity": {
                     "type": "number"
+                },
+                "unit_cost": {
+                    "type": "number",
+                    "minimum": 0
                 }
             }
         },
-        "models.FeedingRecordResp": {
+        "models.StockMovementResp": {
             "type": "object",
             "properties": {
-                "feeding_record": {
-                    "$ref": "#/definitions/models.FeedingRecordWithoutTime"
-                },
                 "message": {
                     "type": "string"
+                },
+                "movement": {
+                    "$ref": "#/definitions/models.StockMovement"
                 }
             }
         },
-        "models.FeedingRecordWithoutTime": {
+        "models.StockReconciliation": {
             "type": "object",
-            "required": [
-                "animal_id",
-                "fed_at",
-                "food_id",
-                "quantity"
-            ],
             "properties": {
-                "animal_id": {
-                    "type": "string"
+                "consistent": {
+                    "type": "boolean"
                 },
-                "fed_at": {
-                    "type": "string"
+                "difference": {
+                    "type": "number"
                 },
-                "food_id": {
+                "item_id": {
                     "type": "string"
                 },
-                "id": {
+                "item_type": {
                     "type": "string"
                 },
-                "notes": {
-                    "type": "string",
-                    "maxLength": 500
+                "ledger_total": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
                 },
                 "quantity": {
                     "type": "number"
                 }
             }
         },
-        "models.Food": {
+        "models.Supplier": {
             "type": "object",
             "required": [
                 "farm_id",
-                "min_threshold",
-                "name",
-                "quantity",
-                "suitable_for",
-                "unit_of_measure"
+                "name"
             ],
             "properties": {
-                "created_at": {
+                "address": {
                     "type": "string"
                 },
-                "farm_id": {
+                "contact_name": {
                     "type": "string"
                 },
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "min_threshold": {
-                    "type": "number"
-                },
-                "name": {
+                "email": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
-                },
-                "suitable_for": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "unit_of_measure": {
+                "farm_id": {
                     "type": "string"
                 },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.FoodDetail": {
-            "type": "object",
-            "properties": {
                 "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "suitable_for": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "unit_of_measure": {
-                    "type": "string"
+                "phone_number": {
+                    "type": "string",
+                    "maxLength": 20
                 }
             }
         },
-        "models.FoodWithoutTime": {
+        "models.SupplierDetails": {
             "type": "object",
             "required": [
-                "farm_id",
-                "min_threshold",
-                "name",
-                "quantity",
-                "suitable_for",
-                "unit_of_measure"
+                "name"
             ],
             "properties": {
-                "farm_id": {
+                "address": {
                     "type": "string"
                 },
-                "id": {
+                "contact_name": {
                     "type": "string"
                 },
-                "min_threshold": {
-                    "type": "number"
+                "email": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
-                },
-                "suitable_for": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
                 },
-                "unit_of_measure": {
-                    "type": "string"
+                "phone_number": {
+                    "type": "string",
+                    "maxLength": 20
                 }
             }
         },
-        "models.LoginRequest": {
+        "models.SupplierReq": {
             "type": "object",
             "required": [
-                "email",
-                "password"
+                "farm_id",
+                "name"
             ],
             "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "contact_name": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
-                "password": {
+                "farm_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
                     "type": "string",
-                    "minLength": 6
+                    "maxLength": 500
+                },
+                "phone_number": {
+                    "type": "string",
+                    "maxLength": 20
                 }
             }
         },
-        "models.LoginResponse": {
+        "models.SupplierResp": {
             "type": "object",
             "properties": {
-                "token": {
+                "message": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "supplier": {
+                    "$ref": "#/definitions/models.Supplier"
                 }
             }
         },
-        "models.MedicalRecordDetailed": {
+        "models.TrashItem": {
             "type": "object",
             "properties": {
-                "animal": {
-                    "$ref": "#/definitions/models.AnimalDetail"
+                "deleted_at": {
+                    "type": "string"
                 },
-                "created_at": {
+                "farm_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "medicine": {
-                    "$ref": "#/definitions/models.MedicineDetail"
-                },
-                "notes": {
+                "name": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
+                "purge_at": {
+                    "type": "string"
                 },
-                "treatment_date": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "models.MedicalRecordReq": {
+        "models.TreatmentEvent": {
             "type": "object",
-            "required": [
-                "animal_id",
-                "medicine_id",
-                "quantity",
-                "treatment_date"
-            ],
             "properties": {
                 "animal_id": {
                     "type": "string"
                 },
+                "animal_name": {
+                    "type": "string"
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dose_number": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "farm_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "medical_record_id": {
+                    "type": "string"
+                },
                 "medicine_id": {
                     "type": "string"
                 },
-                "notes": {
-                    "type": "string",
-                    "maxLength": 500
+                "medicine_name": {
+                    "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
+                "overdue": {
+                    "type": "boolean"
                 },
-                "treatment_date": {
+                "plan_id": {
                     "type": "string"
-                }
-            }
-        },
-        "models.MedicalRecordResp": {
-            "type": "object",
-            "properties": {
-                "medical_record": {
-                    "$ref": "#/definitions/models.MedicalRecordWithoutTime"
                 },
-                "message": {
+                "plan_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "models.MedicalRecordWithoutTime": {
+        "models.TreatmentPlan": {
             "type": "object",
             "required": [
-                "animal_id",
+                "animal_type",
+                "dose_count",
+                "farm_id",
                 "medicine_id",
-                "quantity",
-                "treatment_date"
+                "name",
+                "quantity"
             ],
             "properties": {
-                "animal_id": {
+                "active": {
+                    "type": "boolean"
+                },
+                "animal_type": {
+                    "type": "string"
+                },
+                "booster_interval_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dose_count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "dose_interval_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "farm_id": {
                     "type": "string"
                 },
+                "first_dose_age_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "id": {
                     "type": "string"
                 },
                 "medicine_id": {
                     "type": "string"
                 },
+                "name": {
+                    "type": "string"
+                },
                 "notes": {
                     "type": "string",
                     "maxLength": 500
                 },
                 "quantity": {
                     "type": "number"
-                },
-                "treatment_date": {
-                    "type": "string"
                 }
             }
         },
-        "models.Medicine": {
+        "models.TreatmentPlanReq": {
             "type": "object",
             "required": [
+                "animal_type",
+                "dose_count",
                 "farm_id",
-                "min_threshold",
+                "medicine_id",
                 "name",
-                "quantity",
-                "suitable_for",
-                "unit_of_measure"
+                "quantity"
             ],
             "properties": {
-                "created_at": {
+                "animal_type": {
                     "type": "string"
                 },
+                "booster_interval_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "dose_count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "dose_interval_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "farm_id": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "first_dose_age_days": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "min_threshold": {
-                    "type": "number"
+                "medicine_id": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
                 "quantity": {
                     "type": "number"
-                },
-                "suitable_for": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "unit_of_measure": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
                 }
             }
         },
-        "models.MedicineDetail": {
+        "models.TreatmentPlanResp": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
-                },
-                "name": {
+                "message": {
                     "type": "string"
                 },
-                "suitable_for": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "unit_of_measure": {
-                    "type": "string"
+                "treatment_plan": {
+                    "$ref": "#/definitions/models.TreatmentPlan"
                 }
             }
         },
-        "models.MedicineReq": {
+        "models.TypeGrowth": {
             "type": "object",
-            "required": [
-                "farm_id",
-                "min_threshold",
-                "name",
-                "quantity",
-                "suitable_for",
-                "unit_of_measure"
-            ],
             "properties": {
-                "farm_id": {
-                    "type": "string"
-                },
-                "min_threshold": {
-                    "type": "number"
-                },
-                "name": {
-                    "type": "string"
+                "animals": {
+                    "type": "integer"
                 },
-                "quantity": {
+                "average_daily_gain": {
                     "type": "number"
                 },
-                "suitable_for": {
+                "curve": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.GrowthPoint"
                     }
                 },
-                "unit_of_measure": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "models.MedicineResp": {
+        "models.UpcomingBirth": {
             "type": "object",
             "properties": {
-                "medicine": {
-                    "$ref": "#/definitions/models.MedicineWithoutTime"
+                "birth": {
+                    "$ref": "#/definitions/models.Birth"
                 },
-                "message": {
+                "bred_at": {
                     "type": "string"
-                }
-            }
-        },
-        "models.MedicineWithoutTime": {
-            "type": "object",
-            "required": [
-                "farm_id",
-                "min_threshold",
-                "name",
-                "quantity",
-                "suitable_for",
-                "unit_of_measure"
-            ],
-            "properties": {
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dam_id": {
+                    "type": "string"
+                },
+                "dam_name": {
+                    "type": "string"
+                },
+                "days_left": {
+                    "type": "integer"
+                },
+                "expected_due_date": {
+                    "type": "string"
+                },
                 "farm_id": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "min_threshold": {
-                    "type": "number"
-                },
-                "name": {
+                "method": {
                     "type": "string"
                 },
-                "quantity": {
-                    "type": "number"
+                "notes": {
+                    "type": "string"
                 },
-                "suitable_for": {
+                "pregnancy_checks": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/models.PregnancyCheck"
                     }
                 },
-                "unit_of_measure": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.MessageResp": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.SignUpRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password",
-                "phone_number"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "name": {
+                "sire_description": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
-                },
-                "phone_number": {
-                    "type": "string",
-                    "minLength": 9
-                }
-            }
-        },
-        "models.SignUpResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
+                "sire_id": {
                     "type": "string"
                 },
-                "token": {
+                "sire_name": {
                     "type": "string"
                 },
-                "user_id": {
+                "status": {
                     "type": "string"
                 }
             }
@@ -2578,6 +12465,9 @@ const docTemplate = `{
                 "weight"
             ],
             "properties": {
+                "dam_id": {
+                    "type": "string"
+                },
                 "date_of_birth": {
                     "type": "string"
                 },
@@ -2596,6 +12486,17 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "sex": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "unknown"
+                    ]
+                },
+                "sire_id": {
+                    "type": "string"
+                },
                 "type": {
                     "type": "string"
                 },
@@ -2668,6 +12569,38 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateFeedingScheduleReq": {
+            "type": "object",
+            "required": [
+                "food_id",
+                "quantity",
+                "times_of_day"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "days_of_week": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "food_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "times_of_day": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "models.UpdateFoodReq": {
             "type": "object",
             "required": [
@@ -2736,6 +12669,72 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateMemberRoleReq": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "manager",
+                        "worker",
+                        "veterinarian"
+                    ]
+                }
+            }
+        },
+        "models.UpdateMemberRoleResp": {
+            "type": "object",
+            "properties": {
+                "member": {
+                    "$ref": "#/definitions/models.FarmMember"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdateTreatmentPlanReq": {
+            "type": "object",
+            "required": [
+                "dose_count",
+                "name",
+                "quantity"
+            ],
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "booster_interval_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "dose_count": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "dose_interval_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "first_dose_age_days": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
         "models.UpdateUser": {
             "type": "object",
             "required": [
@@ -2796,6 +12795,25 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateWateringRecordReq": {
+            "type": "object",
+            "required": [
+                "watered_at"
+            ],
+            "properties": {
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "watered_at": {
+                    "type": "string"
+                }
+            }
+        },
         "models.User": {
             "type": "object",
             "required": [
@@ -2826,6 +12844,147 @@ const docTemplate = `{
                     "minLength": 9
                 }
             }
+        },
+        "models.WateringRecord": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "watered_at"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "watered_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WateringRecordReq": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "watered_at"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "watered_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WateringRecordResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "watering_record": {
+                    "$ref": "#/definitions/models.WateringRecordWithoutTime"
+                }
+            }
+        },
+        "models.WateringRecordWithoutTime": {
+            "type": "object",
+            "required": [
+                "animal_id",
+                "watered_at"
+            ],
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "quantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "watered_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.WeightMeasurement": {
+            "type": "object",
+            "properties": {
+                "animal_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "measured_at": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.WeightMeasurementReq": {
+            "type": "object",
+            "required": [
+                "weight"
+            ],
+            "properties": {
+                "measured_at": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.WeightMeasurementResp": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "weight_measurement": {
+                    "$ref": "#/definitions/models.WeightMeasurement"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// currentUserID returns the ID of the authenticated caller set by the auth middleware.
+func currentUserID(c *gin.Context) uuid.UUID {
+	userID, _ := c.Get("userId")
+	id, _ := userID.(uuid.UUID)
+	return id
+}
+
+// authorize writes the matching error response when an access check failed and
+// reports whether the handler may continue.
+func (h *Handler) authorize(c *gin.Context, err error) bool {
+	if err == nil {
+		return true
+	}
+
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrFarmNotFound),
+		errors.Is(err, services.ErrAnimalNotFound),
+		errors.Is(err, services.ErrFoodNotFound),
+		errors.Is(err, services.ErrMedicineNotExist),
+		errors.Is(err, repository.ErrRecordNotFound),
+		errors.Is(err, repository.ErrWateringRecordNotFound),
+		errors.Is(err, repository.ErrFeedingScheduleNotFound),
+		errors.Is(err, repository.ErrFeedingTaskNotFound),
+		errors.Is(err, repository.ErrMedicalRecordNotFound),
+		errors.Is(err, repository.ErrWeightMeasurementNotFound),
+		errors.Is(err, repository.ErrTreatmentPlanNotFound),
+		errors.Is(err, repository.ErrTreatmentEventNotFound),
+		errors.Is(err, repository.ErrSupplierNotFound),
+		errors.Is(err, repository.ErrMedicineBatchNotFound),
+		errors.Is(err, repository.ErrBreedingEventNotFound),
+		errors.Is(err, repository.ErrProductionRecordNotFound),
+		errors.Is(err, repository.ErrPurchaseOrderNotFound),
+		errors.Is(err, repository.ErrAlertNotFound),
+		errors.Is(err, repository.ErrTrashItemNotFound),
+		errors.Is(err, repository.ErrAuditHistoryNotFound),
+		errors.Is(err, repository.ErrStockItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Get alerts
+// @Description Retrieve alerts, optionally filtered by farm, type and read status
+// @Tags alerts
+// @Produce application/json
+// @Param farm_id query string false "Farm ID"
+// @Param type query string false "Alert type"
+// @Param unread query bool false "Only unread alerts"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "created_at or type, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.Alert]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /alerts [get]
+func (h *Handler) GetAlerts(c *gin.Context) {
+	filter := models.AlertFilter{
+		Type:       c.Query("type"),
+		UnreadOnly: c.Query("unread") == "true",
+	}
+	var page models.PageQuery
+	if !bindPage(c, &page, models.AlertSortFields) {
+		return
+	}
+
+	userID := currentUserID(c)
+	if farmIDParam := c.Query("farm_id"); farmIDParam != "" {
+		farmID, err := uuid.Parse(farmIDParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+			return
+		}
+		if !h.authorize(c, h.accessService.CheckFarmAccess(userID, farmID, services.PermViewFarm)) {
+			return
+		}
+		filter.FarmIDs = []uuid.UUID{farmID}
+	} else {
+		farmIDs, err := h.accessService.GetAccessibleFarmIDs(userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		filter.FarmIDs = farmIDs
+	}
+
+	alerts, total, err := h.alertService.GetAlerts(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, alerts, total)
+}
+
+// @Summary Mark alerts as read or unread
+// @Description Set the read status of several alerts at once
+// @Tags alerts
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.MarkAlertsReq true "Alert IDs and read status"
+// @Success 200 {object} models.MarkAlertsResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /alerts/read [put]
+func (h *Handler) MarkAlerts(c *gin.Context) {
+	var req models.MarkAlertsReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	for _, alertID := range req.IDs {
+		if !h.authorize(c, h.accessService.CheckAlertAccess(currentUserID(c), alertID, services.PermViewFarm)) {
+			return
+		}
+	}
+
+	updated, err := h.alertService.MarkAlerts(&req, currentActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alerts updated successfully", "updated": updated})
+}
+
+// @Summary Dismiss an alert
+// @Description Remove an alert by its ID
+// @Tags alerts
+// @Produce application/json
+// @Param id path string true "Alert ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /alerts/{id} [delete]
+func (h *Handler) DismissAlert(c *gin.Context) {
+	alertID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAlertAccess(currentUserID(c), alertID, services.PermManageAlerts)) {
+		return
+	}
+
+	if err := h.alertService.DismissAlert(alertID, currentActor(c)); err != nil {
+		if errors.Is(err, repository.ErrAlertNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Alert dismissed successfully"})
+}
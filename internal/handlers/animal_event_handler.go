@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// animalEventError writes the response for a failed lifecycle event.
+func (h *Handler) animalEventError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrBuyerRequired),
+		errors.Is(err, services.ErrCauseRequired),
+		errors.Is(err, services.ErrTargetFarmRequired),
+		errors.Is(err, services.ErrSameFarm):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAnimalInactive),
+		errors.Is(err, repository.ErrAnimalNotActive),
+		errors.Is(err, services.ErrAnimalUnderWithdrawal):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.authorize(c, err)
+	}
+}
+
+// @Summary Record a lifecycle event
+// @Description Record that an active animal was sold, died, was culled or was transferred to another farm. Sold, dead and culled animals leave the active herd; transferred animals stay active on their new farm, which the caller must also manage. The animal's records are kept. Sales and cullings are rejected while a meat withdrawal period runs.
+// @Tags animals
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param request body models.AnimalEventReq true "Lifecycle event"
+// @Success 201 {object} models.AnimalEventResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Animal or farm not found"
+// @Failure 409 {object} models.ErrResp "Animal not active or under withdrawal"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/events [post]
+func (h *Handler) CreateAnimalEvent(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	var req models.AnimalEventReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(userID, animalID, services.PermManageAnimals)) {
+		return
+	}
+	if req.EventType == models.AnimalEventTransferred && req.ToFarmID != nil {
+		if !h.authorize(c, h.accessService.CheckFarmAccess(userID, *req.ToFarmID, services.PermManageAnimals)) {
+			return
+		}
+	}
+
+	event, err := h.animalEventService.CreateEvent(animalID, &req, userID, currentActor(c))
+	if err != nil {
+		h.animalEventError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "animal event recorded successfully", "animal_event": event})
+}
+
+// @Summary Get the lifecycle events of an animal
+// @Description Retrieve the sales, death, culling and transfers of an animal in the order they occurred
+// @Tags animals
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "occurred_at, event_type or created_at, prefixed with - for descending order; oldest first by default"
+// @Success 200 {object} models.Page[models.AnimalEvent]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Animal not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/events [get]
+func (h *Handler) GetAnimalEvents(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.AnimalEventSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermViewFarm)) {
+		return
+	}
+
+	events, total, err := h.animalEventService.GetEventsByAnimalID(animalID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, events, total)
+}
+
+// @Summary Get mortality rates
+// @Description Count the animals that were on a farm during the period and how many of them died, were culled, sold or transferred out, for the whole farm or per animal type. The mortality rate is the percentage of these animals that died.
+// @Tags reports
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param from query string false "First day in YYYY-MM-DD format, defaults to 30 days before to"
+// @Param to query string false "Last day in YYYY-MM-DD format, defaults to today"
+// @Param group_by query string false "farm (default) or type"
+// @Param type query string false "Animal type"
+// @Success 200 {array} models.MortalityReport
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /reports/mortality [get]
+func (h *Handler) GetMortalityReport(c *gin.Context) {
+	var filter models.ReportFilter
+	if !bindReportFilter(c, &filter) {
+		return
+	}
+
+	filter.GroupBy = c.DefaultQuery("group_by", models.ReportGroupFarm)
+	switch filter.GroupBy {
+	case models.ReportGroupFarm, models.ReportGroupType:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group_by must be one of farm, type"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	report, err := h.animalEventService.GetMortality(&filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
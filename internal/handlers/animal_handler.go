@@ -4,6 +4,7 @@ import (
 	"farmish/internal/models"
 	"farmish/internal/services"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -17,6 +18,7 @@ import (
 // @Param request body models.CreateAnimalReq true "Animal data"
 // @Success 201 {object} models.CreateAnimalResp
 // @Failure 400 {object} models.ErrResp "Invalid input"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
 // @Router /animals [post]
@@ -27,9 +29,15 @@ func (h *Handler) CreateAnimal(c *gin.Context) {
 		return
 	}
 
-	if err := h.animalService.CreateAnimal(&animal); err != nil {
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), animal.FarmID, services.PermManageAnimals)) {
+		return
+	}
+
+	if err := h.animalService.CreateAnimal(&animal, currentActor(c)); err != nil {
 		if err == services.ErrNegativeWeight {
 			c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrNegativeWeight})
+		} else if isParentageError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -50,6 +58,7 @@ func (h *Handler) CreateAnimal(c *gin.Context) {
 // @Param id path string true "Animal ID(UUID)"
 // @Success 200 {object} models.Animal
 // @Failure 400 {object} models.ErrResp "Invalid animal ID"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp "Animal not found"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
@@ -62,6 +71,10 @@ func (h *Handler) GetAnimalByID(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermViewFarm)) {
+		return
+	}
+
 	animal, err := h.animalService.GetAnimalByID(animalID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -77,30 +90,71 @@ func (h *Handler) GetAnimalByID(c *gin.Context) {
 }
 
 // @Summary Get animals by farm ID
-// @Description Retrieve a list of animals for a specific farm
+// @Description Retrieve a list of animals for a specific farm. Only active animals are listed unless another status is asked for.
 // @Tags animals
 // @Produce application/json
 // @Param farm_id query string true "Farm ID"
-// @Success 200 {array} models.Animal
-// @Failure 400 {object} models.ErrResp "Invalid farm ID"
+// @Param status query string false "active (default), dead, sold, culled or all"
+// @Param type query string false "Animal type"
+// @Param health_status query string false "Health status"
+// @Param min_age query int false "Minimum age in days"
+// @Param max_age query int false "Maximum age in days"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of animals to skip"
+// @Param sort query string false "name (default), type, weight, health_status, date_of_birth or created_at, prefixed with - for descending order"
+// @Success 200 {object} models.Page[models.Animal]
+// @Failure 400 {object} models.ErrResp "Invalid farm ID, filter, page or sort"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object}  models.ErrResp "Internal server error"
 // @Security BearerAuth
 // @Router /animals [get]
 func (h *Handler) GetAnimalsByFarmID(c *gin.Context) {
-	farmIDParam := c.Query("farm_id")
-	farmID, err := uuid.Parse(farmIDParam)
-	if err != nil {
+	var filter models.AnimalFilter
+	var err error
+	if filter.FarmID, err = uuid.Parse(c.Query("farm_id")); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid farm ID"})
 		return
 	}
 
-	animals, err := h.animalService.GetAnimalsByFarmID(farmID)
+	filter.Status = c.DefaultQuery("status", models.AnimalActive)
+	switch filter.Status {
+	case models.AnimalActive, models.AnimalDead, models.AnimalSold, models.AnimalCulled:
+	case "all":
+		filter.Status = ""
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "status must be one of active, dead, sold, culled, all"})
+		return
+	}
+	filter.Type = c.Query("type")
+	filter.HealthStatus = c.Query("health_status")
+	var ok bool
+	if filter.MinAge, ok = daysQuery(c, "min_age"); !ok {
+		return
+	}
+	if filter.MaxAge, ok = daysQuery(c, "max_age"); !ok {
+		return
+	}
+	if filter.MinAge != nil && filter.MaxAge != nil && *filter.MinAge > *filter.MaxAge {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "min_age must not be greater than max_age"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.AnimalSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	animals, total, err := h.animalService.GetAnimalsByFarmID(&filter, &page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, animals)
+	respondPage(c, &page, animals, total)
 }
 
 // @Summary Update an animal
@@ -111,6 +165,7 @@ func (h *Handler) GetAnimalsByFarmID(c *gin.Context) {
 // @Param request body models.UpdateAnimalReq true "Updated animal data"
 // @Success 200 {object} models.UpdateAnimalResp
 // @Failure 400 {object} models.ErrResp "Invalid input"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
 // @Router /animals [put]
@@ -121,9 +176,17 @@ func (h *Handler) UpdateAnimal(c *gin.Context) {
 		return
 	}
 
-	if err := h.animalService.UpdateAnimal(&animal); err != nil {
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animal.ID, services.PermEditAnimals)) {
+		return
+	}
+
+	if err := h.animalService.UpdateAnimal(&animal, currentActor(c)); err != nil {
 		if err == services.ErrNegativeWeight {
 			c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrNegativeWeight})
+		} else if isParentageError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else if err == services.ErrAnimalNotFound {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -134,11 +197,12 @@ func (h *Handler) UpdateAnimal(c *gin.Context) {
 }
 
 // @Summary Delete an animal
-// @Description Remove an animal from the system
+// @Description Move an animal and all of its records to the trash, from where they can be restored until they are purged. Record a lifecycle event instead to keep its history.
 // @Tags animals
 // @Produce application/json
 // @Param id path string true "Animal ID"
 // @Success		200		{object}	models.MessageResp	"Animal deleted successfully"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
 // @Router /animals/{id} [delete]
@@ -150,10 +214,67 @@ func (h *Handler) DeleteAnimal(c *gin.Context) {
 		return
 	}
 
-	if err := h.animalService.DeleteAnimal(animalID); err != nil {
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermManageAnimals)) {
+		return
+	}
+
+	if err := h.animalService.DeleteAnimal(animalID, currentActor(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Animal deleted successfully"})
 }
+
+// @Summary Get overdue animals of a farm
+// @Description List animals that were not fed or watered within the expected interval. The intervals default to the server configuration.
+// @Tags farms
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param feeding_interval query string false "Feeding interval, e.g. 24h"
+// @Param watering_interval query string false "Watering interval, e.g. 12h"
+// @Success 200 {array} models.OverdueAnimal
+// @Failure 400 {object} models.ErrResp "Invalid farm ID or interval"
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp "Internal server error"
+// @Security BearerAuth
+// @Router /farms/{id}/overdue [get]
+func (h *Handler) GetOverdueAnimals(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid farm ID"})
+		return
+	}
+
+	var feedingInterval, wateringInterval time.Duration
+	if value := c.Query("feeding_interval"); value != "" {
+		if feedingInterval, err = time.ParseDuration(value); err != nil || feedingInterval <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid feeding interval"})
+			return
+		}
+	}
+	if value := c.Query("watering_interval"); value != "" {
+		if wateringInterval, err = time.ParseDuration(value); err != nil || wateringInterval <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid watering interval"})
+			return
+		}
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	animals, err := h.animalService.GetOverdueAnimals(farmID, feedingInterval, wateringInterval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, animals)
+}
+
+// isParentageError reports whether err rejects the sire or dam of an animal.
+func isParentageError(err error) bool {
+	return err == services.ErrInvalidSire || err == services.ErrInvalidDam || err == services.ErrParentCycle
+}
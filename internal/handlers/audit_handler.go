@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// currentActor returns who the changes made by the request are audited under.
+func currentActor(c *gin.Context) *models.AuditActor {
+	return &models.AuditActor{UserID: currentUserID(c), RequestID: c.GetString("requestId")}
+}
+
+// @Summary Get the audit history of an entity
+// @Description List who created, changed, deleted or restored an entity and which fields changed, latest first. Needs the view_audit permission on the farm the entity belonged to last; users can only see the history of their own account.
+// @Tags audit
+// @Produce application/json
+// @Param entity query string true "Entity type, e.g. animal, food or feeding_record"
+// @Param id query string true "Entity ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "created_at, entity_type or action, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.AuditEntry]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "No audit history found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /audit [get]
+func (h *Handler) GetAuditHistory(c *gin.Context) {
+	entityType := c.Query("entity")
+	if !h.auditService.IsAuditedType(entityType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entity type"})
+		return
+	}
+
+	entityID, err := uuid.Parse(c.Query("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entity ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.AuditSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAuditAccess(currentUserID(c), entityType, entityID)) {
+		return
+	}
+
+	entries, total, err := h.auditService.GetEntityHistory(entityType, entityID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, entries, total)
+}
+
+// @Summary Get the audit timeline of a farm
+// @Description List the changes made on a farm, latest first, optionally narrowed down to one entity type, one user and a date range.
+// @Tags audit
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param entity query string false "Entity type, e.g. animal, food or feeding_record"
+// @Param user_id query string false "ID of the user who made the changes"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "created_at, entity_type or action, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.AuditEntry]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/audit [get]
+func (h *Handler) GetFarmAuditTimeline(c *gin.Context) {
+	var filter models.AuditFilter
+	var err error
+	if filter.FarmID, err = uuid.Parse(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	if filter.EntityType = c.Query("entity"); filter.EntityType != "" && !h.auditService.IsAuditedType(filter.EntityType) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid entity type"})
+		return
+	}
+	if value := c.Query("user_id"); value != "" {
+		if filter.UserID, err = uuid.Parse(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+			return
+		}
+	}
+	var page models.PageQuery
+	if !bindDateRange(c, &filter.From, &filter.To) || !bindPage(c, &page, models.AuditSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewAudit)) {
+		return
+	}
+
+	entries, total, err := h.auditService.GetFarmTimeline(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, entries, total)
+}
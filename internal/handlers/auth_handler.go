@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // @Summary		User login
@@ -38,7 +39,7 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": resp.Token, "user_id": resp.ID})
+	c.JSON(http.StatusOK, resp)
 }
 
 // @Summary User sign-up
@@ -64,7 +65,7 @@ func (h *Handler) SignUp(c *gin.Context) {
 		return
 	}
 
-	token, err := h.userService.SignUp(&user)
+	resp, err := h.userService.SignUp(&user, currentActor(c))
 	if err != nil {
 		if err == repository.ErrEmailAlreadyInUse {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
@@ -75,8 +76,80 @@ func (h *Handler) SignUp(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"message": "User created successfully",
-		"token":   token,
-		"user_id": user.ID,
+		"message":       "User created successfully",
+		"token":         resp.Token,
+		"refresh_token": resp.RefreshToken,
+		"expires_in":    resp.ExpiresIn,
+		"user_id":       resp.ID,
 	})
 }
+
+// @Summary		Refresh tokens
+// @Description	Exchange a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing one revokes the whole session.
+// @Tags			auth
+// @Accept			application/json
+// @Produce		application/json
+// @Param			request	body		models.RefreshTokenReq	true	"Refresh token"
+// @Success		200		{object}	models.LoginResponse
+// @Failure		400		{object}	models.ErrResp		"Invalid input"
+// @Failure		401		{object}	models.ErrResp		"Invalid, expired or reused refresh token"
+// @Failure		500		{object}	models.ErrResp		"Internal server error"
+// @Router			/auth/refresh [post]
+func (h *Handler) RefreshToken(c *gin.Context) {
+	var req models.RefreshTokenReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	resp, err := h.userService.RefreshTokens(req.RefreshToken)
+	if err != nil {
+		if err == services.ErrInvalidRefreshToken || err == services.ErrRefreshTokenReused {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// @Summary		Logout
+// @Description	Revoke the current session so its access and refresh tokens stop working.
+// @Tags			auth
+// @Produce		application/json
+// @Success		200		{object}	models.MessageResp
+// @Failure		401		{object}	models.ErrResp
+// @Failure		500		{object}	models.ErrResp
+// @Security		BearerAuth
+// @Router			/auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	sessionID, _ := c.Get("sessionId")
+	id, _ := sessionID.(uuid.UUID)
+
+	if err := h.userService.Logout(id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// @Summary		Logout from all devices
+// @Description	Revoke every session of the authenticated user.
+// @Tags			auth
+// @Produce		application/json
+// @Success		200		{object}	models.MessageResp
+// @Failure		401		{object}	models.ErrResp
+// @Failure		500		{object}	models.ErrResp
+// @Security		BearerAuth
+// @Router			/auth/logout-all [post]
+func (h *Handler) LogoutAll(c *gin.Context) {
+	if err := h.userService.LogoutAll(currentUserID(c)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out from all sessions"})
+}
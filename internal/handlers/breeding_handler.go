@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// breedingError writes the response for a failed breeding action.
+func (h *Handler) breedingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidSire),
+		errors.Is(err, services.ErrInvalidDam),
+		errors.Is(err, services.ErrUnknownGestation),
+		errors.Is(err, services.ErrDueBeforeBreeding),
+		errors.Is(err, services.ErrEmptyBirth):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrBreedingDelivered):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.authorize(c, err)
+	}
+}
+
+// @Summary Record a breeding event
+// @Description Record a mating or insemination of a female. Without an expected due date, it is computed from the gestation length of the animal type.
+// @Tags breeding
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.BreedingEventReq true "Breeding event"
+// @Success 201 {object} models.BreedingEventResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Dam not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /breeding_events [post]
+func (h *Handler) CreateBreedingEvent(c *gin.Context) {
+	var req models.BreedingEventReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(userID, req.DamID, services.PermManageBreeding)) {
+		return
+	}
+
+	event, err := h.breedingService.CreateEvent(&req, userID, currentActor(c))
+	if err != nil {
+		h.breedingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "breeding event recorded", "breeding_event": event})
+}
+
+// @Summary Get a breeding event by ID
+// @Description Retrieve a breeding event with its pregnancy checks and birth
+// @Tags breeding
+// @Produce application/json
+// @Param id path string true "Breeding Event ID"
+// @Success 200 {object} models.BreedingEvent
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /breeding_events/{id} [get]
+func (h *Handler) GetBreedingEventByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid breeding event ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckBreedingEventAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	event, err := h.breedingService.GetEventByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if event == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrBreedingEventNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// @Summary Delete a breeding event
+// @Description Delete a breeding event whose birth was not recorded yet
+// @Tags breeding
+// @Produce application/json
+// @Param id path string true "Breeding Event ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Birth already recorded"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /breeding_events/{id} [delete]
+func (h *Handler) DeleteBreedingEvent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid breeding event ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckBreedingEventAccess(currentUserID(c), id, services.PermManageBreeding)) {
+		return
+	}
+
+	if err := h.breedingService.DeleteEvent(id, currentActor(c)); err != nil {
+		h.breedingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "breeding event deleted successfully"})
+}
+
+// @Summary Record a pregnancy check
+// @Description Add a pregnancy check to a breeding event. The latest check marks the dam as pregnant or open.
+// @Tags breeding
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Breeding Event ID"
+// @Param request body models.PregnancyCheckReq true "Pregnancy check"
+// @Success 201 {object} models.PregnancyCheckResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Birth already recorded"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /breeding_events/{id}/pregnancy_checks [post]
+func (h *Handler) CreatePregnancyCheck(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid breeding event ID"})
+		return
+	}
+
+	var req models.PregnancyCheckReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckBreedingEventAccess(userID, id, services.PermManageBreeding)) {
+		return
+	}
+
+	check, err := h.breedingService.AddPregnancyCheck(id, &req, userID, currentActor(c))
+	if err != nil {
+		h.breedingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "pregnancy check recorded", "pregnancy_check": check})
+}
+
+// @Summary Record a birth
+// @Description Close a breeding event with its birth. Every live offspring is added to the farm as an animal linked to its sire and dam.
+// @Tags breeding
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Breeding Event ID"
+// @Param request body models.BirthReq true "Birth"
+// @Success 201 {object} models.BirthResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Birth already recorded"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /breeding_events/{id}/birth [post]
+func (h *Handler) RecordBirth(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid breeding event ID"})
+		return
+	}
+
+	var req models.BirthReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckBreedingEventAccess(userID, id, services.PermManageBreeding)) {
+		return
+	}
+
+	birth, err := h.breedingService.RecordBirth(id, &req, userID, currentActor(c))
+	if err != nil {
+		h.breedingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "birth recorded", "birth": birth})
+}
+
+// @Summary Get the upcoming births of a farm
+// @Description Retrieve the pregnancies due within the given days, including overdue ones
+// @Tags farms
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param days query int false "Days ahead to look (default 30)"
+// @Success 200 {array} models.UpcomingBirth
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/births/upcoming [get]
+func (h *Handler) GetUpcomingBirths(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	days, ok := positiveIntQuery(c, "days", 30)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	births, err := h.breedingService.GetUpcomingBirths(farmID, days)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, births)
+}
+
+// @Summary Get the reproductive history of an animal
+// @Description Retrieve every breeding event of a female with its pregnancy checks, birth and offspring
+// @Tags animals
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Success 200 {object} models.ReproductiveHistory
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/reproduction [get]
+func (h *Handler) GetReproductiveHistory(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermViewFarm)) {
+		return
+	}
+
+	history, err := h.breedingService.GetReproductiveHistory(animalID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
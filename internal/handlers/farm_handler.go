@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"farmish/internal/models"
+	"farmish/internal/services"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +17,7 @@ import (
 // @Param request body models.CreateFarmRequest true "Request body for creating a farm"
 // @Success 201 {object} models.CreateFarmResponse
 // @Failure		400		{object}	models.ErrResp	"Invalid request body"
+// @Failure		403		{object}	models.ErrResp	"Access denied"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
 // @Router			/farms [post]
@@ -26,7 +28,12 @@ func (h *Handler) CreateFarm(c *gin.Context) {
 		return
 	}
 
-	if err := h.farmService.CreateFarm(&farm); err != nil {
+	if farm.OwnerID != currentUserID(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "farm owner must be the authenticated user"})
+		return
+	}
+
+	if err := h.farmService.CreateFarm(&farm, currentActor(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -41,6 +48,7 @@ func (h *Handler) CreateFarm(c *gin.Context) {
 // @Param			id		path		string			true	"Farm ID (UUID)"
 // @Success		200		{object}	models.Farm		"Farm details"
 // @Failure		400		{object}	models.ErrResp	"Invalid farm ID format"
+// @Failure		403		{object}	models.ErrResp	"Access denied"
 // @Failure		404		{object}	models.ErrResp	"Farm not found"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
@@ -53,6 +61,10 @@ func (h *Handler) GetFarmByID(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
 	farm, err := h.farmService.GetFarmByID(farmID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -68,21 +80,30 @@ func (h *Handler) GetFarmByID(c *gin.Context) {
 }
 
 // @Summary		Get all farms
-// @Description	Retrieve a list of all existing farms.
+// @Description	Retrieve the farms the authenticated user owns or works on.
 // @Tags			farms
 // @Produce		application/json
-// @Success		200		{array}		models.Farm		"List of farms"
+// @Param			limit	query		int				false	"Page size, 50 by default and 200 at most"
+// @Param			offset	query		int				false	"Number of farms to skip"
+// @Param			sort	query		string			false	"name (default), location or created_at, prefixed with - for descending order"
+// @Success		200		{object}	models.Page[models.Farm]	"Page of farms"
+// @Failure		400		{object}	models.ErrResp	"Invalid page or sort"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
 // @Router			/farms [get]
 func (h *Handler) GetAllFarms(c *gin.Context) {
-	farms, err := h.farmService.GetAllFarms()
+	var page models.PageQuery
+	if !bindPage(c, &page, models.FarmSortFields) {
+		return
+	}
+
+	farms, total, err := h.farmService.GetAllFarms(currentUserID(c), &page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, farms)
+	respondPage(c, &page, farms, total)
 }
 
 // @Summary		Update a farm
@@ -94,6 +115,7 @@ func (h *Handler) GetAllFarms(c *gin.Context) {
 // @Param			request	body		models.UpdateFarmRequest	true	"Farm update payload"
 // @Success		200		{object}	models.UpdateFarmResp			"Farm updated successfully"
 // @Failure		400		{object}	models.ErrResp	"Invalid input or ID format"
+// @Failure		403		{object}	models.ErrResp	"Access denied"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
 // @Router			/farms/{id} [put]
@@ -104,8 +126,16 @@ func (h *Handler) UpdateFarm(c *gin.Context) {
 		return
 	}
 
-	if err := h.farmService.UpdateFarm(&farm); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farm.ID, services.PermManageFarm)) {
+		return
+	}
+
+	if err := h.farmService.UpdateFarm(&farm, currentActor(c)); err != nil {
+		if err == services.ErrNewOwnerNotMember {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
@@ -113,12 +143,13 @@ func (h *Handler) UpdateFarm(c *gin.Context) {
 }
 
 // @Summary		Delete a farm
-// @Description	Delete a farm by their UUID.
+// @Description	Move a farm with its animals, stock and records to the trash. The owner can restore it until it is purged.
 // @Tags			farms
 // @Produce		application/json
 // @Param			id		path		string			true	"Farm ID (UUID)"
 // @Success		200		{object}	models.MessageResp			"Farm deleted successfully"
 // @Failure		400		{object}	models.ErrResp	"Invalid Farm ID format"
+// @Failure		403		{object}	models.ErrResp	"Access denied"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
 // @Router			/farms/{id} [delete]
@@ -130,7 +161,11 @@ func (h *Handler) DeleteFarm(c *gin.Context) {
 		return
 	}
 
-	if err := h.farmService.DeleteFarm(farmID); err != nil {
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermManageFarm)) {
+		return
+	}
+
+	if err := h.farmService.DeleteFarm(farmID, currentActor(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
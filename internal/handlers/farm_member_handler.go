@@ -0,0 +1,369 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (h *Handler) handleMembershipError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrFarmNotFound),
+		errors.Is(err, repository.ErrMemberNotFound),
+		errors.Is(err, repository.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAlreadyMember),
+		errors.Is(err, services.ErrInvitationExists),
+		errors.Is(err, services.ErrInvitationNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrOwnerRoleImmutable):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// @Summary Get farm members
+// @Description Retrieve the owner and staff of a farm with their roles
+// @Tags farm_members
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "name, email, role or created_at, prefixed with - for descending order; oldest first by default"
+// @Success 200 {object} models.Page[models.FarmMember]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/members [get]
+func (h *Handler) GetFarmMembers(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.FarmMemberSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	members, total, err := h.farmMemberService.GetMembers(farmID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, members, total)
+}
+
+// @Summary Invite a member to a farm
+// @Description Invite a user by email to join the farm with the given role
+// @Tags farm_members
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param request body models.InviteMemberReq true "Invitation"
+// @Success 201 {object} models.FarmInvitationResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/invitations [post]
+func (h *Handler) InviteFarmMember(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	var req models.InviteMemberReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckFarmAccess(userID, farmID, services.PermManageMembers)) {
+		return
+	}
+
+	invitation, err := h.farmMemberService.InviteMember(farmID, userID, &req, currentActor(c))
+	if err != nil {
+		h.handleMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Invitation sent successfully", "invitation": invitation})
+}
+
+// @Summary Get farm invitations
+// @Description Retrieve all invitations sent for a farm
+// @Tags farm_members
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "email, role, status or created_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.FarmInvitation]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/invitations [get]
+func (h *Handler) GetFarmInvitations(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.FarmInvitationSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermManageMembers)) {
+		return
+	}
+
+	invitations, total, err := h.farmMemberService.GetFarmInvitations(farmID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, invitations, total)
+}
+
+// @Summary Revoke a farm invitation
+// @Tags farm_members
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/invitations/{invitation_id} [delete]
+func (h *Handler) RevokeFarmInvitation(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitation_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermManageMembers)) {
+		return
+	}
+
+	invitation, err := h.farmMemberService.GetInvitationByID(invitationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if invitation == nil || invitation.FarmID != farmID {
+		c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrInvitationNotFound.Error()})
+		return
+	}
+
+	if err := h.farmMemberService.RevokeInvitation(invitationID, currentActor(c)); err != nil {
+		h.handleMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
+// @Summary Change a member's role
+// @Tags farm_members
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param user_id path string true "User ID"
+// @Param request body models.UpdateMemberRoleReq true "New role"
+// @Success 200 {object} models.UpdateMemberRoleResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/members/{user_id} [put]
+func (h *Handler) UpdateFarmMemberRole(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	var req models.UpdateMemberRoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermManageMembers)) {
+		return
+	}
+
+	member, err := h.farmMemberService.UpdateMemberRole(farmID, memberID, req.Role, currentActor(c))
+	if err != nil {
+		h.handleMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member role updated successfully", "member": member})
+}
+
+// @Summary Remove a member from a farm
+// @Description Owners can remove any staff member; staff can remove themselves to leave the farm
+// @Tags farm_members
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param user_id path string true "User ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/members/{user_id} [delete]
+func (h *Handler) RemoveFarmMember(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		return
+	}
+
+	userID := currentUserID(c)
+	perm := services.PermManageMembers
+	if memberID == userID {
+		perm = services.PermViewFarm
+	}
+	if !h.authorize(c, h.accessService.CheckFarmAccess(userID, farmID, perm)) {
+		return
+	}
+
+	if err := h.farmMemberService.RemoveMember(farmID, memberID, currentActor(c)); err != nil {
+		h.handleMembershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// @Summary Get my invitations
+// @Description Retrieve the pending farm invitations sent to the authenticated user's email
+// @Tags farm_members
+// @Produce application/json
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "email, role, status or created_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.FarmInvitation]
+// @Failure 400 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /invitations [get]
+func (h *Handler) GetMyInvitations(c *gin.Context) {
+	var page models.PageQuery
+	if !bindPage(c, &page, models.FarmInvitationSortFields) {
+		return
+	}
+
+	invitations, total, err := h.farmMemberService.GetMyInvitations(currentUserID(c), &page)
+	if err != nil {
+		h.handleMembershipError(c, err)
+		return
+	}
+
+	respondPage(c, &page, invitations, total)
+}
+
+// @Summary Accept an invitation
+// @Tags farm_members
+// @Produce application/json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /invitations/{id}/accept [post]
+func (h *Handler) AcceptInvitation(c *gin.Context) {
+	h.respondToInvitation(c, true)
+}
+
+// @Summary Decline an invitation
+// @Tags farm_members
+// @Produce application/json
+// @Param id path string true "Invitation ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /invitations/{id}/decline [post]
+func (h *Handler) DeclineInvitation(c *gin.Context) {
+	h.respondToInvitation(c, false)
+}
+
+func (h *Handler) respondToInvitation(c *gin.Context, accept bool) {
+	invitationID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invitation ID"})
+		return
+	}
+
+	userID := currentUserID(c)
+	if err := h.farmMemberService.RespondToInvitation(invitationID, userID, accept, currentActor(c)); err != nil {
+		h.handleMembershipError(c, err)
+		return
+	}
+
+	if accept {
+		c.JSON(http.StatusOK, gin.H{"message": "Invitation accepted"})
+	} else {
+		c.JSON(http.StatusOK, gin.H{"message": "Invitation declined"})
+	}
+}
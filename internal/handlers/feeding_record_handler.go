@@ -18,7 +18,8 @@ import (
 // @Produce application/json
 // @Param request body models.FeedingRecordReq true "Feeding Record request body"
 // @Success 201 {object} models.FeedingRecordResp
-// @Failure 400 {object} models.ErrResp
+// @Failure 400 {object} models.ErrResp "Invalid input, food of another farm or insufficient quantity"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
@@ -30,11 +31,19 @@ func (h *Handler) CreateFeedingRecord(c *gin.Context) {
 		return
 	}
 
-	err := h.feedingRecordService.CreateFeedingRecord(&recordReq)
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), recordReq.AnimalID, services.PermRecordFeeding)) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFoodAccess(currentUserID(c), recordReq.FoodID, services.PermRecordFeeding)) {
+		return
+	}
+
+	err := h.feedingRecordService.CreateFeedingRecord(&recordReq, currentUserID(c), currentActor(c))
 	if err != nil {
 		if err == services.ErrAnimalNotFound || err == services.ErrFoodNotFound {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if err == services.ErrInsufficientQuantity {
+		} else if err == services.ErrFoodNotInFarm || errors.Is(err, repository.ErrInsufficientQuantity) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -51,6 +60,7 @@ func (h *Handler) CreateFeedingRecord(c *gin.Context) {
 // @Param id path string true "Feeding Record ID"
 // @Success 200 {object} models.FeedingRecordDetailed
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
@@ -63,6 +73,10 @@ func (h *Handler) GetFeedingRecordByID(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, h.accessService.CheckFeedingRecordAccess(currentUserID(c), recordID, services.PermViewFarm)) {
+		return
+	}
+
 	record, err := h.feedingRecordService.GetFeedingRecordByID(recordID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch feeding record"})
@@ -81,26 +95,81 @@ func (h *Handler) GetFeedingRecordByID(c *gin.Context) {
 // @Tags feeding_records
 // @Produce application/json
 // @Param animal_id path string true "Animal ID"
-// @Success 200 {array} models.FeedingRecordDetailed
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of records to skip"
+// @Param sort query string false "fed_at, quantity or created_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.FeedingRecordDetailed]
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
 // @Router /feeding_records/animal/{animal_id} [get]
 func (h *Handler) GetFeedingRecordsByAnimalID(c *gin.Context) {
-	animalID := c.Param("animal_id")
-	parsedAnimalID, err := uuid.Parse(animalID)
-	if err != nil {
+	var filter models.RecordFilter
+	var err error
+	if filter.AnimalID, err = uuid.Parse(c.Param("animal_id")); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
 		return
 	}
 
-	records, err := h.feedingRecordService.GetFeedingRecordsByAnimalID(parsedAnimalID)
+	var page models.PageQuery
+	if !bindDateRange(c, &filter.From, &filter.To) || !bindPage(c, &page, models.FeedingRecordSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), filter.AnimalID, services.PermViewFarm)) {
+		return
+	}
+
+	records, total, err := h.feedingRecordService.GetFeedingRecords(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch feeding records"})
+		return
+	}
+
+	respondPage(c, &page, records, total)
+}
+
+// @Summary Get the feeding records of a farm
+// @Description List the feeding records of all animals on a farm, newest first, optionally narrowed down to a date range, an animal type, a food and the user who recorded them.
+// @Tags feeding_records
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param food_id query string false "Food ID"
+// @Param animal_type query string false "Animal type"
+// @Param recorded_by query string false "ID of the user who recorded it"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of records to skip"
+// @Param sort query string false "fed_at, quantity or created_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.FeedingRecordDetailed]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/feeding_records [get]
+func (h *Handler) GetFarmFeedingRecords(c *gin.Context) {
+	var filter models.RecordFilter
+	var page models.PageQuery
+	if !bindFarmRecordFilter(c, &filter, "food_id") || !bindPage(c, &page, models.FeedingRecordSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	records, total, err := h.feedingRecordService.GetFeedingRecords(&filter, &page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch feeding records"})
 		return
 	}
 
-	c.JSON(http.StatusOK, records)
+	respondPage(c, &page, records, total)
 }
 
 // @Summary Update a feeding record by its ID
@@ -111,6 +180,7 @@ func (h *Handler) GetFeedingRecordsByAnimalID(c *gin.Context) {
 // @Param input body models.UpdateFeedRecordReq true "Feeding Record Input"
 // @Success 200 {object} models.MessageResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
@@ -123,18 +193,25 @@ func (h *Handler) UpdateFeedingRecord(c *gin.Context) {
 		return
 	}
 
-	var record models.FeedingRecordWithoutTime
-	if err := c.ShouldBindJSON(&record); err != nil {
+	var req models.UpdateFeedRecordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
 		return
 	}
 
-	record.ID = recordID
+	record := models.FeedingRecordWithoutTime{ID: recordID}
+	record.UpdateFeedRecordReq = req
+
+	if !h.authorize(c, h.accessService.CheckFeedingRecordAccess(currentUserID(c), recordID, services.PermRecordFeeding)) {
+		return
+	}
 
-	err = h.feedingRecordService.UpdateFeedingRecord(&record)
+	err = h.feedingRecordService.UpdateFeedingRecord(&record, currentActor(c))
 	if err != nil {
 		if errors.Is(err, repository.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if errors.Is(err, repository.ErrInsufficientQuantity) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -145,11 +222,13 @@ func (h *Handler) UpdateFeedingRecord(c *gin.Context) {
 }
 
 // @Summary Delete a feeding record by its ID
+// @Description Move a feeding record to the trash and return its quantity to the food stock
 // @Tags feeding_records
 // @Produce application/json
 // @Param id path string true "Feeding Record ID"
 // @Success 200 {object} models.MessageResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
@@ -162,7 +241,11 @@ func (h *Handler) DeleteFeedingRecord(c *gin.Context) {
 		return
 	}
 
-	err = h.feedingRecordService.DeleteFeedingRecord(recordID)
+	if !h.authorize(c, h.accessService.CheckFeedingRecordAccess(currentUserID(c), recordID, services.PermDeleteRecords)) {
+		return
+	}
+
+	err = h.feedingRecordService.DeleteFeedingRecord(recordID, currentActor(c))
 	if err != nil {
 		if errors.Is(err, repository.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -0,0 +1,344 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// isScheduleInputError reports whether err was caused by an invalid schedule.
+func isScheduleInputError(err error) bool {
+	return errors.Is(err, services.ErrInvalidScheduleTarget) ||
+		errors.Is(err, services.ErrInvalidTimeOfDay) ||
+		errors.Is(err, services.ErrAnimalNotInFarm) ||
+		errors.Is(err, services.ErrFoodNotInFarm)
+}
+
+// @Summary Create a feeding schedule
+// @Description Plan recurring feedings for a single animal or for every animal of a type on the farm
+// @Tags feeding_schedules
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.FeedingScheduleReq true "Feeding schedule"
+// @Success 201 {object} models.FeedingScheduleResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Animal or food not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_schedules [post]
+func (h *Handler) CreateFeedingSchedule(c *gin.Context) {
+	var req models.FeedingScheduleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), req.FarmID, services.PermManageSchedules)) {
+		return
+	}
+
+	schedule, err := h.feedingScheduleService.CreateSchedule(&req, currentActor(c))
+	if err != nil {
+		if isScheduleInputError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "feeding schedule created successfully", "feeding_schedule": schedule})
+}
+
+// @Summary Get the feeding schedules of a farm
+// @Tags feeding_schedules
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "created_at or quantity, prefixed with - for descending order; oldest first by default"
+// @Success 200 {object} models.Page[models.FeedingSchedule]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_schedules [get]
+func (h *Handler) GetFeedingSchedules(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Query("farm_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.FeedingScheduleSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	schedules, total, err := h.feedingScheduleService.GetSchedulesByFarmID(farmID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, schedules, total)
+}
+
+// @Summary Get a feeding schedule by ID
+// @Tags feeding_schedules
+// @Produce application/json
+// @Param id path string true "Feeding Schedule ID"
+// @Success 200 {object} models.FeedingSchedule
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_schedules/{id} [get]
+func (h *Handler) GetFeedingScheduleByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid feeding schedule ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFeedingScheduleAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	schedule, err := h.feedingScheduleService.GetScheduleByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if schedule == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrFeedingScheduleNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// @Summary Update a feeding schedule
+// @Description Change the food, quantity or recurrence of a schedule, or pause it. Already generated tasks are kept.
+// @Tags feeding_schedules
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Feeding Schedule ID"
+// @Param request body models.UpdateFeedingScheduleReq true "Feeding schedule"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_schedules/{id} [put]
+func (h *Handler) UpdateFeedingSchedule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid feeding schedule ID"})
+		return
+	}
+
+	var req models.UpdateFeedingScheduleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFeedingScheduleAccess(currentUserID(c), id, services.PermManageSchedules)) {
+		return
+	}
+
+	if err := h.feedingScheduleService.UpdateSchedule(id, &req, currentActor(c)); err != nil {
+		if isScheduleInputError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "feeding schedule updated successfully"})
+}
+
+// @Summary Delete a feeding schedule
+// @Description Delete a schedule together with its feeding tasks
+// @Tags feeding_schedules
+// @Produce application/json
+// @Param id path string true "Feeding Schedule ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_schedules/{id} [delete]
+func (h *Handler) DeleteFeedingSchedule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid feeding schedule ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFeedingScheduleAccess(currentUserID(c), id, services.PermManageSchedules)) {
+		return
+	}
+
+	if err := h.feedingScheduleService.DeleteSchedule(id, currentActor(c)); err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "feeding schedule deleted successfully"})
+}
+
+// @Summary Get feeding tasks
+// @Description Retrieve the feeding tasks of a farm, optionally for a single day and status
+// @Tags feeding_tasks
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param date query string false "Day in YYYY-MM-DD format"
+// @Param status query string false "Task status (pending, completed, skipped)"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "due_at, status, quantity or created_at, prefixed with - for descending order; earliest due first by default"
+// @Success 200 {object} models.Page[models.FeedingTask]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_tasks [get]
+func (h *Handler) GetFeedingTasks(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Query("farm_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	filter := models.FeedingTaskFilter{FarmID: farmID, Status: c.Query("status")}
+	if date := c.Query("date"); date != "" {
+		if filter.Date, err = time.Parse("2006-01-02", date); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.FeedingTaskSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	tasks, total, err := h.feedingScheduleService.GetTasks(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, tasks, total)
+}
+
+// @Summary Complete a feeding task
+// @Description Create the feeding record of a pending task, decrementing the food stock
+// @Tags feeding_tasks
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Feeding Task ID"
+// @Param request body models.CompleteFeedingTaskReq false "Overrides for the planned feeding"
+// @Success 200 {object} models.CompleteFeedingTaskResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Task is no longer pending"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_tasks/{id}/complete [post]
+func (h *Handler) CompleteFeedingTask(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid feeding task ID"})
+		return
+	}
+
+	var req models.CompleteFeedingTaskReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckFeedingTaskAccess(userID, id, services.PermRecordFeeding)) {
+		return
+	}
+
+	recordID, err := h.feedingScheduleService.CompleteTask(id, userID, &req, currentActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTaskNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrInsufficientQuantity):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.authorize(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "feeding task completed", "feeding_record_id": recordID})
+}
+
+// @Summary Skip a feeding task
+// @Tags feeding_tasks
+// @Produce application/json
+// @Param id path string true "Feeding Task ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Task is no longer pending"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /feeding_tasks/{id}/skip [post]
+func (h *Handler) SkipFeedingTask(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid feeding task ID"})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckFeedingTaskAccess(userID, id, services.PermRecordFeeding)) {
+		return
+	}
+
+	if err := h.feedingScheduleService.SkipTask(id, userID, currentActor(c)); err != nil {
+		if errors.Is(err, repository.ErrTaskNotPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "feeding task skipped"})
+}
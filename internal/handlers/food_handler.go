@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"farmish/internal/models"
+	"farmish/internal/services"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +17,7 @@ import (
 // @Param request body models.AddFoodReq true "Warehouse Food"
 // @Success 201 {object} models.AddFoodResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
 // @Router /foods [post]
@@ -26,7 +28,11 @@ func (h *Handler) AddFoodToWarehouse(c *gin.Context) {
 		return
 	}
 
-	err := h.foodService.AddFoodToWarehouse(&food)
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), food.FarmID, services.PermManageFoods)) {
+		return
+	}
+
+	err := h.foodService.AddFoodToWarehouse(&food, currentUserID(c), currentActor(c))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -40,25 +46,41 @@ func (h *Handler) AddFoodToWarehouse(c *gin.Context) {
 // @Tags foods
 // @Produce application/json
 // @Param farm_id path string true "Farm ID"
-// @Success 200 {array} models.Food
+// @Param suitable_for query string false "Animal type the item must be suitable for"
+// @Param below_threshold query bool false "Only list items below their minimum threshold"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "name (default), quantity, created_at or updated_at, prefixed with - for descending order"
+// @Success 200 {object} models.Page[models.Food]
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
 // @Router /foods/{farm_id} [get]
 func (h *Handler) GetWarehouseFoods(c *gin.Context) {
-	farmID, err := uuid.Parse(c.Param("farm_id"))
-	if err != nil {
+	var filter models.StockFilter
+	var err error
+	if filter.FarmID, err = uuid.Parse(c.Param("farm_id")); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid farm ID"})
 		return
 	}
 
-	foods, err := h.foodService.GetFoodsByFarm(farmID)
+	var page models.PageQuery
+	if !bindStockFilter(c, &filter) || !bindPage(c, &page, models.StockSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	foods, total, err := h.foodService.GetFoodsByFarm(&filter, &page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, foods)
+	respondPage(c, &page, foods, total)
 }
 
 // @Summary Get a food by ID
@@ -68,6 +90,7 @@ func (h *Handler) GetWarehouseFoods(c *gin.Context) {
 // @Param food_id path string true "Food ID(UUID)"
 // @Success 200 {object} models.Food
 // @Failure 400 {object} models.ErrResp "Invalid food ID"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp "Food not found"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
@@ -79,6 +102,10 @@ func (h *Handler) GetFoodByID(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, h.accessService.CheckFoodAccess(currentUserID(c), foodID, services.PermViewFarm)) {
+		return
+	}
+
 	food, err := h.foodService.GetFoodByID(foodID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -101,6 +128,7 @@ func (h *Handler) GetFoodByID(c *gin.Context) {
 // @Param request body models.UpdateFoodReq true "Warehouse Food"
 // @Success 200 {object} models.UpdateFoodResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
 // @Router /foods [put]
@@ -111,7 +139,11 @@ func (h *Handler) UpdateWarehouseFood(c *gin.Context) {
 		return
 	}
 
-	if err := h.foodService.UpdateFood(&food); err != nil {
+	if !h.authorize(c, h.accessService.CheckFoodAccess(currentUserID(c), food.ID, services.PermManageFoods)) {
+		return
+	}
+
+	if err := h.foodService.UpdateFood(&food, currentUserID(c), currentActor(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -120,12 +152,13 @@ func (h *Handler) UpdateWarehouseFood(c *gin.Context) {
 }
 
 // @Summary Remove a food item from the warehouse
-// @Description Delete a food item from the warehouse
+// @Description Move a food item of the warehouse to the trash, from where it can be restored until it is purged
 // @Tags foods
 // @Produce application/json
 // @Param food_id path string true "Food ID"
 // @Success 200 {object} models.MessageResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
 // @Router /foods/{food_id} [delete]
@@ -136,7 +169,11 @@ func (h *Handler) RemoveWarehouseFood(c *gin.Context) {
 		return
 	}
 
-	if err := h.foodService.RemoveWarehouseFood(foodID); err != nil {
+	if !h.authorize(c, h.accessService.CheckFoodAccess(currentUserID(c), foodID, services.PermManageFoods)) {
+		return
+	}
+
+	if err := h.foodService.RemoveWarehouseFood(foodID, currentActor(c)); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -17,7 +17,8 @@ import (
 // @Produce application/json
 // @Param request body models.MedicalRecordReq true "Medical Record"
 // @Success 201 {object} models.MedicalRecordResp "Created successfully"
-// @Failure 400 {object} models.ErrResp "Invalid input"
+// @Failure 400 {object} models.ErrResp "Invalid input, or medicine of another farm"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp "Animal or Medicine Not Found"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
@@ -29,11 +30,20 @@ func (h *Handler) CreateMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	err := h.medicalRecordService.CreateMedicalRecord(&record)
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), record.AnimalID, services.PermRecordTreatment)) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(currentUserID(c), record.MedicineID, services.PermRecordTreatment)) {
+		return
+	}
+
+	err := h.medicalRecordService.CreateMedicalRecord(&record, currentUserID(c), currentActor(c))
 	if err != nil {
 		if err == services.ErrAnimalNotFound || err == services.ErrMedicineNotExist {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if err == services.ErrInsufficientQuantity {
+		} else if err == services.ErrMedicineNotInFarm || errors.Is(err, repository.ErrInsufficientQuantity) ||
+			errors.Is(err, repository.ErrStockExpired) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -50,6 +60,7 @@ func (h *Handler) CreateMedicalRecord(c *gin.Context) {
 // @Param id path string true "Medical Record ID"
 // @Success 200 {object} models.MedicalRecordDetailed
 // @Failure 400 {object} models.ErrResp "Invalid record ID"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp "Not found"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
@@ -62,6 +73,10 @@ func (h *Handler) GetMedicalRecordByID(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, h.accessService.CheckMedicalRecordAccess(currentUserID(c), recordID, services.PermViewFarm)) {
+		return
+	}
+
 	record, err := h.medicalRecordService.GetMedicalRecordByID(recordID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -79,26 +94,81 @@ func (h *Handler) GetMedicalRecordByID(c *gin.Context) {
 // @Tags medical_records
 // @Produce application/json
 // @Param animal_id path string true "Animal ID"
-// @Success 200 {array} models.MedicalRecordDetailed
-// @Failure 400 {object} models.ErrResp "Invalid animal ID"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of records to skip"
+// @Param sort query string false "treatment_date, quantity or created_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.MedicalRecordDetailed]
+// @Failure 400 {object} models.ErrResp "Invalid animal ID, date range, page or sort"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
 // @Router /medical_records/animals/{animal_id} [get]
 func (h *Handler) GetMedicalRecordsByAnimalID(c *gin.Context) {
-	id := c.Param("animal_id")
-	animalID, err := uuid.Parse(id)
-	if err != nil {
+	var filter models.RecordFilter
+	var err error
+	if filter.AnimalID, err = uuid.Parse(c.Param("animal_id")); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal record ID"})
 		return
 	}
 
-	records, err := h.medicalRecordService.GetMedicalRecordsByAnimalID(animalID)
+	var page models.PageQuery
+	if !bindDateRange(c, &filter.From, &filter.To) || !bindPage(c, &page, models.MedicalRecordSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), filter.AnimalID, services.PermViewFarm)) {
+		return
+	}
+
+	records, total, err := h.medicalRecordService.GetMedicalRecords(&filter, &page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, records)
+	respondPage(c, &page, records, total)
+}
+
+// @Summary Get the medical records of a farm
+// @Description List the medical records of all animals on a farm, newest first, optionally narrowed down to a date range, an animal type, a medicine and the user who recorded them.
+// @Tags medical_records
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param medicine_id query string false "Medicine ID"
+// @Param animal_type query string false "Animal type"
+// @Param recorded_by query string false "ID of the user who recorded it"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of records to skip"
+// @Param sort query string false "treatment_date, quantity or created_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.MedicalRecordDetailed]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/medical_records [get]
+func (h *Handler) GetFarmMedicalRecords(c *gin.Context) {
+	var filter models.RecordFilter
+	var page models.PageQuery
+	if !bindFarmRecordFilter(c, &filter, "medicine_id") || !bindPage(c, &page, models.MedicalRecordSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	records, total, err := h.medicalRecordService.GetMedicalRecords(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, records, total)
 }
 
 // @Summary Update a medical record
@@ -109,6 +179,7 @@ func (h *Handler) GetMedicalRecordsByAnimalID(c *gin.Context) {
 // @Param request body models.UpdateMedicalRecordReq true "Medical Record"
 // @Success 200 {object} models.MessageResp "Updated successfully"
 // @Failure 400 {object} models.ErrResp "Invalid input"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp "Not Found"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
@@ -121,18 +192,25 @@ func (h *Handler) UpdateMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	var record models.MedicalRecordWithoutTime
-	if err := c.ShouldBindJSON(&record); err != nil {
+	var req models.UpdateMedicalRecordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
 		return
 	}
 
-	record.ID = recordID
+	record := models.MedicalRecordWithoutTime{ID: recordID}
+	record.UpdateMedicalRecordReq = req
 
-	err = h.medicalRecordService.UpdateMedicalRecord(&record)
+	if !h.authorize(c, h.accessService.CheckMedicalRecordAccess(currentUserID(c), recordID, services.PermRecordTreatment)) {
+		return
+	}
+
+	err = h.medicalRecordService.UpdateMedicalRecord(&record, currentActor(c))
 	if err != nil {
 		if errors.Is(err, repository.ErrMedicalRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrMedicalRecordNotFound})
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else if errors.Is(err, repository.ErrInsufficientQuantity) || errors.Is(err, repository.ErrStockExpired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -143,11 +221,13 @@ func (h *Handler) UpdateMedicalRecord(c *gin.Context) {
 }
 
 // @Summary Delete a medical record by ID
+// @Description Move a medical record to the trash and return its quantity to the medicine stock and batches
 // @Tags medical_records
 // @Produce application/json
 // @Param id path string true "Medical Record ID"
 // @Success 200 {object} models.MessageResp "Deleted successfully"
 // @Failure 400 {object} models.ErrResp "Invalid record ID"
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp "Not found"
 // @Failure 500 {object} models.ErrResp "Internal server error"
 // @Security BearerAuth
@@ -160,10 +240,14 @@ func (h *Handler) DeleteMedicalRecord(c *gin.Context) {
 		return
 	}
 
-	err = h.medicalRecordService.DeleteMedicalRecord(recordID)
+	if !h.authorize(c, h.accessService.CheckMedicalRecordAccess(currentUserID(c), recordID, services.PermDeleteRecords)) {
+		return
+	}
+
+	err = h.medicalRecordService.DeleteMedicalRecord(recordID, currentActor(c))
 	if err != nil {
 		if errors.Is(err, repository.ErrMedicalRecordNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrMedicalRecordNotFound})
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 		} else {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		}
@@ -172,3 +256,35 @@ func (h *Handler) DeleteMedicalRecord(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"message": "Record deleted successfully"})
 }
+
+// @Summary Get animals under withdrawal
+// @Description List the animals of a farm whose meat, milk or eggs may not be used yet because of a recent treatment, with the day each withdrawal period ends
+// @Tags farms
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Success 200 {array} models.AnimalWithdrawal
+// @Failure 400 {object} models.ErrResp "Invalid farm ID"
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp "Internal server error"
+// @Security BearerAuth
+// @Router /farms/{id}/withdrawals [get]
+func (h *Handler) GetFarmWithdrawals(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	withdrawals, err := h.medicalRecordService.GetWithdrawals(farmID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, withdrawals)
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Add a medicine batch
+// @Description Stock a lot of the medicine with its expiry date. The quantity is booked as a purchase.
+// @Tags medicines
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Medicine ID"
+// @Param request body models.MedicineBatchReq true "Batch"
+// @Success 201 {object} models.MedicineBatchResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /medicines/{id}/batches [post]
+func (h *Handler) CreateMedicineBatch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+
+	var req models.MedicineBatchReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(userID, id, services.PermManageMedicines)) {
+		return
+	}
+
+	batch, err := h.medicineBatchService.CreateBatch(id, &req, userID, currentActor(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrStockItemNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": services.ErrMedicineNotExist.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "batch added successfully", "batch": batch})
+}
+
+// @Summary Get the batches of a medicine
+// @Description Retrieve the batches in the order they are used, first expiring first
+// @Tags medicines
+// @Produce application/json
+// @Param id path string true "Medicine ID"
+// @Param include_empty query bool false "Include used up batches"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "expiry_date, lot_number, quantity or created_at, prefixed with - for descending order; first expiring first by default"
+// @Success 200 {object} models.Page[models.MedicineBatch]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /medicines/{id}/batches [get]
+func (h *Handler) GetMedicineBatches(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.MedicineBatchSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	batches, total, err := h.medicineBatchService.GetBatches(id, c.Query("include_empty") == "true", &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, batches, total)
+}
+
+// @Summary Discard a medicine batch
+// @Description Write off what is left of the batch as waste, e.g. once it has expired
+// @Tags medicines
+// @Produce application/json
+// @Param id path string true "Batch ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Batch is already empty"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /medicine_batches/{id}/discard [post]
+func (h *Handler) DiscardMedicineBatch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid batch ID"})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckMedicineBatchAccess(userID, id, services.PermManageMedicines)) {
+		return
+	}
+
+	if err := h.medicineBatchService.DiscardBatch(id, userID, currentActor(c)); err != nil {
+		if errors.Is(err, repository.ErrBatchEmpty) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "batch discarded"})
+}
+
+// @Summary Get the expiring medicine batches of a farm
+// @Description Retrieve the batches with stock left that expire within the given days, including those already expired
+// @Tags farms
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param days query int false "Days ahead to look (default 30)"
+// @Param expired query bool false "Only return expired batches"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "expiry_date, lot_number, quantity or created_at, prefixed with - for descending order; first expiring first by default"
+// @Success 200 {object} models.Page[models.ExpiringBatch]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/medicine_batches/expiring [get]
+func (h *Handler) GetExpiringMedicineBatches(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	days, ok := positiveIntQuery(c, "days", 30)
+	if !ok {
+		return
+	}
+	if c.Query("expired") == "true" {
+		days = -1
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.MedicineBatchSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	batches, total, err := h.medicineBatchService.GetExpiringBatches(farmID, days, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, batches, total)
+}
@@ -17,6 +17,7 @@ import (
 // @Param request body models.MedicineReq true "Medicine Details"
 // @Success 201 {object} models.MedicineResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
 // @Router /medicines [post]
@@ -27,7 +28,11 @@ func (h *Handler) CreateMedicine(c *gin.Context) {
 		return
 	}
 
-	if err := h.medicineService.CreateMedicine(&medicine); err != nil {
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), medicine.FarmID, services.PermManageMedicines)) {
+		return
+	}
+
+	if err := h.medicineService.CreateMedicine(&medicine, currentUserID(c), currentActor(c)); err != nil {
 		if err == services.ErrQuantityLessThanThreshold {
 			c.JSON(http.StatusBadRequest, gin.H{"error": services.ErrQuantityLessThanThreshold})
 		} else {
@@ -43,25 +48,41 @@ func (h *Handler) CreateMedicine(c *gin.Context) {
 // @Description Retrieve all medicines for a specific farm
 // @Tags medicines
 // @Param farm_id query string true "Farm ID"
-// @Success 200 {array} models.Medicine
+// @Param suitable_for query string false "Animal type the item must be suitable for"
+// @Param below_threshold query bool false "Only list items below their minimum threshold"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "name (default), quantity, created_at or updated_at, prefixed with - for descending order"
+// @Success 200 {object} models.Page[models.Medicine]
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
 // @Router /medicines [get]
 func (h *Handler) GetAllMedicines(c *gin.Context) {
-	farmID, err := uuid.Parse(c.Query("farm_id"))
-	if err != nil {
+	var filter models.StockFilter
+	var err error
+	if filter.FarmID, err = uuid.Parse(c.Query("farm_id")); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID format"})
 		return
 	}
 
-	medicines, err := h.medicineService.GetAllMedicines(farmID)
+	var page models.PageQuery
+	if !bindStockFilter(c, &filter) || !bindPage(c, &page, models.StockSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	medicines, total, err := h.medicineService.GetAllMedicines(&filter, &page)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, medicines)
+	respondPage(c, &page, medicines, total)
 }
 
 // @Summary Get a medicine by ID
@@ -70,6 +91,7 @@ func (h *Handler) GetAllMedicines(c *gin.Context) {
 // @Param id path string true "Medicine ID"
 // @Success 200 {object} models.Medicine
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
@@ -81,6 +103,10 @@ func (h *Handler) GetMedicineByID(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
 	medicine, err := h.medicineService.GetMedicineByID(id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -104,6 +130,7 @@ func (h *Handler) GetMedicineByID(c *gin.Context) {
 // @Param request body models.MedicineReq true "Updated Medicine Details"
 // @Success 200 {object} models.MedicineResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
@@ -122,7 +149,11 @@ func (h *Handler) UpdateMedicine(c *gin.Context) {
 	}
 	medicine.ID = id
 
-	if err := h.medicineService.UpdateMedicine(&medicine); err != nil {
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(currentUserID(c), id, services.PermManageMedicines)) {
+		return
+	}
+
+	if err := h.medicineService.UpdateMedicine(&medicine, currentUserID(c), currentActor(c)); err != nil {
 		if err == services.ErrMedicineNotExist {
 			c.JSON(http.StatusNotFound, gin.H{"error": services.ErrMedicineNotExist})
 		} else if err == services.ErrQuantityLessThanThreshold {
@@ -137,11 +168,12 @@ func (h *Handler) UpdateMedicine(c *gin.Context) {
 }
 
 // @Summary Delete a medicine
-// @Description Remove a specific medicine by its ID
+// @Description Move a medicine to the trash, from where it can be restored until it is purged
 // @Tags medicines
 // @Param id path string true "Medicine ID"
 // @Success 200 {object} models.MessageResp
 // @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
 // @Failure 404 {object} models.ErrResp
 // @Failure 500 {object} models.ErrResp
 // @Security BearerAuth
@@ -153,7 +185,11 @@ func (h *Handler) DeleteMedicine(c *gin.Context) {
 		return
 	}
 
-	if err := h.medicineService.DeleteMedicine(id); err != nil {
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(currentUserID(c), id, services.PermManageMedicines)) {
+		return
+	}
+
+	if err := h.medicineService.DeleteMedicine(id, currentActor(c)); err != nil {
 		if err == services.ErrMedicineNotExist {
 			c.JSON(http.StatusNotFound, gin.H{"error": services.ErrMedicineNotExist})
 		} else {
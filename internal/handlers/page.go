@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"farmish/internal/models"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 200
+)
+
+// bindPage reads the limit, offset and sort of a list. sort must be one of the
+// given fields, prefixed with - for descending order.
+func bindPage(c *gin.Context, page *models.PageQuery, sortFields []string) bool {
+	page.Limit = defaultPageLimit
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxPageLimit)})
+			return false
+		}
+		page.Limit = limit
+	}
+	if value := c.Query("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "offset must be a non-negative number"})
+			return false
+		}
+		page.Offset = offset
+	}
+	if value := c.Query("sort"); value != "" {
+		page.Desc = strings.HasPrefix(value, "-")
+		page.Sort = strings.TrimPrefix(value, "-")
+		if !slices.Contains(sortFields, page.Sort) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of " + strings.Join(sortFields, ", ")})
+			return false
+		}
+	}
+	return true
+}
+
+// respondPage writes one page of a list in the list envelope, linking to the
+// next page when there are more items.
+func respondPage[T any](c *gin.Context, page *models.PageQuery, items []T, total int) {
+	if items == nil {
+		items = []T{}
+	}
+
+	resp := models.Page[T]{Items: items, Total: total, Limit: page.Limit, Offset: page.Offset}
+	if page.Offset+page.Limit < total {
+		query := c.Request.URL.Query()
+		query.Set("limit", strconv.Itoa(page.Limit))
+		query.Set("offset", strconv.Itoa(page.Offset+page.Limit))
+		resp.Next = c.Request.URL.Path + "?" + query.Encode()
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// daysQuery reads an optional number of days, which is nil when not given.
+func daysQuery(c *gin.Context, name string) (*int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be a non-negative number of days"})
+		return nil, false
+	}
+	return &days, true
+}
+
+// bindDateRange reads the optional from and to days of a list. to is
+// inclusive: it is moved to the start of the following day.
+func bindDateRange(c *gin.Context, from, to *time.Time) bool {
+	var err error
+	if value := c.Query("from"); value != "" {
+		if *from, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date, expected YYYY-MM-DD"})
+			return false
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		day, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date, expected YYYY-MM-DD"})
+			return false
+		}
+		*to = day.AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(*to) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return false
+	}
+	return true
+}
+
+// bindStockFilter reads the optional suitable_for and below_threshold filters
+// of a food or medicine list.
+func bindStockFilter(c *gin.Context, filter *models.StockFilter) bool {
+	filter.SuitableFor = c.Query("suitable_for")
+	if value := c.Query("below_threshold"); value != "" {
+		below, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "below_threshold must be true or false"})
+			return false
+		}
+		filter.BelowThreshold = below
+	}
+	return true
+}
+
+// bindFarmRecordFilter reads the farm of a farm-level record list and its
+// optional date range, animal_type and recorded_by filters. itemParam names
+// the query parameter holding the food or medicine to filter by.
+func bindFarmRecordFilter(c *gin.Context, filter *models.RecordFilter, itemParam string) bool {
+	var err error
+	if filter.FarmID, err = uuid.Parse(c.Param("id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return false
+	}
+	filter.AnimalType = c.Query("animal_type")
+	if value := c.Query(itemParam); value != "" {
+		if filter.ItemID, err = uuid.Parse(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + itemParam})
+			return false
+		}
+	}
+	if value := c.Query("recorded_by"); value != "" {
+		if filter.RecordedBy, err = uuid.Parse(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid recorded_by user ID"})
+			return false
+		}
+	}
+	return bindDateRange(c, &filter.From, &filter.To)
+}
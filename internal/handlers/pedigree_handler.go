@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// generationsQuery reads the generations query parameter, bounded by
+// services.MaxPedigreeGenerations.
+func generationsQuery(c *gin.Context, defaultValue int) (int, bool) {
+	generations, ok := positiveIntQuery(c, "generations", defaultValue)
+	if !ok {
+		return 0, false
+	}
+	if generations > services.MaxPedigreeGenerations {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "generations cannot exceed " + strconv.Itoa(services.MaxPedigreeGenerations)})
+		return 0, false
+	}
+	return generations, true
+}
+
+// @Summary Get the pedigree of an animal
+// @Description Retrieve the ancestry tree of an animal through its sires and dams, and its inbreeding coefficient over that tree
+// @Tags animals
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param generations query int false "Generations back (default 3, at most 10)"
+// @Success 200 {object} models.Pedigree
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/pedigree [get]
+func (h *Handler) GetAnimalPedigree(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	generations, ok := generationsQuery(c, 3)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermViewFarm)) {
+		return
+	}
+
+	pedigree, err := h.pedigreeService.GetPedigree(animalID, generations)
+	if err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pedigree)
+}
+
+// @Summary Get the descendants of an animal
+// @Description Retrieve the offspring of an animal down to the given generation; generation 1 are its children
+// @Tags animals
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param generations query int false "Generations down (default 3, at most 10)"
+// @Success 200 {array} models.Descendant
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/descendants [get]
+func (h *Handler) GetAnimalDescendants(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	generations, ok := generationsQuery(c, 3)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermViewFarm)) {
+		return
+	}
+
+	descendants, err := h.pedigreeService.GetDescendants(animalID, generations)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, descendants)
+}
+
+// @Summary Compute the inbreeding coefficient of a mating
+// @Description Compute the inbreeding coefficient the offspring of a proposed sire and dam would have, and list the ancestors they share
+// @Tags animals
+// @Produce application/json
+// @Param sire_id query string true "Sire ID"
+// @Param dam_id query string true "Dam ID"
+// @Param generations query int false "Generations of ancestry to consider (default 6, at most 10)"
+// @Success 200 {object} models.InbreedingResult
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/inbreeding [get]
+func (h *Handler) GetInbreedingCoefficient(c *gin.Context) {
+	sireID, err := uuid.Parse(c.Query("sire_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sire ID"})
+		return
+	}
+	damID, err := uuid.Parse(c.Query("dam_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid dam ID"})
+		return
+	}
+
+	generations, ok := generationsQuery(c, 6)
+	if !ok {
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(userID, sireID, services.PermViewFarm)) {
+		return
+	}
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(userID, damID, services.PermViewFarm)) {
+		return
+	}
+
+	result, err := h.pedigreeService.GetInbreeding(sireID, damID, generations)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidSire) || errors.Is(err, services.ErrInvalidDam) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// productionError writes the response for a failed production action.
+func (h *Handler) productionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrProductionSource),
+		errors.Is(err, services.ErrNoActiveAnimals):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrAnimalInactive),
+		errors.Is(err, services.ErrAnimalUnderWithdrawal):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.authorize(c, err)
+	}
+}
+
+// bindProductFilter reads the optional product query parameter.
+func bindProductFilter(c *gin.Context, filter *models.ReportFilter) bool {
+	filter.Product = c.Query("product")
+	switch filter.Product {
+	case "", models.ProductMilk, models.ProductEggs, models.ProductWool:
+		return true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "product must be one of milk, eggs, wool"})
+		return false
+	}
+}
+
+// @Summary Record production
+// @Description Record the milk, eggs or wool produced by an animal, or by the animals of a type on a farm when farm_id and animal_type are given instead. Production is rejected for dead or sold animals and, for milk and eggs, while a drug withdrawal period runs for the animal or any active animal of the group.
+// @Tags production
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.ProductionRecordReq true "Production record"
+// @Success 201 {object} models.ProductionRecordResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Animal or farm not found"
+// @Failure 409 {object} models.ErrResp "Animal not active or under withdrawal"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /production_records [post]
+func (h *Handler) CreateProductionRecord(c *gin.Context) {
+	var req models.ProductionRecordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	var err error
+	switch {
+	case req.AnimalID != nil:
+		err = h.accessService.CheckAnimalAccess(userID, *req.AnimalID, services.PermRecordProduction)
+	case req.FarmID != nil:
+		err = h.accessService.CheckFarmAccess(userID, *req.FarmID, services.PermRecordProduction)
+	default:
+		err = services.ErrProductionSource
+	}
+	if err != nil {
+		h.productionError(c, err)
+		return
+	}
+
+	record, err := h.productionRecordService.CreateRecord(&req, userID, currentActor(c))
+	if err != nil {
+		h.productionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "production recorded successfully", "production_record": record})
+}
+
+// @Summary Get production records
+// @Description List the production records of a farm in a date range, latest first
+// @Tags production
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param from query string false "First day in YYYY-MM-DD format, defaults to 30 days before to"
+// @Param to query string false "Last day in YYYY-MM-DD format, defaults to today"
+// @Param animal_id query string false "Animal ID"
+// @Param type query string false "Animal type"
+// @Param product query string false "milk, eggs or wool"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "produced_at, product, quantity or created_at, prefixed with - for descending order; latest first by default"
+// @Success 200 {object} models.Page[models.ProductionRecord]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /production_records [get]
+func (h *Handler) GetProductionRecords(c *gin.Context) {
+	var filter models.ReportFilter
+	var page models.PageQuery
+	if !bindReportFilter(c, &filter) || !bindProductFilter(c, &filter) || !bindPage(c, &page, models.ProductionRecordSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	records, total, err := h.productionRecordService.GetRecords(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, records, total)
+}
+
+// @Summary Get a production record by ID
+// @Description Retrieve a production record
+// @Tags production
+// @Produce application/json
+// @Param id path string true "Production Record ID"
+// @Success 200 {object} models.ProductionRecord
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /production_records/{id} [get]
+func (h *Handler) GetProductionRecordByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid production record ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckProductionRecordAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	record, err := h.productionRecordService.GetRecordByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if record == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrProductionRecordNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// @Summary Delete a production record
+// @Description Move a production record to the trash, from where it can be restored until it is purged
+// @Tags production
+// @Produce application/json
+// @Param id path string true "Production Record ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /production_records/{id} [delete]
+func (h *Handler) DeleteProductionRecord(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid production record ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckProductionRecordAccess(currentUserID(c), id, services.PermDeleteRecords)) {
+		return
+	}
+
+	if err := h.productionRecordService.DeleteRecord(id, currentActor(c)); err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "production record deleted successfully"})
+}
+
+// @Summary Get production yields
+// @Description Sum the milk, eggs and wool produced on a farm per day, week or month, for the whole farm or per animal or animal type. Quantities in different units are reported separately. Per animal, records of a group are reported under their animal type. Per type, the yield is also divided among the type's active animals.
+// @Tags reports
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param from query string false "First day in YYYY-MM-DD format, defaults to 30 days before to"
+// @Param to query string false "Last day in YYYY-MM-DD format, defaults to today"
+// @Param interval query string false "Bucket: day, week (default) or month"
+// @Param group_by query string false "farm (default), animal or type"
+// @Param animal_id query string false "Animal ID"
+// @Param type query string false "Animal type"
+// @Param product query string false "milk, eggs or wool"
+// @Success 200 {array} models.ProductionYield
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /reports/production [get]
+func (h *Handler) GetProductionReport(c *gin.Context) {
+	var filter models.ReportFilter
+	if !bindReportFilter(c, &filter) || !bindProductFilter(c, &filter) {
+		return
+	}
+
+	filter.Interval = c.DefaultQuery("interval", services.IntervalWeek)
+	if filter.Interval != services.IntervalDay && filter.Interval != services.IntervalWeek && filter.Interval != services.IntervalMonth {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be one of day, week, month"})
+		return
+	}
+
+	filter.GroupBy = c.DefaultQuery("group_by", models.ReportGroupFarm)
+	switch filter.GroupBy {
+	case models.ReportGroupFarm, models.ReportGroupAnimal, models.ReportGroupType:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group_by must be one of farm, animal, type"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	yields, err := h.productionRecordService.GetYields(&filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, yields)
+}
@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// isOrderInputError reports whether err was caused by an invalid order.
+func isOrderInputError(err error) bool {
+	return errors.Is(err, services.ErrInvalidOrderItem) ||
+		errors.Is(err, services.ErrSupplierNotInFarm) ||
+		errors.Is(err, services.ErrOrderItemDuplicate) ||
+		errors.Is(err, services.ErrFoodNotInFarm) ||
+		errors.Is(err, services.ErrMedicineNotInFarm) ||
+		errors.Is(err, repository.ErrOrderItemNotFound) ||
+		errors.Is(err, repository.ErrReceiveExceedsOrdered)
+}
+
+// orderError writes the response for a failed purchase order action.
+func (h *Handler) orderError(c *gin.Context, err error) {
+	switch {
+	case isOrderInputError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrOrderStatus):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.authorize(c, err)
+	}
+}
+
+// @Summary Create a purchase order
+// @Description Create a draft order of foods and medicines from a supplier
+// @Tags purchase_orders
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.PurchaseOrderReq true "Purchase order"
+// @Success 201 {object} models.PurchaseOrderResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Supplier, food or medicine not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders [post]
+func (h *Handler) CreatePurchaseOrder(c *gin.Context) {
+	var req models.PurchaseOrderReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckFarmAccess(userID, req.FarmID, services.PermManagePurchases)) {
+		return
+	}
+
+	order, err := h.purchaseOrderService.CreateOrder(&req, userID, currentActor(c))
+	if err != nil {
+		h.orderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "purchase order created successfully", "purchase_order": order})
+}
+
+// @Summary Get the purchase orders of a farm
+// @Tags purchase_orders
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param supplier_id query string false "Supplier ID"
+// @Param status query string false "Status (draft, ordered, partially_received, received, cancelled)"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "created_at, status, expected_delivery, ordered_at or received_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.PurchaseOrder]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders [get]
+func (h *Handler) GetPurchaseOrders(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Query("farm_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	filter := models.PurchaseOrderFilter{FarmID: farmID, Status: c.Query("status")}
+	if value := c.Query("supplier_id"); value != "" {
+		if filter.SupplierID, err = uuid.Parse(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid supplier ID"})
+			return
+		}
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.PurchaseOrderSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	orders, total, err := h.purchaseOrderService.GetOrders(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, orders, total)
+}
+
+// @Summary Get a purchase order by ID
+// @Description Retrieve an order with its items and the quantities received so far
+// @Tags purchase_orders
+// @Produce application/json
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders/{id} [get]
+func (h *Handler) GetPurchaseOrderByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid purchase order ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckPurchaseOrderAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	order, err := h.purchaseOrderService.GetOrderByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if order == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrPurchaseOrderNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// @Summary Update a purchase order
+// @Description Replace the supplier, expected delivery, notes and items of a draft order
+// @Tags purchase_orders
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Purchase Order ID"
+// @Param request body models.PurchaseOrderDetails true "Purchase order"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Order is no longer a draft"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders/{id} [put]
+func (h *Handler) UpdatePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid purchase order ID"})
+		return
+	}
+
+	var req models.PurchaseOrderDetails
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckPurchaseOrderAccess(currentUserID(c), id, services.PermManagePurchases)) {
+		return
+	}
+
+	if err := h.purchaseOrderService.UpdateOrder(id, &req, currentActor(c)); err != nil {
+		h.orderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "purchase order updated successfully"})
+}
+
+// @Summary Delete a purchase order
+// @Description Delete a draft order
+// @Tags purchase_orders
+// @Produce application/json
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Order is no longer a draft"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders/{id} [delete]
+func (h *Handler) DeletePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid purchase order ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckPurchaseOrderAccess(currentUserID(c), id, services.PermManagePurchases)) {
+		return
+	}
+
+	if err := h.purchaseOrderService.DeleteOrder(id, currentActor(c)); err != nil {
+		h.orderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "purchase order deleted successfully"})
+}
+
+// @Summary Submit a purchase order
+// @Description Mark a draft order as sent to the supplier. It can no longer be edited.
+// @Tags purchase_orders
+// @Produce application/json
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Order is not a draft"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders/{id}/submit [post]
+func (h *Handler) SubmitPurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid purchase order ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckPurchaseOrderAccess(currentUserID(c), id, services.PermManagePurchases)) {
+		return
+	}
+
+	if err := h.purchaseOrderService.SubmitOrder(id, currentActor(c)); err != nil {
+		h.orderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "purchase order submitted"})
+}
+
+// @Summary Receive a purchase order
+// @Description Add delivered quantities to stock at the unit cost of their order line, in a single transaction. Without items, everything still outstanding is received.
+// @Tags purchase_orders
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Purchase Order ID"
+// @Param request body models.ReceivePurchaseOrderReq false "Delivered quantities"
+// @Success 200 {object} models.PurchaseOrderResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Order is not awaiting delivery"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders/{id}/receive [post]
+func (h *Handler) ReceivePurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid purchase order ID"})
+		return
+	}
+
+	var req models.ReceivePurchaseOrderReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckPurchaseOrderAccess(userID, id, services.PermManagePurchases)) {
+		return
+	}
+
+	order, err := h.purchaseOrderService.ReceiveOrder(id, &req, userID, currentActor(c))
+	if err != nil {
+		h.orderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "purchase order received", "purchase_order": order})
+}
+
+// @Summary Cancel a purchase order
+// @Description Cancel an order that was not fully received. Quantities already received stay in stock.
+// @Tags purchase_orders
+// @Produce application/json
+// @Param id path string true "Purchase Order ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Order is already received or cancelled"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /purchase_orders/{id}/cancel [post]
+func (h *Handler) CancelPurchaseOrder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid purchase order ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckPurchaseOrderAccess(currentUserID(c), id, services.PermManagePurchases)) {
+		return
+	}
+
+	if err := h.purchaseOrderService.CancelOrder(id, currentActor(c)); err != nil {
+		h.orderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "purchase order cancelled"})
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/services"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// bindReportFilter reads the farm, the optional animal, type and food filters
+// and the date range, which defaults to the last 30 days. to is inclusive.
+func bindReportFilter(c *gin.Context, filter *models.ReportFilter) bool {
+	var err error
+	if filter.FarmID, err = uuid.Parse(c.Query("farm_id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return false
+	}
+	if value := c.Query("animal_id"); value != "" {
+		if filter.AnimalID, err = uuid.Parse(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+			return false
+		}
+	}
+	if value := c.Query("food_id"); value != "" {
+		if filter.FoodID, err = uuid.Parse(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid food ID"})
+			return false
+		}
+	}
+	filter.Type = c.Query("type")
+
+	now := time.Now()
+	filter.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	if value := c.Query("to"); value != "" {
+		day, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date, expected YYYY-MM-DD"})
+			return false
+		}
+		filter.To = day.AddDate(0, 0, 1)
+	}
+	filter.From = filter.To.AddDate(0, 0, -30)
+	if value := c.Query("from"); value != "" {
+		if filter.From, err = time.Parse("2006-01-02", value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date, expected YYYY-MM-DD"})
+			return false
+		}
+	}
+	if !filter.From.Before(filter.To) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "from must not be after to"})
+		return false
+	}
+
+	return true
+}
+
+// @Summary Get feed consumption
+// @Description Sum the feed consumed on a farm per day, week or month, for the whole farm or per animal, animal type or food. Quantities of foods with different units are reported separately.
+// @Tags reports
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param from query string false "First day in YYYY-MM-DD format, defaults to 30 days before to"
+// @Param to query string false "Last day in YYYY-MM-DD format, defaults to today"
+// @Param interval query string false "Bucket: day, week (default) or month"
+// @Param group_by query string false "farm (default), animal, type or food"
+// @Param animal_id query string false "Animal ID"
+// @Param type query string false "Animal type"
+// @Param food_id query string false "Food ID"
+// @Success 200 {array} models.FeedingReportRow
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /reports/feeding [get]
+func (h *Handler) GetFeedingReport(c *gin.Context) {
+	var filter models.ReportFilter
+	if !bindReportFilter(c, &filter) {
+		return
+	}
+
+	filter.Interval = c.DefaultQuery("interval", services.IntervalWeek)
+	if filter.Interval != services.IntervalDay && filter.Interval != services.IntervalWeek && filter.Interval != services.IntervalMonth {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be one of day, week, month"})
+		return
+	}
+
+	filter.GroupBy = c.DefaultQuery("group_by", models.ReportGroupFarm)
+	switch filter.GroupBy {
+	case models.ReportGroupFarm, models.ReportGroupAnimal, models.ReportGroupType, models.ReportGroupFood:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group_by must be one of farm, animal, type, food"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	report, err := h.reportService.GetFeedingConsumption(&filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// @Summary Get feed conversion ratios
+// @Description Relate the feed consumed to the weight gained over the period, for the whole farm or per animal or animal type. Only animals weighed at least twice in the period are counted. Use food_id to restrict the ratio to foods measured in the same unit as the weights.
+// @Tags reports
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param from query string false "First day in YYYY-MM-DD format, defaults to 30 days before to"
+// @Param to query string false "Last day in YYYY-MM-DD format, defaults to today"
+// @Param group_by query string false "farm (default), animal or type"
+// @Param animal_id query string false "Animal ID"
+// @Param type query string false "Animal type"
+// @Param food_id query string false "Food ID"
+// @Success 200 {array} models.FeedConversion
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /reports/feeding/conversion [get]
+func (h *Handler) GetFeedConversionReport(c *gin.Context) {
+	var filter models.ReportFilter
+	if !bindReportFilter(c, &filter) {
+		return
+	}
+
+	filter.GroupBy = c.DefaultQuery("group_by", models.ReportGroupFarm)
+	switch filter.GroupBy {
+	case models.ReportGroupFarm, models.ReportGroupAnimal, models.ReportGroupType:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "group_by must be one of farm, animal, type"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), filter.FarmID, services.PermViewFarm)) {
+		return
+	}
+
+	report, err := h.reportService.GetFeedConversion(&filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -3,7 +3,9 @@ package handlers
 import (
 	_ "farmish/docs"
 	"farmish/internal/services"
+	"farmish/pkg/config"
 	"farmish/pkg/middleware"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -11,13 +13,31 @@ import (
 )
 
 type Handler struct {
-	userService          *services.UserService
-	farmService          *services.FarmService
-	animalService        *services.AnimalService
-	foodService          *services.FoodService
-	medicineService      *services.MedicineService
-	feedingRecordService *services.FeedingRecordService
-	medicalRecordService *services.MedicalRecordService
+	userService              *services.UserService
+	farmService              *services.FarmService
+	animalService            *services.AnimalService
+	foodService              *services.FoodService
+	medicineService          *services.MedicineService
+	feedingRecordService     *services.FeedingRecordService
+	wateringRecordService    *services.WateringRecordService
+	feedingScheduleService   *services.FeedingScheduleService
+	medicalRecordService     *services.MedicalRecordService
+	alertService             *services.AlertService
+	accessService            *services.AccessService
+	farmMemberService        *services.FarmMemberService
+	stockService             *services.StockService
+	treatmentPlanService     *services.TreatmentPlanService
+	weightMeasurementService *services.WeightMeasurementService
+	reportService            *services.ReportService
+	supplierService          *services.SupplierService
+	purchaseOrderService     *services.PurchaseOrderService
+	medicineBatchService     *services.MedicineBatchService
+	breedingService          *services.BreedingService
+	pedigreeService          *services.PedigreeService
+	productionRecordService  *services.ProductionRecordService
+	animalEventService       *services.AnimalEventService
+	trashService             *services.TrashService
+	auditService             *services.AuditService
 }
 
 func NewHandler(userService *services.UserService, farmService *services.FarmService,
@@ -25,15 +45,51 @@ func NewHandler(userService *services.UserService, farmService *services.FarmSer
 	foodService *services.FoodService, medicineService *services.MedicineService,
 	feedingRecordService *services.FeedingRecordService,
 	medicalRecordService *services.MedicalRecordService,
+	alertService *services.AlertService,
+	accessService *services.AccessService,
+	farmMemberService *services.FarmMemberService,
+	stockService *services.StockService,
+	wateringRecordService *services.WateringRecordService,
+	feedingScheduleService *services.FeedingScheduleService,
+	treatmentPlanService *services.TreatmentPlanService,
+	weightMeasurementService *services.WeightMeasurementService,
+	reportService *services.ReportService,
+	supplierService *services.SupplierService,
+	purchaseOrderService *services.PurchaseOrderService,
+	medicineBatchService *services.MedicineBatchService,
+	breedingService *services.BreedingService,
+	pedigreeService *services.PedigreeService,
+	productionRecordService *services.ProductionRecordService,
+	animalEventService *services.AnimalEventService,
+	trashService *services.TrashService,
+	auditService *services.AuditService,
 ) *Handler {
 	return &Handler{
-		userService:          userService,
-		farmService:          farmService,
-		animalService:        animalService,
-		foodService:          foodService,
-		medicineService:      medicineService,
-		feedingRecordService: feedingRecordService,
-		medicalRecordService: medicalRecordService,
+		userService:              userService,
+		farmService:              farmService,
+		animalService:            animalService,
+		foodService:              foodService,
+		medicineService:          medicineService,
+		feedingRecordService:     feedingRecordService,
+		medicalRecordService:     medicalRecordService,
+		alertService:             alertService,
+		accessService:            accessService,
+		farmMemberService:        farmMemberService,
+		stockService:             stockService,
+		wateringRecordService:    wateringRecordService,
+		feedingScheduleService:   feedingScheduleService,
+		treatmentPlanService:     treatmentPlanService,
+		weightMeasurementService: weightMeasurementService,
+		reportService:            reportService,
+		supplierService:          supplierService,
+		purchaseOrderService:     purchaseOrderService,
+		medicineBatchService:     medicineBatchService,
+		breedingService:          breedingService,
+		pedigreeService:          pedigreeService,
+		productionRecordService:  productionRecordService,
+		animalEventService:       animalEventService,
+		trashService:             trashService,
+		auditService:             auditService,
 	}
 }
 
@@ -51,20 +107,26 @@ func NewHandler(userService *services.UserService, farmService *services.FarmSer
 // @type 			apikey
 // @schema 			bearer
 // @bearerFormat	JWT
-func Run(h *Handler) *gin.Engine {
+func Run(h *Handler, cfg config.ServerConfig) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.CORS(cfg.CORSOrigins), middleware.RequestID())
 
-	url := ginSwagger.URL("http://localhost:8080/swagger/doc.json")
+	url := ginSwagger.URL(strings.TrimSuffix(cfg.PublicURL, "/") + "/swagger/doc.json")
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
 
+	authMiddleware := middleware.AuthMiddleware(h.userService)
+
 	// AUTH ROUTES
 	authRoutes := router.Group("/auth")
 	{
 		authRoutes.POST("/login", h.Login)
 		authRoutes.POST("/signup", h.SignUp)
+		authRoutes.POST("/refresh", h.RefreshToken)
+		authRoutes.POST("/logout", authMiddleware, h.Logout)
+		authRoutes.POST("/logout-all", authMiddleware, h.LogoutAll)
 	}
 
-	router.Use(middleware.AuthMiddleware())
+	router.Use(authMiddleware)
 
 	// USER ROUTES
 	userRoutes := router.Group("/users")
@@ -83,6 +145,33 @@ func Run(h *Handler) *gin.Engine {
 		farmRoutes.GET("/", h.GetAllFarms)
 		farmRoutes.PUT("/:id", h.UpdateFarm)
 		farmRoutes.DELETE("/:id", h.DeleteFarm)
+		farmRoutes.GET("/:id/members", h.GetFarmMembers)
+		farmRoutes.PUT("/:id/members/:user_id", h.UpdateFarmMemberRole)
+		farmRoutes.DELETE("/:id/members/:user_id", h.RemoveFarmMember)
+		farmRoutes.POST("/:id/invitations", h.InviteFarmMember)
+		farmRoutes.GET("/:id/invitations", h.GetFarmInvitations)
+		farmRoutes.DELETE("/:id/invitations/:invitation_id", h.RevokeFarmInvitation)
+		farmRoutes.GET("/:id/stock/reconciliation", h.ReconcileFarmStock)
+		farmRoutes.GET("/:id/stock/forecast", h.ForecastFarmStock)
+		farmRoutes.GET("/:id/stock/reorder", h.GetFarmReorderList)
+		farmRoutes.GET("/:id/overdue", h.GetOverdueAnimals)
+		farmRoutes.GET("/:id/treatments/upcoming", h.GetFarmUpcomingTreatments)
+		farmRoutes.GET("/:id/withdrawals", h.GetFarmWithdrawals)
+		farmRoutes.GET("/:id/medicine_batches/expiring", h.GetExpiringMedicineBatches)
+		farmRoutes.GET("/:id/births/upcoming", h.GetUpcomingBirths)
+		farmRoutes.GET("/:id/growth", h.GetFarmGrowth)
+		farmRoutes.GET("/:id/growth/below_average", h.GetBelowAverageGrowth)
+		farmRoutes.GET("/:id/audit", h.GetFarmAuditTimeline)
+		farmRoutes.GET("/:id/feeding_records", h.GetFarmFeedingRecords)
+		farmRoutes.GET("/:id/medical_records", h.GetFarmMedicalRecords)
+	}
+
+	// INVITATION ROUTES
+	invitationRoutes := router.Group("/invitations")
+	{
+		invitationRoutes.GET("", h.GetMyInvitations)
+		invitationRoutes.POST("/:id/accept", h.AcceptInvitation)
+		invitationRoutes.POST("/:id/decline", h.DeclineInvitation)
 	}
 
 	// ANIMAL ROUTES
@@ -93,6 +182,35 @@ func Run(h *Handler) *gin.Engine {
 		animalRoutes.GET("/", h.GetAnimalsByFarmID)
 		animalRoutes.PUT("/", h.UpdateAnimal)
 		animalRoutes.DELETE("/:id", h.DeleteAnimal)
+		animalRoutes.POST("/:id/events", h.CreateAnimalEvent)
+		animalRoutes.GET("/:id/events", h.GetAnimalEvents)
+		animalRoutes.GET("/:id/treatments/upcoming", h.GetAnimalUpcomingTreatments)
+		animalRoutes.POST("/:id/weights", h.CreateWeightMeasurement)
+		animalRoutes.GET("/:id/weights", h.GetAnimalWeights)
+		animalRoutes.DELETE("/:id/weights/:measurement_id", h.DeleteWeightMeasurement)
+		animalRoutes.GET("/:id/reproduction", h.GetReproductiveHistory)
+		animalRoutes.GET("/:id/pedigree", h.GetAnimalPedigree)
+		animalRoutes.GET("/:id/descendants", h.GetAnimalDescendants)
+		animalRoutes.GET("/inbreeding", h.GetInbreedingCoefficient)
+	}
+
+	// BREEDING ROUTES
+	breedingRoutes := router.Group("/breeding_events")
+	{
+		breedingRoutes.POST("", h.CreateBreedingEvent)
+		breedingRoutes.GET("/:id", h.GetBreedingEventByID)
+		breedingRoutes.DELETE("/:id", h.DeleteBreedingEvent)
+		breedingRoutes.POST("/:id/pregnancy_checks", h.CreatePregnancyCheck)
+		breedingRoutes.POST("/:id/birth", h.RecordBirth)
+	}
+
+	// PRODUCTION RECORD ROUTES
+	productionRecordRoutes := router.Group("/production_records")
+	{
+		productionRecordRoutes.POST("", h.CreateProductionRecord)
+		productionRecordRoutes.GET("", h.GetProductionRecords)
+		productionRecordRoutes.GET("/:id", h.GetProductionRecordByID)
+		productionRecordRoutes.DELETE("/:id", h.DeleteProductionRecord)
 	}
 
 	// FOOD ROUTES
@@ -101,6 +219,9 @@ func Run(h *Handler) *gin.Engine {
 		foodRoutes.POST("/", h.AddFoodToWarehouse)
 		foodRoutes.GET("/:farm_id", h.GetWarehouseFoods)
 		foodRoutes.GET("/food/:food_id", h.GetFoodByID)
+		foodRoutes.GET("/food/:food_id/movements", h.GetFoodMovements)
+		foodRoutes.POST("/food/:food_id/movements", h.RecordFoodMovement)
+		foodRoutes.GET("/food/:food_id/reconciliation", h.ReconcileFood)
 		foodRoutes.PUT("/", h.UpdateWarehouseFood)
 		foodRoutes.DELETE("/:food_id", h.RemoveWarehouseFood)
 	}
@@ -113,6 +234,40 @@ func Run(h *Handler) *gin.Engine {
 		medicineRoutes.GET("/:id", h.GetMedicineByID)
 		medicineRoutes.PUT("/:id", h.UpdateMedicine)
 		medicineRoutes.DELETE("/:id", h.DeleteMedicine)
+		medicineRoutes.GET("/:id/movements", h.GetMedicineMovements)
+		medicineRoutes.POST("/:id/movements", h.RecordMedicineMovement)
+		medicineRoutes.GET("/:id/reconciliation", h.ReconcileMedicine)
+		medicineRoutes.POST("/:id/batches", h.CreateMedicineBatch)
+		medicineRoutes.GET("/:id/batches", h.GetMedicineBatches)
+	}
+
+	// MEDICINE BATCH ROUTES
+	medicineBatchRoutes := router.Group("/medicine_batches")
+	{
+		medicineBatchRoutes.POST("/:id/discard", h.DiscardMedicineBatch)
+	}
+
+	// SUPPLIER ROUTES
+	supplierRoutes := router.Group("/suppliers")
+	{
+		supplierRoutes.POST("", h.CreateSupplier)
+		supplierRoutes.GET("", h.GetSuppliers)
+		supplierRoutes.GET("/:id", h.GetSupplierByID)
+		supplierRoutes.PUT("/:id", h.UpdateSupplier)
+		supplierRoutes.DELETE("/:id", h.DeleteSupplier)
+	}
+
+	// PURCHASE ORDER ROUTES
+	purchaseOrderRoutes := router.Group("/purchase_orders")
+	{
+		purchaseOrderRoutes.POST("", h.CreatePurchaseOrder)
+		purchaseOrderRoutes.GET("", h.GetPurchaseOrders)
+		purchaseOrderRoutes.GET("/:id", h.GetPurchaseOrderByID)
+		purchaseOrderRoutes.PUT("/:id", h.UpdatePurchaseOrder)
+		purchaseOrderRoutes.DELETE("/:id", h.DeletePurchaseOrder)
+		purchaseOrderRoutes.POST("/:id/submit", h.SubmitPurchaseOrder)
+		purchaseOrderRoutes.POST("/:id/receive", h.ReceivePurchaseOrder)
+		purchaseOrderRoutes.POST("/:id/cancel", h.CancelPurchaseOrder)
 	}
 
 	// FEEDING RECORD ROUTES
@@ -125,6 +280,34 @@ func Run(h *Handler) *gin.Engine {
 		feedingRecordRoutes.DELETE("/:id", h.DeleteFeedingRecord)
 	}
 
+	// FEEDING SCHEDULE ROUTES
+	feedingScheduleRoutes := router.Group("/feeding_schedules")
+	{
+		feedingScheduleRoutes.POST("", h.CreateFeedingSchedule)
+		feedingScheduleRoutes.GET("", h.GetFeedingSchedules)
+		feedingScheduleRoutes.GET("/:id", h.GetFeedingScheduleByID)
+		feedingScheduleRoutes.PUT("/:id", h.UpdateFeedingSchedule)
+		feedingScheduleRoutes.DELETE("/:id", h.DeleteFeedingSchedule)
+	}
+
+	// FEEDING TASK ROUTES
+	feedingTaskRoutes := router.Group("/feeding_tasks")
+	{
+		feedingTaskRoutes.GET("", h.GetFeedingTasks)
+		feedingTaskRoutes.POST("/:id/complete", h.CompleteFeedingTask)
+		feedingTaskRoutes.POST("/:id/skip", h.SkipFeedingTask)
+	}
+
+	// WATERING RECORD ROUTES
+	wateringRecordRoutes := router.Group("/watering_records")
+	{
+		wateringRecordRoutes.POST("", h.CreateWateringRecord)
+		wateringRecordRoutes.GET("/:id", h.GetWateringRecordByID)
+		wateringRecordRoutes.GET("/animal/:animal_id", h.GetWateringRecordsByAnimalID)
+		wateringRecordRoutes.PUT("/:id", h.UpdateWateringRecord)
+		wateringRecordRoutes.DELETE("/:id", h.DeleteWateringRecord)
+	}
+
 	// MEDICAL RECORD ROUTES
 	medicalRecords := router.Group("/medical_records")
 	{
@@ -135,5 +318,49 @@ func Run(h *Handler) *gin.Engine {
 		medicalRecords.DELETE("/:id", h.DeleteMedicalRecord)
 	}
 
+	// TREATMENT PLAN ROUTES
+	treatmentPlanRoutes := router.Group("/treatment_plans")
+	{
+		treatmentPlanRoutes.POST("", h.CreateTreatmentPlan)
+		treatmentPlanRoutes.GET("", h.GetTreatmentPlans)
+		treatmentPlanRoutes.GET("/:id", h.GetTreatmentPlanByID)
+		treatmentPlanRoutes.PUT("/:id", h.UpdateTreatmentPlan)
+		treatmentPlanRoutes.DELETE("/:id", h.DeleteTreatmentPlan)
+	}
+
+	// TREATMENT EVENT ROUTES
+	treatmentEventRoutes := router.Group("/treatment_events")
+	{
+		treatmentEventRoutes.POST("/:id/complete", h.CompleteTreatmentEvent)
+		treatmentEventRoutes.POST("/:id/skip", h.SkipTreatmentEvent)
+	}
+
+	// REPORT ROUTES
+	reportRoutes := router.Group("/reports")
+	{
+		reportRoutes.GET("/feeding", h.GetFeedingReport)
+		reportRoutes.GET("/feeding/conversion", h.GetFeedConversionReport)
+		reportRoutes.GET("/production", h.GetProductionReport)
+		reportRoutes.GET("/mortality", h.GetMortalityReport)
+	}
+
+	// ALERT ROUTES
+	alertRoutes := router.Group("/alerts")
+	{
+		alertRoutes.GET("", h.GetAlerts)
+		alertRoutes.PUT("/read", h.MarkAlerts)
+		alertRoutes.DELETE("/:id", h.DismissAlert)
+	}
+
+	// TRASH ROUTES
+	trashRoutes := router.Group("/trash")
+	{
+		trashRoutes.GET("", h.GetTrash)
+		trashRoutes.POST("/:type/:id/restore", h.RestoreTrashItem)
+	}
+
+	// AUDIT ROUTES
+	router.GET("/audit", h.GetAuditHistory)
+
 	return router
 }
@@ -0,0 +1,379 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Get the stock movements of a food
+// @Description Retrieve the inventory ledger of a food, newest first
+// @Tags foods
+// @Produce application/json
+// @Param food_id path string true "Food ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "created_at, movement_type or quantity, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.StockMovement]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Food not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /foods/food/{food_id}/movements [get]
+func (h *Handler) GetFoodMovements(c *gin.Context) {
+	foodID, err := uuid.Parse(c.Param("food_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.MovementSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFoodAccess(currentUserID(c), foodID, services.PermViewFarm)) {
+		return
+	}
+
+	movements, total, err := h.stockService.GetMovements(models.StockItemFood, foodID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, movements, total)
+}
+
+// @Summary Record a stock movement for a food
+// @Description Book a purchase, waste, adjustment or transfer of a food. Purchases add and waste removes stock; adjustments and transfers use the sign of the quantity.
+// @Tags foods
+// @Accept application/json
+// @Produce application/json
+// @Param food_id path string true "Food ID"
+// @Param request body models.StockMovementReq true "Stock movement"
+// @Success 201 {object} models.StockMovementResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Food not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /foods/food/{food_id}/movements [post]
+func (h *Handler) RecordFoodMovement(c *gin.Context) {
+	foodID, err := uuid.Parse(c.Param("food_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	var req models.StockMovementReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input"})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckFoodAccess(userID, foodID, services.PermManageFoods)) {
+		return
+	}
+
+	movement, err := h.stockService.RecordFoodMovement(foodID, &req, userID, currentActor(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientQuantity) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "stock movement recorded successfully", "movement": movement})
+}
+
+// @Summary Reconcile the stock of a food
+// @Description Compare the current quantity of a food with the sum of its stock movements
+// @Tags foods
+// @Produce application/json
+// @Param food_id path string true "Food ID"
+// @Success 200 {object} models.StockReconciliation
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Food not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /foods/food/{food_id}/reconciliation [get]
+func (h *Handler) ReconcileFood(c *gin.Context) {
+	foodID, err := uuid.Parse(c.Param("food_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid food ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFoodAccess(currentUserID(c), foodID, services.PermViewFarm)) {
+		return
+	}
+
+	reconciliation, err := h.stockService.Reconcile(models.StockItemFood, foodID)
+	if err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliation)
+}
+
+// @Summary Get the stock movements of a medicine
+// @Description Retrieve the inventory ledger of a medicine, newest first
+// @Tags medicines
+// @Produce application/json
+// @Param id path string true "Medicine ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "created_at, movement_type or quantity, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.StockMovement]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /medicines/{id}/movements [get]
+func (h *Handler) GetMedicineMovements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.MovementSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	movements, total, err := h.stockService.GetMovements(models.StockItemMedicine, id, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, movements, total)
+}
+
+// @Summary Record a stock movement for a medicine
+// @Description Book a purchase, waste, adjustment or transfer of a medicine. Purchases add and waste removes stock; adjustments and transfers use the sign of the quantity.
+// @Tags medicines
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Medicine ID"
+// @Param request body models.StockMovementReq true "Stock movement"
+// @Success 201 {object} models.StockMovementResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /medicines/{id}/movements [post]
+func (h *Handler) RecordMedicineMovement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+
+	var req models.StockMovementReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := currentUserID(c)
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(userID, id, services.PermManageMedicines)) {
+		return
+	}
+
+	movement, err := h.stockService.RecordMedicineMovement(id, &req, userID, currentActor(c))
+	if err != nil {
+		if errors.Is(err, repository.ErrInsufficientQuantity) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "stock movement recorded successfully", "movement": movement})
+}
+
+// @Summary Reconcile the stock of a medicine
+// @Description Compare the current quantity of a medicine with the sum of its stock movements
+// @Tags medicines
+// @Produce application/json
+// @Param id path string true "Medicine ID"
+// @Success 200 {object} models.StockReconciliation
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /medicines/{id}/reconciliation [get]
+func (h *Handler) ReconcileMedicine(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckMedicineAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	reconciliation, err := h.stockService.Reconcile(models.StockItemMedicine, id)
+	if err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliation)
+}
+
+// @Summary Reconcile the stock of a farm
+// @Description Compare the quantity of every food and medicine of the farm with the sum of its stock movements
+// @Tags farms
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Success 200 {array} models.StockReconciliation
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/stock/reconciliation [get]
+func (h *Handler) ReconcileFarmStock(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	reconciliations, err := h.stockService.ReconcileFarm(farmID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, reconciliations)
+}
+
+// positiveIntQuery reads an optional positive integer query parameter.
+func positiveIntQuery(c *gin.Context, name string, defaultValue int) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return defaultValue, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": name + " must be a positive integer"})
+		return 0, false
+	}
+	return n, true
+}
+
+// @Summary Forecast the stock of a farm
+// @Description Estimate the daily use of every food and medicine from its consumption over the window and from active feeding schedules and upcoming treatments, and the days left until it falls below its threshold and until it runs out
+// @Tags farms
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param window query int false "Days of consumption history to average (default 30)"
+// @Success 200 {array} models.StockForecast
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/stock/forecast [get]
+func (h *Handler) ForecastFarmStock(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	window, ok := positiveIntQuery(c, "window", 30)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	forecasts, err := h.stockService.Forecast(farmID, window)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, forecasts)
+}
+
+// @Summary Get the reorder list of a farm
+// @Description List the foods and medicines expected to fall below their threshold within the lead time, soonest first, with the quantity to order to cover the following days
+// @Tags farms
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param window query int false "Days of consumption history to average (default 30)"
+// @Param lead_days query int false "Days until an order arrives (default 7)"
+// @Param cover_days query int false "Days an order should last after it arrives (default 30)"
+// @Success 200 {array} models.ReorderSuggestion
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/stock/reorder [get]
+func (h *Handler) GetFarmReorderList(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	window, ok := positiveIntQuery(c, "window", 30)
+	if !ok {
+		return
+	}
+	leadDays, ok := positiveIntQuery(c, "lead_days", 7)
+	if !ok {
+		return
+	}
+	coverDays, ok := positiveIntQuery(c, "cover_days", 30)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	suggestions, err := h.stockService.ReorderList(farmID, window, leadDays, coverDays)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
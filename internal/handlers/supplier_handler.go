@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Create a supplier
+// @Tags suppliers
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.SupplierReq true "Supplier"
+// @Success 201 {object} models.SupplierResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /suppliers [post]
+func (h *Handler) CreateSupplier(c *gin.Context) {
+	var req models.SupplierReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), req.FarmID, services.PermManagePurchases)) {
+		return
+	}
+
+	supplier, err := h.supplierService.CreateSupplier(&req, currentActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "supplier created successfully", "supplier": supplier})
+}
+
+// @Summary Get the suppliers of a farm
+// @Tags suppliers
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "name (default) or created_at, prefixed with - for descending order"
+// @Success 200 {object} models.Page[models.Supplier]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /suppliers [get]
+func (h *Handler) GetSuppliers(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Query("farm_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.SupplierSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	suppliers, total, err := h.supplierService.GetSuppliersByFarmID(farmID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, suppliers, total)
+}
+
+// @Summary Get a supplier by ID
+// @Tags suppliers
+// @Produce application/json
+// @Param id path string true "Supplier ID"
+// @Success 200 {object} models.Supplier
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /suppliers/{id} [get]
+func (h *Handler) GetSupplierByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid supplier ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckSupplierAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	supplier, err := h.supplierService.GetSupplierByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if supplier == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrSupplierNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+// @Summary Update a supplier
+// @Tags suppliers
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Supplier ID"
+// @Param request body models.SupplierDetails true "Supplier"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /suppliers/{id} [put]
+func (h *Handler) UpdateSupplier(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid supplier ID"})
+		return
+	}
+
+	var req models.SupplierDetails
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckSupplierAccess(currentUserID(c), id, services.PermManagePurchases)) {
+		return
+	}
+
+	if err := h.supplierService.UpdateSupplier(id, &req, currentActor(c)); err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "supplier updated successfully"})
+}
+
+// @Summary Delete a supplier
+// @Description Delete a supplier that has no purchase orders
+// @Tags suppliers
+// @Produce application/json
+// @Param id path string true "Supplier ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Supplier has purchase orders"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /suppliers/{id} [delete]
+func (h *Handler) DeleteSupplier(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid supplier ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckSupplierAccess(currentUserID(c), id, services.PermManagePurchases)) {
+		return
+	}
+
+	if err := h.supplierService.DeleteSupplier(id, currentActor(c)); err != nil {
+		if errors.Is(err, repository.ErrSupplierInUse) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "supplier deleted successfully"})
+}
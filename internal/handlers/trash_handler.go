@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Get the trash
+// @Description List the deleted animals, foods, medicines and records of a farm, latest first, with the time each one is purged for good. Records deleted together with their animal are not listed; they come back when the animal is restored. Without farm_id, list the deleted farms the caller owns.
+// @Tags trash
+// @Produce application/json
+// @Param farm_id query string false "Farm ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "deleted_at, type or name, prefixed with - for descending order; latest first by default"
+// @Success 200 {object} models.Page[models.TrashItem]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /trash [get]
+func (h *Handler) GetTrash(c *gin.Context) {
+	userID := currentUserID(c)
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.TrashSortFields) {
+		return
+	}
+
+	var items []models.TrashItem
+	var total int
+	var err error
+	if farmIDStr := c.Query("farm_id"); farmIDStr != "" {
+		farmID, parseErr := uuid.Parse(farmIDStr)
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+			return
+		}
+		if !h.authorize(c, h.accessService.CheckFarmAccess(userID, farmID, services.PermViewFarm)) {
+			return
+		}
+		items, total, err = h.trashService.GetItems(farmID, &page)
+	} else {
+		items, total, err = h.trashService.GetFarms(userID, &page)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, items, total)
+}
+
+// @Summary Restore a deleted item
+// @Description Take a farm, animal, food, medicine or record out of the trash. A restored farm or animal brings back everything deleted with it. Restored feeding and medical records consume their quantity from the stock again. Restoring needs the permission that allows deleting the item; farms can only be restored by their owner.
+// @Tags trash
+// @Produce application/json
+// @Param type path string true "farm, animal, food, medicine, feeding_record, watering_record, medical_record or production_record"
+// @Param id path string true "Item ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Item not found in trash"
+// @Failure 409 {object} models.ErrResp "Animal of the record is deleted or stock is insufficient"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /trash/{type}/{id}/restore [post]
+func (h *Handler) RestoreTrashItem(c *gin.Context) {
+	itemType := c.Param("type")
+	switch itemType {
+	case models.TrashFarm, models.TrashAnimal, models.TrashFood, models.TrashMedicine, models.TrashFeedingRecord,
+		models.TrashWateringRecord, models.TrashMedicalRecord, models.TrashProductionRecord:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be one of farm, animal, food, medicine, feeding_record, watering_record, medical_record, production_record"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid item ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckTrashItemAccess(currentUserID(c), itemType, id)) {
+		return
+	}
+
+	if err := h.trashService.RestoreItem(itemType, id, currentActor(c)); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrTrashParentDeleted),
+			errors.Is(err, repository.ErrInsufficientQuantity),
+			errors.Is(err, repository.ErrStockExpired):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			h.authorize(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "item restored successfully"})
+}
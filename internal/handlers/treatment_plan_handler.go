@@ -0,0 +1,374 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Create a treatment plan
+// @Description Define a vaccination or treatment protocol applied to every animal of a type on the farm. The first dose of each animal is scheduled right away.
+// @Tags treatment_plans
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.TreatmentPlanReq true "Treatment plan"
+// @Success 201 {object} models.TreatmentPlanResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Medicine not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /treatment_plans [post]
+func (h *Handler) CreateTreatmentPlan(c *gin.Context) {
+	var req models.TreatmentPlanReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), req.FarmID, services.PermManageTreatmentPlans)) {
+		return
+	}
+
+	plan, err := h.treatmentPlanService.CreatePlan(&req, currentActor(c))
+	if err != nil {
+		if errors.Is(err, services.ErrMedicineNotInFarm) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "treatment plan created successfully", "treatment_plan": plan})
+}
+
+// @Summary Get the treatment plans of a farm
+// @Tags treatment_plans
+// @Produce application/json
+// @Param farm_id query string true "Farm ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "name, animal_type or created_at, prefixed with - for descending order; oldest first by default"
+// @Success 200 {object} models.Page[models.TreatmentPlan]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /treatment_plans [get]
+func (h *Handler) GetTreatmentPlans(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Query("farm_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.TreatmentPlanSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	plans, total, err := h.treatmentPlanService.GetPlansByFarmID(farmID, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, plans, total)
+}
+
+// @Summary Get a treatment plan by ID
+// @Tags treatment_plans
+// @Produce application/json
+// @Param id path string true "Treatment Plan ID"
+// @Success 200 {object} models.TreatmentPlan
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /treatment_plans/{id} [get]
+func (h *Handler) GetTreatmentPlanByID(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid treatment plan ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckTreatmentPlanAccess(currentUserID(c), id, services.PermViewFarm)) {
+		return
+	}
+
+	plan, err := h.treatmentPlanService.GetPlanByID(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if plan == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": repository.ErrTreatmentPlanNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// @Summary Update a treatment plan
+// @Description Change the dosage or intervals of a plan, or pause it. Pending events keep their due date; later doses follow the new intervals.
+// @Tags treatment_plans
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Treatment Plan ID"
+// @Param request body models.UpdateTreatmentPlanReq true "Treatment plan"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /treatment_plans/{id} [put]
+func (h *Handler) UpdateTreatmentPlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid treatment plan ID"})
+		return
+	}
+
+	var req models.UpdateTreatmentPlanReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckTreatmentPlanAccess(currentUserID(c), id, services.PermManageTreatmentPlans)) {
+		return
+	}
+
+	if err := h.treatmentPlanService.UpdatePlan(id, &req, currentActor(c)); err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "treatment plan updated successfully"})
+}
+
+// @Summary Delete a treatment plan
+// @Description Delete a plan together with its treatment events. Medical records created from completed events are kept.
+// @Tags treatment_plans
+// @Produce application/json
+// @Param id path string true "Treatment Plan ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /treatment_plans/{id} [delete]
+func (h *Handler) DeleteTreatmentPlan(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid treatment plan ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckTreatmentPlanAccess(currentUserID(c), id, services.PermManageTreatmentPlans)) {
+		return
+	}
+
+	if err := h.treatmentPlanService.DeletePlan(id, currentActor(c)); err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "treatment plan deleted successfully"})
+}
+
+// @Summary Get the upcoming treatments of an animal
+// @Description Retrieve the pending treatment events of an animal, overdue ones first
+// @Tags treatment_events
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "due_date, dose_number or created_at, prefixed with - for descending order; overdue first by default"
+// @Success 200 {object} models.Page[models.TreatmentEvent]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/treatments/upcoming [get]
+func (h *Handler) GetAnimalUpcomingTreatments(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.TreatmentEventSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermViewFarm)) {
+		return
+	}
+
+	events, total, err := h.treatmentPlanService.GetUpcomingEvents(&models.TreatmentEventFilter{AnimalID: animalID}, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, events, total)
+}
+
+// @Summary Get the upcoming treatments of a farm
+// @Description Retrieve the pending treatment events of every animal on the farm, overdue ones first
+// @Tags treatment_events
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param days query int false "Only events due within this many days"
+// @Param overdue query bool false "Only overdue events"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of items to skip"
+// @Param sort query string false "due_date, dose_number or created_at, prefixed with - for descending order; overdue first by default"
+// @Success 200 {object} models.Page[models.TreatmentEvent]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/treatments/upcoming [get]
+func (h *Handler) GetFarmUpcomingTreatments(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	filter := models.TreatmentEventFilter{FarmID: farmID, OverdueOnly: c.Query("overdue") == "true"}
+	if value := c.Query("days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "days must be a non-negative integer"})
+			return
+		}
+		filter.Until = time.Now().AddDate(0, 0, days)
+	}
+
+	var page models.PageQuery
+	if !bindPage(c, &page, models.TreatmentEventSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	events, total, err := h.treatmentPlanService.GetUpcomingEvents(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respondPage(c, &page, events, total)
+}
+
+// @Summary Complete a treatment event
+// @Description Create the medical record of a pending event, decrementing the medicine stock, and schedule the next dose
+// @Tags treatment_events
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Treatment Event ID"
+// @Param request body models.CompleteTreatmentEventReq false "Overrides for the planned treatment"
+// @Success 200 {object} models.CompleteTreatmentEventResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Event is no longer pending"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /treatment_events/{id}/complete [post]
+func (h *Handler) CompleteTreatmentEvent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid treatment event ID"})
+		return
+	}
+
+	var req models.CompleteTreatmentEventReq
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if !h.authorize(c, h.accessService.CheckTreatmentEventAccess(currentUserID(c), id, services.PermRecordTreatment)) {
+		return
+	}
+
+	recordID, err := h.treatmentPlanService.CompleteEvent(id, currentUserID(c), &req, currentActor(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrEventNotPending):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrInsufficientQuantity), errors.Is(err, repository.ErrStockExpired):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.authorize(c, err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "treatment event completed", "medical_record_id": recordID})
+}
+
+// @Summary Skip a treatment event
+// @Description Mark a pending event as skipped and schedule the next dose
+// @Tags treatment_events
+// @Produce application/json
+// @Param id path string true "Treatment Event ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 409 {object} models.ErrResp "Event is no longer pending"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /treatment_events/{id}/skip [post]
+func (h *Handler) SkipTreatmentEvent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid treatment event ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckTreatmentEventAccess(currentUserID(c), id, services.PermRecordTreatment)) {
+		return
+	}
+
+	if err := h.treatmentPlanService.SkipEvent(id, currentActor(c)); err != nil {
+		if errors.Is(err, repository.ErrEventNotPending) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "treatment event skipped"})
+}
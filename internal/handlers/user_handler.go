@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"errors"
 	"farmish/internal/models"
+	"farmish/internal/repository"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -15,6 +17,7 @@ import (
 // @Param			id		path		string			true	"User ID (UUID)"
 // @Success		200		{object}	models.User		"User details"
 // @Failure		400		{object}	models.ErrResp	"Invalid user ID format"
+// @Failure		403		{object}	models.ErrResp	"Access denied"
 // @Failure		404		{object}	models.ErrResp	"User not found"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
@@ -27,6 +30,10 @@ func (h *Handler) GetUserByID(ctx *gin.Context) {
 		return
 	}
 
+	if !h.authorize(ctx, h.accessService.CheckUserAccess(currentUserID(ctx), userID)) {
+		return
+	}
+
 	user, err := h.userService.GetUserByID(userID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -42,25 +49,34 @@ func (h *Handler) GetUserByID(ctx *gin.Context) {
 }
 
 // @Summary		Get all users
-// @Description	Retrieve a list of all registered users.
+// @Description	Retrieve the users visible to the authenticated user.
 // @Tags			users
 // @Produce		application/json
-// @Success		200		{array}		models.User		"List of users"
+// @Param			limit	query		int				false	"Page size, 50 by default and 200 at most"
+// @Param			offset	query		int				false	"Number of users to skip"
+// @Param			sort	query		string			false	"name (default), email or created_at, prefixed with - for descending order"
+// @Success		200		{object}	models.Page[models.User]	"Page of users"
+// @Failure		400		{object}	models.ErrResp	"Invalid page or sort"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
 // @Router			/users [get]
 func (h *Handler) GetAllUsers(ctx *gin.Context) {
-	users, err := h.userService.GetAllUsers()
+	var page models.PageQuery
+	if !bindPage(ctx, &page, models.UserSortFields) {
+		return
+	}
+
+	users, total, err := h.userService.GetVisibleUsers(currentUserID(ctx), &page)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, users)
+	respondPage(ctx, &page, users, total)
 }
 
 // @Summary		Update a user
-// @Description	Update details of a user by their UUID.
+// @Description	Update details of a user by their UUID. Changing the password signs the user out of every session.
 // @Tags			users
 // @Accept			application/json
 // @Produce		application/json
@@ -68,6 +84,7 @@ func (h *Handler) GetAllUsers(ctx *gin.Context) {
 // @Param			request	body		models.UpdateUserSwag	true	"User update payload, provide password only if it is updated"
 // @Success		200		{object}	models.UpdateUserResp			"User updated successfully"
 // @Failure		400		{object}	models.ErrResp	"Invalid input or user ID format"
+// @Failure		403		{object}	models.ErrResp	"Access denied"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
 // @Router			/users/{id} [put]
@@ -79,6 +96,10 @@ func (h *Handler) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
+	if !h.authorize(ctx, h.accessService.CheckUserAccess(currentUserID(ctx), userId)) {
+		return
+	}
+
 	user := models.UpdateUser{}
 	if err := ctx.ShouldBindJSON(&user); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid input: " + err.Error()})
@@ -92,7 +113,7 @@ func (h *Handler) UpdateUser(ctx *gin.Context) {
 		return
 	}
 
-	err = h.userService.UpdateUser(&user)
+	err = h.userService.UpdateUser(&user, currentActor(ctx))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -102,13 +123,15 @@ func (h *Handler) UpdateUser(ctx *gin.Context) {
 }
 
 // @Summary		Delete a user
-// @Description	Delete a user by their UUID.
+// @Description	Delete a user by their UUID. Users who still own farms, including deleted farms that have not been purged yet, must transfer them first.
 // @Tags			users
 // @Produce		application/json
 // @Param			id		path		string			true	"User ID (UUID)"
 // @Success		200		{object}	models.MessageResp			"User deleted successfully"
 // @Failure		400		{object}	models.ErrResp	"Invalid user ID format"
+// @Failure		403		{object}	models.ErrResp	"Access denied"
 // @Failure		404		{object}	models.ErrResp	"User not found"
+// @Failure		409		{object}	models.ErrResp	"User still owns farms"
 // @Failure		500		{object}	models.ErrResp	"Internal server error"
 // @Security		BearerAuth
 // @Router			/users/{id} [delete]
@@ -120,8 +143,15 @@ func (h *Handler) DeleteUser(ctx *gin.Context) {
 		return
 	}
 
-	err = h.userService.DeleteUser(userId)
-	if err != nil {
+	if !h.authorize(ctx, h.accessService.CheckUserAccess(currentUserID(ctx), userId)) {
+		return
+	}
+
+	err = h.userService.DeleteUser(userId, currentActor(ctx))
+	if errors.Is(err, repository.ErrUserOwnsFarms) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/internal/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// @Summary Create a new watering record
+// @Description Record that an animal was watered and update its last watered time
+// @Tags watering_records
+// @Accept application/json
+// @Produce application/json
+// @Param request body models.WateringRecordReq true "Watering Record request body"
+// @Success 201 {object} models.WateringRecordResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /watering_records [post]
+func (h *Handler) CreateWateringRecord(c *gin.Context) {
+	var record models.WateringRecordWithoutTime
+	if err := c.ShouldBindJSON(&record); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), record.AnimalID, services.PermRecordWatering)) {
+		return
+	}
+
+	if err := h.wateringRecordService.CreateWateringRecord(&record, currentActor(c)); err != nil {
+		if errors.Is(err, services.ErrAnimalNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Watering record created", "watering_record": record})
+}
+
+// @Summary Get a watering record by its ID
+// @Tags watering_records
+// @Produce application/json
+// @Param id path string true "Watering Record ID"
+// @Success 200 {object} models.WateringRecord
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /watering_records/{id} [get]
+func (h *Handler) GetWateringRecordByID(c *gin.Context) {
+	recordID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watering record ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckWateringRecordAccess(currentUserID(c), recordID, services.PermViewFarm)) {
+		return
+	}
+
+	record, err := h.wateringRecordService.GetWateringRecordByID(recordID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch watering record"})
+		return
+	}
+
+	if record == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Record not found with this ID"})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// @Summary Get all watering records for a specific animal
+// @Tags watering_records
+// @Produce application/json
+// @Param animal_id path string true "Animal ID"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Param limit query int false "Page size, 50 by default and 200 at most"
+// @Param offset query int false "Number of records to skip"
+// @Param sort query string false "watered_at, quantity or created_at, prefixed with - for descending order; newest first by default"
+// @Success 200 {object} models.Page[models.WateringRecord]
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /watering_records/animal/{animal_id} [get]
+func (h *Handler) GetWateringRecordsByAnimalID(c *gin.Context) {
+	var filter models.RecordFilter
+	var err error
+	if filter.AnimalID, err = uuid.Parse(c.Param("animal_id")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	var page models.PageQuery
+	if !bindDateRange(c, &filter.From, &filter.To) || !bindPage(c, &page, models.WateringRecordSortFields) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), filter.AnimalID, services.PermViewFarm)) {
+		return
+	}
+
+	records, total, err := h.wateringRecordService.GetWateringRecordsByAnimalID(&filter, &page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch watering records"})
+		return
+	}
+
+	respondPage(c, &page, records, total)
+}
+
+// @Summary Update a watering record by its ID
+// @Tags watering_records
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Watering Record ID"
+// @Param input body models.UpdateWateringRecordReq true "Watering Record Input"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /watering_records/{id} [put]
+func (h *Handler) UpdateWateringRecord(c *gin.Context) {
+	recordID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watering record ID"})
+		return
+	}
+
+	var req models.UpdateWateringRecordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	record := models.WateringRecordWithoutTime{ID: recordID}
+	record.UpdateWateringRecordReq = req
+
+	if !h.authorize(c, h.accessService.CheckWateringRecordAccess(currentUserID(c), recordID, services.PermRecordWatering)) {
+		return
+	}
+
+	if err := h.wateringRecordService.UpdateWateringRecord(&record, currentActor(c)); err != nil {
+		if errors.Is(err, repository.ErrWateringRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Watering record updated successfully"})
+}
+
+// @Summary Delete a watering record by its ID
+// @Description Move a watering record to the trash
+// @Tags watering_records
+// @Produce application/json
+// @Param id path string true "Watering Record ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /watering_records/{id} [delete]
+func (h *Handler) DeleteWateringRecord(c *gin.Context) {
+	recordID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watering record ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckWateringRecordAccess(currentUserID(c), recordID, services.PermDeleteRecords)) {
+		return
+	}
+
+	if err := h.wateringRecordService.DeleteWateringRecord(recordID, currentActor(c)); err != nil {
+		if errors.Is(err, repository.ErrWateringRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		} else {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "watering record deleted successfully"})
+}
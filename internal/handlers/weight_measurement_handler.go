@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/services"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// bindGrowthFilter reads the optional type, from and to query parameters. to
+// is inclusive: measurements of that whole day are included.
+func bindGrowthFilter(c *gin.Context, filter *models.GrowthFilter) bool {
+	filter.Type = c.Query("type")
+
+	if from := c.Query("from"); from != "" {
+		day, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from date, expected YYYY-MM-DD"})
+			return false
+		}
+		filter.From = day
+	}
+	if to := c.Query("to"); to != "" {
+		day, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to date, expected YYYY-MM-DD"})
+			return false
+		}
+		filter.To = day.AddDate(0, 0, 1)
+	}
+
+	return true
+}
+
+// @Summary Record a weight measurement
+// @Description Append a measurement to the weight history of an animal. The animal's weight is set to its latest measurement.
+// @Tags weights
+// @Accept application/json
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param request body models.WeightMeasurementReq true "Weight measurement"
+// @Success 201 {object} models.WeightMeasurementResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Animal not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/weights [post]
+func (h *Handler) CreateWeightMeasurement(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	var req models.WeightMeasurementReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermEditAnimals)) {
+		return
+	}
+
+	measurement, err := h.weightMeasurementService.CreateMeasurement(animalID, &req, currentActor(c))
+	if err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "weight measurement recorded successfully", "weight_measurement": measurement})
+}
+
+// @Summary Get the weight history of an animal
+// @Description Retrieve the measurements of an animal with its average daily gain over the period
+// @Tags weights
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Success 200 {object} models.AnimalGrowth
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Animal not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/weights [get]
+func (h *Handler) GetAnimalWeights(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	filter := models.GrowthFilter{AnimalID: animalID}
+	if !bindGrowthFilter(c, &filter) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermViewFarm)) {
+		return
+	}
+
+	growth, err := h.weightMeasurementService.GetAnimalGrowth(&filter)
+	if err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, growth)
+}
+
+// @Summary Delete a weight measurement
+// @Description Delete a measurement of an animal. The animal's weight falls back to its latest remaining measurement.
+// @Tags weights
+// @Produce application/json
+// @Param id path string true "Animal ID"
+// @Param measurement_id path string true "Weight Measurement ID"
+// @Success 200 {object} models.MessageResp
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /animals/{id}/weights/{measurement_id} [delete]
+func (h *Handler) DeleteWeightMeasurement(c *gin.Context) {
+	animalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid animal ID"})
+		return
+	}
+
+	measurementID, err := uuid.Parse(c.Param("measurement_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid weight measurement ID"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckAnimalAccess(currentUserID(c), animalID, services.PermEditAnimals)) {
+		return
+	}
+
+	if err := h.weightMeasurementService.DeleteMeasurement(animalID, measurementID, currentActor(c)); err != nil {
+		h.authorize(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "weight measurement deleted successfully"})
+}
+
+// @Summary Get the growth curves of a farm
+// @Description Retrieve, per animal type, the average daily gain and the average weight per period
+// @Tags weights
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param type query string false "Animal type"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Param interval query string false "Curve period: day, week (default) or month"
+// @Success 200 {array} models.TypeGrowth
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/growth [get]
+func (h *Handler) GetFarmGrowth(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	filter := models.GrowthFilter{FarmID: farmID}
+	if !bindGrowthFilter(c, &filter) {
+		return
+	}
+
+	interval := c.DefaultQuery("interval", services.IntervalWeek)
+	if interval != services.IntervalDay && interval != services.IntervalWeek && interval != services.IntervalMonth {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "interval must be one of day, week, month"})
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	growth, err := h.weightMeasurementService.GetTypeGrowth(&filter, interval)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, growth)
+}
+
+// @Summary Get animals growing below the herd average
+// @Description List the animals whose average daily gain over the period is lower than the average of the animals of the same type on the farm, furthest behind first
+// @Tags weights
+// @Produce application/json
+// @Param id path string true "Farm ID"
+// @Param type query string false "Animal type"
+// @Param from query string false "First day in YYYY-MM-DD format"
+// @Param to query string false "Last day in YYYY-MM-DD format"
+// @Success 200 {array} models.AnimalGrowth
+// @Failure 400 {object} models.ErrResp
+// @Failure 403 {object} models.ErrResp "Access denied"
+// @Failure 404 {object} models.ErrResp "Farm not found"
+// @Failure 500 {object} models.ErrResp
+// @Security BearerAuth
+// @Router /farms/{id}/growth/below_average [get]
+func (h *Handler) GetBelowAverageGrowth(c *gin.Context) {
+	farmID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid farm ID"})
+		return
+	}
+
+	filter := models.GrowthFilter{FarmID: farmID}
+	if !bindGrowthFilter(c, &filter) {
+		return
+	}
+
+	if !h.authorize(c, h.accessService.CheckFarmAccess(currentUserID(c), farmID, services.PermViewFarm)) {
+		return
+	}
+
+	animals, err := h.weightMeasurementService.GetBelowAverageGrowth(&filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, animals)
+}
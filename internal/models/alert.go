@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AlertTypeLowFoodStock     = "low_food_stock"
+	AlertTypeLowMedicineStock = "low_medicine_stock"
+	AlertTypeTreatmentDue     = "treatment_due"
+	AlertTypeBatchExpiring    = "batch_expiring"
+	AlertTypeBatchExpired     = "batch_expired"
+)
+
+type Alert struct {
+	ID        uuid.UUID `json:"id"`
+	FarmID    uuid.UUID `json:"farm_id"`
+	Type      string    `json:"type"`
+	Message   string    `json:"message"`
+	IsRead    bool      `json:"is_read"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AlertSortFields are the fields alerts can be listed by.
+var AlertSortFields = []string{"created_at", "type"}
+
+type AlertFilter struct {
+	FarmIDs    []uuid.UUID
+	Type       string
+	UnreadOnly bool
+}
+
+type MarkAlertsReq struct {
+	IDs    []uuid.UUID `json:"ids" binding:"required,min=1"`
+	IsRead bool        `json:"is_read"`
+}
+
+type MarkAlertsResp struct {
+	MessageResp
+	Updated int64 `json:"updated"`
+}
@@ -9,6 +9,7 @@ import (
 type Animal struct {
 	ID uuid.UUID `json:"id"`
 	CreateAnimalReq
+	Status      string    `json:"status"`
 	LastFed     time.Time `json:"last_fed"`
 	LastWatered time.Time `json:"last_watered"`
 	CreatedAt   time.Time `json:"created_at"`
@@ -20,6 +21,33 @@ type AnimalWithoutTime struct {
 	CreateAnimalReq
 }
 
+const (
+	AnimalActive = "active"
+	AnimalDead   = "dead"
+	AnimalSold   = "sold"
+	AnimalCulled = "culled"
+)
+
+// AnimalFilter narrows down the animals of a farm. Status, Type and
+// HealthStatus are ignored when empty, and the ages, in days, when nil.
+type AnimalFilter struct {
+	FarmID       uuid.UUID
+	Status       string
+	Type         string
+	HealthStatus string
+	MinAge       *int
+	MaxAge       *int
+}
+
+// AnimalSortFields are the fields animals can be listed by.
+var AnimalSortFields = []string{"name", "type", "weight", "health_status", "date_of_birth", "created_at"}
+
+const (
+	SexMale    = "male"
+	SexFemale  = "female"
+	SexUnknown = "unknown"
+)
+
 type CreateAnimalReq struct {
 	FarmID       uuid.UUID `json:"farm_id" binding:"required,uuid"`
 	Name         string    `json:"name"`
@@ -27,6 +55,15 @@ type CreateAnimalReq struct {
 	Weight       float64   `json:"weight" binding:"required"`
 	HealthStatus string    `json:"health_status"`
 	DateOfBirth  time.Time `json:"date_of_birth"`
+	AnimalParentage
+}
+
+// AnimalParentage is the sex of an animal and its parents on the farm. The
+// sire must be male and the dam female, both of the same type as the animal.
+type AnimalParentage struct {
+	Sex    string     `json:"sex" binding:"omitempty,oneof=male female unknown"`
+	SireID *uuid.UUID `json:"sire_id"`
+	DamID  *uuid.UUID `json:"dam_id"`
 }
 
 type CreateAnimalResp struct {
@@ -43,6 +80,7 @@ type UpdateAnimalReq struct {
 	DateOfBirth  time.Time `json:"date_of_birth"`
 	LastFed      time.Time `json:"last_fed" binding:"required"`
 	LastWatered  time.Time `json:"last_watered" binding:"required"`
+	AnimalParentage
 }
 
 type UpdateAnimalResp struct {
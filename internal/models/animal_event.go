@@ -0,0 +1,65 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AnimalEventSold        = "sold"
+	AnimalEventDied        = "died"
+	AnimalEventCulled      = "culled"
+	AnimalEventTransferred = "transferred"
+)
+
+// AnimalEventReq records an animal leaving the herd. A sale needs the buyer, a
+// death its cause and a transfer the farm the animal moves to.
+type AnimalEventReq struct {
+	EventType  string     `json:"event_type" binding:"required,oneof=sold died culled transferred"`
+	OccurredAt *time.Time `json:"occurred_at"`
+	Buyer      string     `json:"buyer" binding:"max=255"`
+	Price      *float64   `json:"price" binding:"omitempty,gte=0"`
+	Cause      string     `json:"cause" binding:"max=500"`
+	ToFarmID   *uuid.UUID `json:"to_farm_id"`
+	Notes      string     `json:"notes" binding:"max=500"`
+}
+
+// AnimalEvent is a sale, death, culling or transfer of an animal. FromFarmID
+// is the farm the animal belonged to when the event occurred.
+type AnimalEvent struct {
+	ID         uuid.UUID  `json:"id"`
+	AnimalID   uuid.UUID  `json:"animal_id"`
+	EventType  string     `json:"event_type"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	FromFarmID *uuid.UUID `json:"from_farm_id,omitempty"`
+	ToFarmID   *uuid.UUID `json:"to_farm_id,omitempty"`
+	Buyer      string     `json:"buyer,omitempty"`
+	Price      *float64   `json:"price,omitempty"`
+	Cause      string     `json:"cause,omitempty"`
+	Notes      string     `json:"notes"`
+	RecordedBy *uuid.UUID `json:"recorded_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// AnimalEventSortFields are the fields animal events can be listed by.
+var AnimalEventSortFields = []string{"occurred_at", "event_type", "created_at"}
+
+type AnimalEventResp struct {
+	MessageResp
+	AnimalEvent `json:"animal_event"`
+}
+
+// MortalityReport counts the animals of a group that were on the farm during
+// the period and how they left it. MortalityRate is the percentage of them that
+// died; it is nil when the group had no animals.
+type MortalityReport struct {
+	GroupID       string   `json:"group_id"`
+	GroupName     string   `json:"group_name"`
+	Animals       int      `json:"animals"`
+	Deaths        int      `json:"deaths"`
+	Culls         int      `json:"culls"`
+	Sales         int      `json:"sales"`
+	TransfersOut  int      `json:"transfers_out"`
+	MortalityRate *float64 `json:"mortality_rate"`
+}
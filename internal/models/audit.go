@@ -0,0 +1,94 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+)
+
+// Audited entity types. The ones that can be deleted share their name with
+// the trash item type.
+const (
+	AuditUser              = "user"
+	AuditFarm              = TrashFarm
+	AuditFarmMember        = "farm_member"
+	AuditFarmInvitation    = "farm_invitation"
+	AuditAnimal            = TrashAnimal
+	AuditAnimalEvent       = "animal_event"
+	AuditWeightMeasurement = "weight_measurement"
+	AuditBreedingEvent     = "breeding_event"
+	AuditPregnancyCheck    = "pregnancy_check"
+	AuditBirth             = "birth"
+	AuditProductionRecord  = TrashProductionRecord
+	AuditFood              = TrashFood
+	AuditMedicine          = TrashMedicine
+	AuditMedicineBatch     = "medicine_batch"
+	AuditSupplier          = "supplier"
+	AuditPurchaseOrder     = "purchase_order"
+	AuditFeedingRecord     = TrashFeedingRecord
+	AuditFeedingSchedule   = "feeding_schedule"
+	AuditFeedingTask       = "feeding_task"
+	AuditWateringRecord    = TrashWateringRecord
+	AuditMedicalRecord     = TrashMedicalRecord
+	AuditTreatmentPlan     = "treatment_plan"
+	AuditTreatmentEvent    = "treatment_event"
+	AuditAlert             = "alert"
+)
+
+// AuditChange is the value of a field before and after a change. Before is
+// null for created entities and After is null for deleted ones.
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntry records who created, changed, deleted or restored an entity and
+// which of its fields changed. FarmID is empty for entities outside a farm and
+// UserID for changes made by a background job.
+type AuditEntry struct {
+	ID         uuid.UUID              `json:"id"`
+	UserID     *uuid.UUID             `json:"user_id,omitempty"`
+	FarmID     *uuid.UUID             `json:"farm_id,omitempty"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   uuid.UUID              `json:"entity_id"`
+	Action     string                 `json:"action"`
+	Changes    map[string]AuditChange `json:"changes"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditActor is who a change is made for: the user of a request, or a
+// background job, which has no user and is named in RequestID instead.
+type AuditActor struct {
+	UserID    uuid.UUID
+	RequestID string
+}
+
+// AuditSortFields are the fields audit entries can be listed by.
+var AuditSortFields = []string{"created_at", "entity_type", "action"}
+
+// AuditFilter selects the entries of a farm timeline. Zero fields are not
+// filtered on; To is exclusive.
+type AuditFilter struct {
+	FarmID     uuid.UUID
+	EntityType string
+	UserID     uuid.UUID
+	From       time.Time
+	To         time.Time
+}
+
+// AuditSnapshot is the stored state of an entity at one point in time, taken
+// before and after a change to work out what changed.
+type AuditSnapshot struct {
+	EntityType string
+	EntityID   uuid.UUID
+	FarmID     *uuid.UUID
+	Data       map[string]interface{}
+}
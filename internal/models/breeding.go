@@ -0,0 +1,146 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	BreedingNatural                = "natural"
+	BreedingArtificialInsemination = "artificial_insemination"
+)
+
+const (
+	BreedingBred      = "bred"
+	BreedingPregnant  = "pregnant"
+	BreedingOpen      = "open"
+	BreedingDelivered = "delivered"
+)
+
+const (
+	PregnancyPositive = "pregnant"
+	PregnancyNegative = "not_pregnant"
+)
+
+// GestationDays is the average gestation or incubation length per animal
+// type, used to compute expected due dates.
+var GestationDays = map[string]int{
+	"cattle":  283,
+	"cow":     283,
+	"buffalo": 310,
+	"sheep":   147,
+	"goat":    150,
+	"pig":     114,
+	"horse":   340,
+	"donkey":  365,
+	"camel":   390,
+	"rabbit":  31,
+	"chicken": 21,
+	"duck":    28,
+	"turkey":  28,
+	"goose":   30,
+}
+
+// BreedingEventReq records a mating or insemination of a dam. The sire is
+// either an animal of the farm or described, e.g. by the semen straw used.
+// Without an expected due date, it is derived from the type's gestation.
+type BreedingEventReq struct {
+	DamID           uuid.UUID  `json:"dam_id" binding:"required"`
+	SireID          *uuid.UUID `json:"sire_id"`
+	SireDescription string     `json:"sire_description" binding:"max=255"`
+	Method          string     `json:"method" binding:"required,oneof=natural artificial_insemination"`
+	BredAt          time.Time  `json:"bred_at" binding:"required"`
+	ExpectedDueDate *time.Time `json:"expected_due_date"`
+	Notes           string     `json:"notes" binding:"max=500"`
+}
+
+type BreedingEvent struct {
+	ID              uuid.UUID        `json:"id"`
+	FarmID          uuid.UUID        `json:"farm_id"`
+	DamID           uuid.UUID        `json:"dam_id"`
+	DamName         string           `json:"dam_name"`
+	SireID          *uuid.UUID       `json:"sire_id,omitempty"`
+	SireName        string           `json:"sire_name,omitempty"`
+	SireDescription string           `json:"sire_description,omitempty"`
+	Method          string           `json:"method"`
+	BredAt          time.Time        `json:"bred_at"`
+	ExpectedDueDate time.Time        `json:"expected_due_date"`
+	Status          string           `json:"status"`
+	Notes           string           `json:"notes"`
+	CreatedBy       *uuid.UUID       `json:"created_by,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	PregnancyChecks []PregnancyCheck `json:"pregnancy_checks,omitempty"`
+	Birth           *Birth           `json:"birth,omitempty"`
+}
+
+type BreedingEventResp struct {
+	MessageResp
+	BreedingEvent `json:"breeding_event"`
+}
+
+// PregnancyCheckReq records the result of a pregnancy check. The latest
+// check decides whether the dam is pregnant.
+type PregnancyCheckReq struct {
+	CheckedAt time.Time `json:"checked_at" binding:"required"`
+	Result    string    `json:"result" binding:"required,oneof=pregnant not_pregnant"`
+	Notes     string    `json:"notes" binding:"max=500"`
+}
+
+type PregnancyCheck struct {
+	ID              uuid.UUID `json:"id"`
+	BreedingEventID uuid.UUID `json:"breeding_event_id"`
+	PregnancyCheckReq
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type PregnancyCheckResp struct {
+	MessageResp
+	PregnancyCheck `json:"pregnancy_check"`
+}
+
+type OffspringReq struct {
+	Name   string  `json:"name"`
+	Sex    string  `json:"sex" binding:"omitempty,oneof=male female unknown"`
+	Weight float64 `json:"weight" binding:"required,gt=0"`
+}
+
+// BirthReq records the outcome of a pregnancy. Every live offspring is added
+// to the farm as an animal linked to its sire and dam.
+type BirthReq struct {
+	BornAt         time.Time      `json:"born_at" binding:"required"`
+	StillbornCount int            `json:"stillborn_count" binding:"min=0"`
+	Notes          string         `json:"notes" binding:"max=500"`
+	Offspring      []OffspringReq `json:"offspring" binding:"dive"`
+}
+
+type Birth struct {
+	ID              uuid.UUID           `json:"id"`
+	BreedingEventID uuid.UUID           `json:"breeding_event_id"`
+	BornAt          time.Time           `json:"born_at"`
+	LiveCount       int                 `json:"live_count"`
+	StillbornCount  int                 `json:"stillborn_count"`
+	Notes           string              `json:"notes"`
+	CreatedAt       time.Time           `json:"created_at"`
+	Offspring       []AnimalWithoutTime `json:"offspring"`
+}
+
+type BirthResp struct {
+	MessageResp
+	Birth `json:"birth"`
+}
+
+// UpcomingBirth is a breeding event still awaiting its birth.
+type UpcomingBirth struct {
+	BreedingEvent
+	DaysLeft int `json:"days_left"`
+}
+
+// ReproductiveHistory is the breeding record of a female.
+type ReproductiveHistory struct {
+	AnimalID       uuid.UUID       `json:"animal_id"`
+	BreedingEvents []BreedingEvent `json:"breeding_events"`
+	Births         int             `json:"births"`
+	LiveOffspring  int             `json:"live_offspring"`
+	Stillborn      int             `json:"stillborn"`
+}
@@ -12,6 +12,9 @@ type Farm struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// FarmSortFields are the fields farms can be listed by.
+var FarmSortFields = []string{"name", "location", "created_at"}
+
 type CreateFarmRequest struct {
 	Name     string    `json:"name" binding:"required"`
 	Location string    `json:"location" binding:"required"`
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RoleOwner        = "owner"
+	RoleManager      = "manager"
+	RoleWorker       = "worker"
+	RoleVeterinarian = "veterinarian"
+)
+
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+)
+
+type FarmMember struct {
+	FarmID    uuid.UUID `json:"farm_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FarmMemberSortFields are the fields farm members can be listed by.
+var FarmMemberSortFields = []string{"name", "email", "role", "created_at"}
+
+type InviteMemberReq struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=manager worker veterinarian"`
+}
+
+type UpdateMemberRoleReq struct {
+	Role string `json:"role" binding:"required,oneof=manager worker veterinarian"`
+}
+
+type UpdateMemberRoleResp struct {
+	MessageResp
+	FarmMember `json:"member"`
+}
+
+type FarmInvitation struct {
+	ID          uuid.UUID  `json:"id"`
+	FarmID      uuid.UUID  `json:"farm_id"`
+	FarmName    string     `json:"farm_name,omitempty"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role"`
+	InvitedBy   uuid.UUID  `json:"invited_by"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+}
+
+// FarmInvitationSortFields are the fields invitations can be listed by.
+var FarmInvitationSortFields = []string{"email", "role", "status", "created_at"}
+
+type FarmInvitationResp struct {
+	MessageResp
+	FarmInvitation `json:"invitation"`
+}
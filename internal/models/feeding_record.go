@@ -20,6 +20,7 @@ type FeedingRecordResp struct {
 type FeedingRecordWithoutTime struct {
 	ID uuid.UUID `json:"id"`
 	FeedingRecordReq
+	RecordedBy *uuid.UUID `json:"recorded_by,omitempty"`
 }
 
 type UpdateFeedRecordReq struct {
@@ -28,11 +29,15 @@ type UpdateFeedRecordReq struct {
 	Notes    string    `json:"notes" binding:"max=500"`
 }
 
+// FeedingRecordSortFields are the fields feeding records can be listed by.
+var FeedingRecordSortFields = []string{"fed_at", "quantity", "created_at"}
+
 type FeedingRecordDetailed struct {
 	FeedingRecordID uuid.UUID    `json:"feeding_record_id"`
 	Quantity        float64      `json:"quantity"`
 	FedAt           time.Time    `json:"fed_at"`
 	Notes           string       `json:"notes,omitempty"`
+	RecordedBy      *uuid.UUID   `json:"recorded_by,omitempty"`
 	CreatedAt       time.Time    `json:"created_at"`
 	Animal          AnimalDetail `json:"animal"`
 	Food            FoodDetail   `json:"food"`
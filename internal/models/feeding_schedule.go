@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TaskPending   = "pending"
+	TaskCompleted = "completed"
+	TaskSkipped   = "skipped"
+)
+
+// ScheduleDetails describes what is fed and when. TimesOfDay holds "HH:MM"
+// values; DaysOfWeek holds 0 (Sunday) to 6 (Saturday) and means every day
+// when empty.
+type ScheduleDetails struct {
+	FoodID     uuid.UUID `json:"food_id" binding:"required"`
+	Quantity   float64   `json:"quantity" binding:"required,gt=0"`
+	TimesOfDay []string  `json:"times_of_day" binding:"required,min=1"`
+	DaysOfWeek []int64   `json:"days_of_week" binding:"dive,min=0,max=6"`
+}
+
+// FeedingScheduleReq targets either a single animal or every animal of a type
+// on the farm.
+type FeedingScheduleReq struct {
+	FarmID     uuid.UUID  `json:"farm_id" binding:"required"`
+	AnimalID   *uuid.UUID `json:"animal_id"`
+	AnimalType string     `json:"animal_type"`
+	ScheduleDetails
+}
+
+type UpdateFeedingScheduleReq struct {
+	ScheduleDetails
+	Active bool `json:"active"`
+}
+
+type FeedingSchedule struct {
+	ID uuid.UUID `json:"id"`
+	FeedingScheduleReq
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FeedingScheduleSortFields are the fields feeding schedules can be listed by.
+var FeedingScheduleSortFields = []string{"created_at", "quantity"}
+
+type FeedingScheduleResp struct {
+	MessageResp
+	FeedingSchedule `json:"feeding_schedule"`
+}
+
+type FeedingTask struct {
+	ID              uuid.UUID  `json:"id"`
+	ScheduleID      uuid.UUID  `json:"schedule_id"`
+	AnimalID        uuid.UUID  `json:"animal_id"`
+	AnimalName      string     `json:"animal_name"`
+	FoodID          uuid.UUID  `json:"food_id"`
+	FoodName        string     `json:"food_name"`
+	Quantity        float64    `json:"quantity"`
+	DueAt           time.Time  `json:"due_at"`
+	Status          string     `json:"status"`
+	FeedingRecordID *uuid.UUID `json:"feeding_record_id,omitempty"`
+	CompletedBy     *uuid.UUID `json:"completed_by,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// FeedingTaskSortFields are the fields feeding tasks can be listed by.
+var FeedingTaskSortFields = []string{"due_at", "status", "quantity", "created_at"}
+
+type FeedingTaskFilter struct {
+	FarmID uuid.UUID
+	Date   time.Time
+	Status string
+}
+
+// CompleteFeedingTaskReq may override the planned quantity and feeding time.
+type CompleteFeedingTaskReq struct {
+	Quantity float64    `json:"quantity" binding:"omitempty,gt=0"`
+	FedAt    *time.Time `json:"fed_at"`
+	Notes    string     `json:"notes" binding:"max=500"`
+}
+
+type CompleteFeedingTaskResp struct {
+	MessageResp
+	FeedingRecordID uuid.UUID `json:"feeding_record_id"`
+}
@@ -9,6 +9,8 @@ import (
 type MedicalRecordWithoutTime struct {
 	ID uuid.UUID `json:"id"`
 	MedicalRecordReq
+	Batches    []BatchUsage `json:"batches,omitempty"`
+	RecordedBy *uuid.UUID   `json:"recorded_by,omitempty"`
 }
 
 type MedicalRecordReq struct {
@@ -28,6 +30,9 @@ type UpdateMedicalRecordReq struct {
 	Notes         string    `json:"notes" binding:"max=500"`
 }
 
+// MedicalRecordSortFields are the fields medical records can be listed by.
+var MedicalRecordSortFields = []string{"treatment_date", "quantity", "created_at"}
+
 type MedicalRecordDetailed struct {
 	ID            string         `json:"id"`
 	Animal        AnimalDetail   `json:"animal"`
@@ -35,7 +40,35 @@ type MedicalRecordDetailed struct {
 	Quantity      float64        `json:"quantity"`
 	TreatmentDate time.Time      `json:"treatment_date"`
 	Notes         string         `json:"notes"`
+	RecordedBy    *uuid.UUID     `json:"recorded_by,omitempty"`
 	CreatedAt     time.Time      `json:"created_at"`
+	Batches       []BatchUsage   `json:"batches"`
+	WithdrawalDates
+}
+
+// WithdrawalDates holds the day a treatment's withdrawal period ends for each
+// product. The product may be used again from that day on; nil means the
+// medicine has no withdrawal for it.
+type WithdrawalDates struct {
+	MeatWithdrawalUntil *time.Time `json:"meat_withdrawal_until,omitempty"`
+	MilkWithdrawalUntil *time.Time `json:"milk_withdrawal_until,omitempty"`
+	EggWithdrawalUntil  *time.Time `json:"egg_withdrawal_until,omitempty"`
+}
+
+const (
+	ProductMeat = "meat"
+	ProductMilk = "milk"
+	ProductEggs = "eggs"
+	ProductWool = "wool"
+)
+
+// AnimalWithdrawal is an animal whose products are held back because of a
+// recent treatment.
+type AnimalWithdrawal struct {
+	AnimalID   uuid.UUID `json:"animal_id"`
+	AnimalName string    `json:"animal_name"`
+	AnimalType string    `json:"animal_type"`
+	WithdrawalDates
 }
 
 type MedicineDetail struct {
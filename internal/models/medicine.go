@@ -20,6 +20,15 @@ type MedicineReq struct {
 	UnitOfMeasure string    `json:"unit_of_measure" binding:"required"`
 	Quantity      float64   `json:"quantity" binding:"required,gt=0"`
 	MinThreshold  float64   `json:"min_threshold" binding:"required,gt=0"`
+	WithdrawalPeriods
+}
+
+// WithdrawalPeriods is how many days after a treatment the animal's meat,
+// milk or eggs may not be used. Zero means no withdrawal.
+type WithdrawalPeriods struct {
+	MeatWithdrawalDays int `json:"meat_withdrawal_days" binding:"min=0"`
+	MilkWithdrawalDays int `json:"milk_withdrawal_days" binding:"min=0"`
+	EggWithdrawalDays  int `json:"egg_withdrawal_days" binding:"min=0"`
 }
 
 type MedicineResp struct {
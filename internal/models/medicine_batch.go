@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MedicineBatchReq stocks a lot of a medicine. The quantity is added to the
+// medicine as a purchase.
+type MedicineBatchReq struct {
+	LotNumber  string    `json:"lot_number" binding:"required,max=100"`
+	ExpiryDate time.Time `json:"expiry_date" binding:"required"`
+	Quantity   float64   `json:"quantity" binding:"required,gt=0"`
+	UnitCost   *float64  `json:"unit_cost" binding:"omitempty,gte=0"`
+}
+
+// MedicineBatch is a lot of a medicine. Quantity is what is left of it.
+type MedicineBatch struct {
+	ID              uuid.UUID `json:"id"`
+	MedicineID      uuid.UUID `json:"medicine_id"`
+	LotNumber       string    `json:"lot_number"`
+	ExpiryDate      time.Time `json:"expiry_date"`
+	InitialQuantity float64   `json:"initial_quantity"`
+	Quantity        float64   `json:"quantity"`
+	Expired         bool      `json:"expired"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// MedicineBatchSortFields are the fields medicine batches can be listed by.
+var MedicineBatchSortFields = []string{"expiry_date", "lot_number", "quantity", "created_at"}
+
+type MedicineBatchResp struct {
+	MessageResp
+	MedicineBatch `json:"batch"`
+}
+
+// ExpiringBatch is a batch with stock left that expires soon or has expired.
+type ExpiringBatch struct {
+	MedicineBatch
+	FarmID        uuid.UUID `json:"farm_id"`
+	MedicineName  string    `json:"medicine_name"`
+	UnitOfMeasure string    `json:"unit_of_measure"`
+	DaysLeft      int       `json:"days_left"`
+}
+
+// BatchUsage is the quantity of a batch a medical record consumed.
+type BatchUsage struct {
+	BatchID    uuid.UUID `json:"batch_id"`
+	LotNumber  string    `json:"lot_number"`
+	ExpiryDate time.Time `json:"expiry_date"`
+	Quantity   float64   `json:"quantity"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PageQuery is the slice of a list asked for: at most Limit items after
+// skipping Offset, sorted by Sort, which is empty for the default order of the
+// list.
+type PageQuery struct {
+	Limit  int
+	Offset int
+	Sort   string
+	Desc   bool
+}
+
+// Page is the envelope of every list response. Total counts the items on all
+// pages and Next links to the following page, when there is one.
+type Page[T any] struct {
+	Items  []T    `json:"items"`
+	Total  int    `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Next   string `json:"next,omitempty"`
+}
+
+// RecordFilter narrows down feeding, watering or medical records to those of
+// an animal or of the animals on a farm, taken from From up to, but excluding,
+// To. ItemID is the food of feeding records or the medicine of medical records.
+// Zero values are ignored.
+type RecordFilter struct {
+	AnimalID   uuid.UUID
+	FarmID     uuid.UUID
+	AnimalType string
+	ItemID     uuid.UUID
+	RecordedBy uuid.UUID
+	From       time.Time
+	To         time.Time
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PedigreeAnimal is an animal as it appears in a pedigree.
+type PedigreeAnimal struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	Sex         string     `json:"sex"`
+	DateOfBirth *time.Time `json:"date_of_birth,omitempty"`
+	SireID      *uuid.UUID `json:"sire_id,omitempty"`
+	DamID       *uuid.UUID `json:"dam_id,omitempty"`
+}
+
+// PedigreeNode is an animal with its known parents, recursively.
+type PedigreeNode struct {
+	PedigreeAnimal
+	Sire *PedigreeNode `json:"sire,omitempty"`
+	Dam  *PedigreeNode `json:"dam,omitempty"`
+}
+
+// Pedigree is the ancestry tree of an animal. The inbreeding coefficient only
+// accounts for the generations included.
+type Pedigree struct {
+	Generations           int          `json:"generations"`
+	InbreedingCoefficient float64      `json:"inbreeding_coefficient"`
+	Tree                  PedigreeNode `json:"tree"`
+}
+
+// Descendant is an offspring of an animal; generation 1 are its children.
+type Descendant struct {
+	PedigreeAnimal
+	Generation int `json:"generation"`
+}
+
+// InbreedingResult is the inbreeding coefficient the offspring of a mating
+// would have, with the ancestors the sire and dam share.
+type InbreedingResult struct {
+	SireID          uuid.UUID        `json:"sire_id"`
+	DamID           uuid.UUID        `json:"dam_id"`
+	Generations     int              `json:"generations"`
+	Coefficient     float64          `json:"coefficient"`
+	CommonAncestors []PedigreeAnimal `json:"common_ancestors"`
+}
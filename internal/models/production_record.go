@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductionRecordReq records the output of a single animal, or of a group of
+// animals of a type on a farm when AnimalID is not given.
+type ProductionRecordReq struct {
+	AnimalID   *uuid.UUID `json:"animal_id"`
+	FarmID     *uuid.UUID `json:"farm_id"`
+	AnimalType string     `json:"animal_type" binding:"max=50"`
+	Product    string     `json:"product" binding:"required,oneof=milk eggs wool"`
+	Quantity   float64    `json:"quantity" binding:"required,gt=0"`
+	Unit       string     `json:"unit" binding:"required,max=20"`
+	ProducedAt *time.Time `json:"produced_at"`
+	Notes      string     `json:"notes" binding:"max=500"`
+}
+
+type ProductionRecord struct {
+	ID         uuid.UUID  `json:"id"`
+	FarmID     uuid.UUID  `json:"farm_id"`
+	AnimalID   *uuid.UUID `json:"animal_id,omitempty"`
+	AnimalName string     `json:"animal_name,omitempty"`
+	AnimalType string     `json:"animal_type"`
+	Product    string     `json:"product"`
+	Quantity   float64    `json:"quantity"`
+	Unit       string     `json:"unit"`
+	ProducedAt time.Time  `json:"produced_at"`
+	Notes      string     `json:"notes"`
+	RecordedBy *uuid.UUID `json:"recorded_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ProductionRecordSortFields are the fields production records can be listed by.
+var ProductionRecordSortFields = []string{"produced_at", "product", "quantity", "created_at"}
+
+type ProductionRecordResp struct {
+	MessageResp
+	ProductionRecord `json:"production_record"`
+}
+
+// ProductionYield is the quantity of a product a group produced in a period.
+// Quantities are only summed within the same unit. For animal types, Animals
+// is the number of active animals the type currently has on the farm and
+// PerHead the quantity divided among them.
+type ProductionYield struct {
+	PeriodStart time.Time `json:"period_start"`
+	GroupID     string    `json:"group_id"`
+	GroupName   string    `json:"group_name"`
+	Product     string    `json:"product"`
+	Unit        string    `json:"unit"`
+	Quantity    float64   `json:"quantity"`
+	Records     int       `json:"records"`
+	Animals     int       `json:"animals,omitempty"`
+	PerHead     *float64  `json:"per_head,omitempty"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderOrdered           = "ordered"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// PurchaseOrderItemReq is a line of an order. Exactly one of FoodID and
+// MedicineID must be set.
+type PurchaseOrderItemReq struct {
+	FoodID     *uuid.UUID `json:"food_id"`
+	MedicineID *uuid.UUID `json:"medicine_id"`
+	Quantity   float64    `json:"quantity" binding:"required,gt=0"`
+	UnitCost   float64    `json:"unit_cost" binding:"gte=0"`
+}
+
+type PurchaseOrderDetails struct {
+	SupplierID       uuid.UUID              `json:"supplier_id" binding:"required"`
+	ExpectedDelivery *time.Time             `json:"expected_delivery"`
+	Notes            string                 `json:"notes" binding:"max=500"`
+	Items            []PurchaseOrderItemReq `json:"items" binding:"required,min=1,dive"`
+}
+
+type PurchaseOrderReq struct {
+	FarmID uuid.UUID `json:"farm_id" binding:"required"`
+	PurchaseOrderDetails
+}
+
+type PurchaseOrderItem struct {
+	ID               uuid.UUID  `json:"id"`
+	ItemType         string     `json:"item_type"`
+	FoodID           *uuid.UUID `json:"food_id,omitempty"`
+	MedicineID       *uuid.UUID `json:"medicine_id,omitempty"`
+	Name             string     `json:"name"`
+	UnitOfMeasure    string     `json:"unit_of_measure"`
+	Quantity         float64    `json:"quantity"`
+	UnitCost         float64    `json:"unit_cost"`
+	ReceivedQuantity float64    `json:"received_quantity"`
+}
+
+type PurchaseOrder struct {
+	ID               uuid.UUID           `json:"id"`
+	FarmID           uuid.UUID           `json:"farm_id"`
+	SupplierID       uuid.UUID           `json:"supplier_id"`
+	SupplierName     string              `json:"supplier_name"`
+	Status           string              `json:"status"`
+	ExpectedDelivery *time.Time          `json:"expected_delivery,omitempty"`
+	Notes            string              `json:"notes"`
+	TotalCost        float64             `json:"total_cost"`
+	CreatedBy        *uuid.UUID          `json:"created_by,omitempty"`
+	OrderedAt        *time.Time          `json:"ordered_at,omitempty"`
+	ReceivedAt       *time.Time          `json:"received_at,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+	Items            []PurchaseOrderItem `json:"items,omitempty"`
+}
+
+type PurchaseOrderResp struct {
+	MessageResp
+	PurchaseOrder `json:"purchase_order"`
+}
+
+// PurchaseOrderSortFields are the fields purchase orders can be listed by.
+var PurchaseOrderSortFields = []string{"created_at", "status", "expected_delivery", "ordered_at", "received_at"}
+
+type PurchaseOrderFilter struct {
+	FarmID     uuid.UUID
+	SupplierID uuid.UUID
+	Status     string
+}
+
+// ReceiveOrderItemReq is a delivered quantity of an order line. For medicine
+// lines, giving an expiry date stocks the delivery as a batch.
+type ReceiveOrderItemReq struct {
+	ItemID     uuid.UUID  `json:"item_id" binding:"required"`
+	Quantity   float64    `json:"quantity" binding:"required,gt=0"`
+	LotNumber  string     `json:"lot_number" binding:"required_with=ExpiryDate,max=100"`
+	ExpiryDate *time.Time `json:"expiry_date"`
+}
+
+// ReceivePurchaseOrderReq lists the quantities delivered per line. When Items
+// is empty, everything still outstanding is received.
+type ReceivePurchaseOrderReq struct {
+	Items []ReceiveOrderItemReq `json:"items" binding:"omitempty,dive"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ReportGroupFarm   = "farm"
+	ReportGroupAnimal = "animal"
+	ReportGroupType   = "type"
+	ReportGroupFood   = "food"
+)
+
+// ReportFilter selects the records of a farm between From (inclusive) and To
+// (exclusive), optionally narrowed to an animal, an animal type, a food or a
+// product.
+type ReportFilter struct {
+	FarmID   uuid.UUID
+	AnimalID uuid.UUID
+	Type     string
+	FoodID   uuid.UUID
+	Product  string
+	From     time.Time
+	To       time.Time
+	Interval string
+	GroupBy  string
+}
+
+// FeedingReportRow is the feed consumed by a group in a period. Quantities
+// are only summed within the same unit of measure.
+type FeedingReportRow struct {
+	PeriodStart   time.Time `json:"period_start"`
+	GroupID       string    `json:"group_id"`
+	GroupName     string    `json:"group_name"`
+	UnitOfMeasure string    `json:"unit_of_measure"`
+	Quantity      float64   `json:"quantity"`
+	Feedings      int       `json:"feedings"`
+}
+
+// AnimalFeedGain is the feed an animal consumed and the weight it gained in a
+// period. Gain is only known when the animal was weighed at least twice.
+type AnimalFeedGain struct {
+	AnimalID     uuid.UUID
+	AnimalName   string
+	AnimalType   string
+	FeedConsumed float64
+	FirstWeight  float64
+	LastWeight   float64
+	Measurements int
+}
+
+// FeedConversion relates the feed consumed by a group to the weight it
+// gained. The ratio only covers animals weighed at least twice in the period
+// and is nil when they did not gain weight.
+type FeedConversion struct {
+	GroupID             string   `json:"group_id"`
+	GroupName           string   `json:"group_name"`
+	Animals             int      `json:"animals"`
+	FeedConsumed        float64  `json:"feed_consumed"`
+	WeightGained        float64  `json:"weight_gained"`
+	FeedConversionRatio *float64 `json:"feed_conversion_ratio"`
+}
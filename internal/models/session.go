@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Session struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type RefreshToken struct {
+	ID             uuid.UUID
+	SessionID      uuid.UUID
+	UserID         uuid.UUID
+	TokenHash      string
+	ExpiresAt      time.Time
+	UsedAt         *time.Time
+	SessionRevoked bool
+}
+
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StockItemFood     = "food"
+	StockItemMedicine = "medicine"
+)
+
+const (
+	MovementPurchase    = "purchase"
+	MovementConsumption = "consumption"
+	MovementAdjustment  = "adjustment"
+	MovementWaste       = "waste"
+	MovementTransfer    = "transfer"
+)
+
+// StockFilter narrows down the foods or medicines of a farm to those suitable
+// for an animal type, when given, and to those below their minimum threshold.
+type StockFilter struct {
+	FarmID         uuid.UUID
+	SuitableFor    string
+	BelowThreshold bool
+}
+
+// StockSortFields are the fields foods and medicines can be listed by.
+var StockSortFields = []string{"name", "quantity", "created_at", "updated_at"}
+
+// MovementSortFields are the fields stock movements can be listed by.
+var MovementSortFields = []string{"created_at", "movement_type", "quantity"}
+
+// StockMovement is a single entry of the inventory ledger. Quantity is signed:
+// positive values add stock, negative values remove it.
+type StockMovement struct {
+	ID           uuid.UUID  `json:"id"`
+	FarmID       uuid.UUID  `json:"farm_id"`
+	ItemType     string     `json:"item_type"`
+	ItemID       uuid.UUID  `json:"item_id"`
+	MovementType string     `json:"movement_type"`
+	Quantity     float64    `json:"quantity"`
+	BalanceAfter float64    `json:"balance_after"`
+	UnitCost     *float64   `json:"unit_cost,omitempty"`
+	ReferenceID  *uuid.UUID `json:"reference_id,omitempty"`
+	Notes        string     `json:"notes"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// StockMovementReq records a manual movement. Purchases always add and waste
+// always removes stock; adjustments and transfers use the sign of Quantity.
+type StockMovementReq struct {
+	MovementType string   `json:"movement_type" binding:"required,oneof=purchase adjustment waste transfer"`
+	Quantity     float64  `json:"quantity" binding:"required"`
+	UnitCost     *float64 `json:"unit_cost" binding:"omitempty,gte=0"`
+	Notes        string   `json:"notes"`
+}
+
+type StockMovementResp struct {
+	MessageResp
+	StockMovement `json:"movement"`
+}
+
+// StockReconciliation compares the stored quantity of an item with the sum of
+// its ledger entries.
+type StockReconciliation struct {
+	ItemType    string    `json:"item_type"`
+	ItemID      uuid.UUID `json:"item_id"`
+	Name        string    `json:"name"`
+	Quantity    float64   `json:"quantity"`
+	LedgerTotal float64   `json:"ledger_total"`
+	Difference  float64   `json:"difference"`
+	Consistent  bool      `json:"consistent"`
+}
+
+// StockUsage is a stock item with its consumption over a recent window and
+// the consumption planned by feeding schedules or treatment plans, both per
+// day.
+type StockUsage struct {
+	ItemType           string    `json:"item_type"`
+	ItemID             uuid.UUID `json:"item_id"`
+	Name               string    `json:"name"`
+	UnitOfMeasure      string    `json:"unit_of_measure"`
+	Quantity           float64   `json:"quantity"`
+	MinThreshold       float64   `json:"min_threshold"`
+	HistoricalDailyUse float64   `json:"historical_daily_use"`
+	PlannedDailyUse    float64   `json:"planned_daily_use"`
+}
+
+// StockForecast estimates when a stock item runs low. The estimated daily
+// use is the larger of the historical and the planned use; the day counts are
+// nil when nothing is used.
+type StockForecast struct {
+	StockUsage
+	EstimatedDailyUse  float64  `json:"estimated_daily_use"`
+	DaysUntilThreshold *float64 `json:"days_until_threshold"`
+	DaysUntilEmpty     *float64 `json:"days_until_empty"`
+}
+
+// ReorderSuggestion is an item expected to fall below its threshold within
+// the lead time, with the quantity needed to cover the following days.
+type ReorderSuggestion struct {
+	StockForecast
+	SuggestedQuantity float64 `json:"suggested_quantity"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SupplierDetails struct {
+	Name        string `json:"name" binding:"required"`
+	ContactName string `json:"contact_name"`
+	PhoneNumber string `json:"phone_number" binding:"max=20"`
+	Email       string `json:"email" binding:"omitempty,email"`
+	Address     string `json:"address"`
+	Notes       string `json:"notes" binding:"max=500"`
+}
+
+type SupplierReq struct {
+	FarmID uuid.UUID `json:"farm_id" binding:"required"`
+	SupplierDetails
+}
+
+type Supplier struct {
+	ID uuid.UUID `json:"id"`
+	SupplierReq
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SupplierSortFields are the fields suppliers can be listed by.
+var SupplierSortFields = []string{"name", "created_at"}
+
+type SupplierResp struct {
+	MessageResp
+	Supplier `json:"supplier"`
+}
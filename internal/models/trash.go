@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TrashFarm             = "farm"
+	TrashAnimal           = "animal"
+	TrashFood             = "food"
+	TrashMedicine         = "medicine"
+	TrashFeedingRecord    = "feeding_record"
+	TrashWateringRecord   = "watering_record"
+	TrashMedicalRecord    = "medical_record"
+	TrashProductionRecord = "production_record"
+)
+
+// TrashItem is a deleted item that can still be restored. It is purged for
+// good at PurgeAt. Items deleted together with their farm or animal are not
+// listed on their own; they come back when the farm or animal is restored.
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        uuid.UUID `json:"id"`
+	FarmID    uuid.UUID `json:"farm_id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+// TrashSortFields are the fields the trash can be listed by.
+var TrashSortFields = []string{"deleted_at", "type", "name"}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	TreatmentEventPending   = "pending"
+	TreatmentEventCompleted = "completed"
+	TreatmentEventSkipped   = "skipped"
+)
+
+// TreatmentPlanDetails describes a protocol of DoseCount doses given
+// DoseIntervalDays apart, followed by a booster every BoosterIntervalDays
+// (no boosters when 0). The first dose is due at FirstDoseAgeDays of age, or
+// on enrollment when the age or the date of birth is unknown.
+type TreatmentPlanDetails struct {
+	Name                string  `json:"name" binding:"required"`
+	Quantity            float64 `json:"quantity" binding:"required,gt=0"`
+	FirstDoseAgeDays    *int    `json:"first_dose_age_days" binding:"omitempty,min=0"`
+	DoseCount           int     `json:"dose_count" binding:"required,min=1"`
+	DoseIntervalDays    int     `json:"dose_interval_days" binding:"min=0"`
+	BoosterIntervalDays int     `json:"booster_interval_days" binding:"min=0"`
+	Notes               string  `json:"notes" binding:"max=500"`
+}
+
+type TreatmentPlanReq struct {
+	FarmID     uuid.UUID `json:"farm_id" binding:"required"`
+	AnimalType string    `json:"animal_type" binding:"required"`
+	MedicineID uuid.UUID `json:"medicine_id" binding:"required"`
+	TreatmentPlanDetails
+}
+
+type UpdateTreatmentPlanReq struct {
+	TreatmentPlanDetails
+	Active bool `json:"active"`
+}
+
+type TreatmentPlan struct {
+	ID uuid.UUID `json:"id"`
+	TreatmentPlanReq
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TreatmentPlanSortFields are the fields treatment plans can be listed by.
+var TreatmentPlanSortFields = []string{"name", "animal_type", "created_at"}
+
+type TreatmentPlanResp struct {
+	MessageResp
+	TreatmentPlan `json:"treatment_plan"`
+}
+
+type TreatmentEvent struct {
+	ID              uuid.UUID  `json:"id"`
+	PlanID          uuid.UUID  `json:"plan_id"`
+	PlanName        string     `json:"plan_name"`
+	FarmID          uuid.UUID  `json:"farm_id"`
+	AnimalID        uuid.UUID  `json:"animal_id"`
+	AnimalName      string     `json:"animal_name"`
+	MedicineID      uuid.UUID  `json:"medicine_id"`
+	MedicineName    string     `json:"medicine_name"`
+	DoseNumber      int        `json:"dose_number"`
+	Quantity        float64    `json:"quantity"`
+	DueDate         time.Time  `json:"due_date"`
+	Status          string     `json:"status"`
+	Overdue         bool       `json:"overdue"`
+	MedicalRecordID *uuid.UUID `json:"medical_record_id,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// TreatmentEventSortFields are the fields treatment events can be listed by.
+var TreatmentEventSortFields = []string{"due_date", "dose_number", "created_at"}
+
+// TreatmentEventFilter selects pending events of an animal or a farm that are
+// due up to Until (no limit when zero).
+type TreatmentEventFilter struct {
+	AnimalID    uuid.UUID
+	FarmID      uuid.UUID
+	Until       time.Time
+	OverdueOnly bool
+}
+
+// TreatmentEnrollment is an animal covered by an active plan together with
+// the last event generated for it.
+type TreatmentEnrollment struct {
+	PlanID              uuid.UUID
+	FarmID              uuid.UUID
+	FirstDoseAgeDays    *int
+	DoseCount           int
+	DoseIntervalDays    int
+	BoosterIntervalDays int
+	PlanCreatedAt       time.Time
+	AnimalID            uuid.UUID
+	DateOfBirth         *time.Time
+	AnimalCreatedAt     time.Time
+	LastDoseNumber      int
+	LastStatus          string
+	LastDoseDate        *time.Time
+}
+
+// CompleteTreatmentEventReq may override the planned quantity and date.
+type CompleteTreatmentEventReq struct {
+	Quantity      float64    `json:"quantity" binding:"omitempty,gt=0"`
+	TreatmentDate *time.Time `json:"treatment_date"`
+	Notes         string     `json:"notes" binding:"max=500"`
+}
+
+type CompleteTreatmentEventResp struct {
+	MessageResp
+	MedicalRecordID uuid.UUID `json:"medical_record_id"`
+}
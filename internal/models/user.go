@@ -12,6 +12,9 @@ type User struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// UserSortFields are the fields users can be listed by.
+var UserSortFields = []string{"name", "email", "created_at"}
+
 type SignUpRequest struct {
 	Name        string `json:"name" binding:"required"`
 	PhoneNumber string `json:"phone_number" binding:"required,min=9"`
@@ -29,8 +32,10 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	ID    uuid.UUID `json:"user_id"`
-	Token string    `json:"token"`
+	ID           uuid.UUID `json:"user_id"`
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresIn    int64     `json:"expires_in"`
 }
 
 type UpdateUserSwag struct {
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WateringRecordReq struct {
+	AnimalID uuid.UUID `json:"animal_id" binding:"required,uuid"`
+	UpdateWateringRecordReq
+}
+
+type UpdateWateringRecordReq struct {
+	Quantity  float64   `json:"quantity" binding:"gte=0"`
+	WateredAt time.Time `json:"watered_at" binding:"required"`
+	Notes     string    `json:"notes" binding:"max=500"`
+}
+
+type WateringRecordWithoutTime struct {
+	ID uuid.UUID `json:"id"`
+	WateringRecordReq
+}
+
+type WateringRecord struct {
+	WateringRecordWithoutTime
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WateringRecordSortFields are the fields watering records can be listed by.
+var WateringRecordSortFields = []string{"watered_at", "quantity", "created_at"}
+
+type WateringRecordResp struct {
+	MessageResp
+	WateringRecordWithoutTime `json:"watering_record"`
+}
+
+// OverdueAnimal is an animal that has not been fed or watered within the
+// expected interval.
+type OverdueAnimal struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Type            string    `json:"type"`
+	LastFed         time.Time `json:"last_fed"`
+	LastWatered     time.Time `json:"last_watered"`
+	FeedingOverdue  bool      `json:"feeding_overdue"`
+	WateringOverdue bool      `json:"watering_overdue"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WeightMeasurementReq struct {
+	Weight     float64    `json:"weight" binding:"required,gt=0"`
+	MeasuredAt *time.Time `json:"measured_at"`
+	Notes      string     `json:"notes" binding:"max=500"`
+}
+
+type WeightMeasurement struct {
+	ID         uuid.UUID `json:"id"`
+	AnimalID   uuid.UUID `json:"animal_id"`
+	Weight     float64   `json:"weight"`
+	MeasuredAt time.Time `json:"measured_at"`
+	Notes      string    `json:"notes"`
+	CreatedAt  time.Time `json:"created_at"`
+	AnimalName string    `json:"-"`
+	AnimalType string    `json:"-"`
+}
+
+type WeightMeasurementResp struct {
+	MessageResp
+	WeightMeasurement `json:"weight_measurement"`
+}
+
+// GrowthFilter selects the measurements of an animal, or of a farm optionally
+// narrowed to one animal type, taken between From and To (unbounded when zero).
+type GrowthFilter struct {
+	FarmID   uuid.UUID
+	AnimalID uuid.UUID
+	Type     string
+	From     time.Time
+	To       time.Time
+}
+
+// AnimalGrowth summarizes the measurements of an animal. AverageDailyGain is
+// the weight gained per day between the first and the last measurement; it is
+// nil when they are less than a day apart.
+type AnimalGrowth struct {
+	AnimalID             uuid.UUID           `json:"animal_id"`
+	AnimalName           string              `json:"animal_name"`
+	AnimalType           string              `json:"animal_type"`
+	FirstWeight          float64             `json:"first_weight"`
+	LastWeight           float64             `json:"last_weight"`
+	Days                 float64             `json:"days"`
+	AverageDailyGain     *float64            `json:"average_daily_gain"`
+	HerdAverageDailyGain *float64            `json:"herd_average_daily_gain,omitempty"`
+	Measurements         []WeightMeasurement `json:"measurements,omitempty"`
+}
+
+// GrowthPoint is the average weight of the measurements taken in a period.
+type GrowthPoint struct {
+	PeriodStart   time.Time `json:"period_start"`
+	AverageWeight float64   `json:"average_weight"`
+	Measurements  int       `json:"measurements"`
+}
+
+// TypeGrowth is the growth curve of all animals of a type on a farm.
+type TypeGrowth struct {
+	Type             string        `json:"type"`
+	Animals          int           `json:"animals"`
+	AverageDailyGain *float64      `json:"average_daily_gain"`
+	Curve            []GrowthPoint `json:"curve"`
+}
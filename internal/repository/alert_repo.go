@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"farmish/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type AlertRepository struct {
+	DB *sql.DB
+}
+
+func NewAlertRepository(db *sql.DB) *AlertRepository {
+	return &AlertRepository{DB: db}
+}
+
+var ErrAlertNotFound = errors.New("alert not found")
+
+func (r *AlertRepository) CreateAlert(alert *models.Alert) error {
+	query := `
+        INSERT INTO alerts (id, farm_id, type, message, is_read)
+        VALUES ($1, $2, $3, $4, $5)
+    `
+	_, err := r.DB.Exec(query, alert.ID, alert.FarmID, alert.Type, alert.Message, alert.IsRead)
+	if err != nil {
+		return fmt.Errorf("failed to create alert: %v", err)
+	}
+	return nil
+}
+
+func (r *AlertRepository) GetAlertByID(alertID uuid.UUID) (*models.Alert, error) {
+	query := `SELECT id, farm_id, type, message, is_read, created_at FROM alerts WHERE id = $1`
+	row := r.DB.QueryRow(query, alertID)
+
+	var alert models.Alert
+	if err := row.Scan(&alert.ID, &alert.FarmID, &alert.Type, &alert.Message, &alert.IsRead, &alert.CreatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get alert: %v", err)
+	}
+
+	return &alert, nil
+}
+
+func (r *AlertRepository) GetAlerts(filter *models.AlertFilter, page *models.PageQuery) ([]models.Alert, int, error) {
+	q := newListQuery(`al.id, al.farm_id, al.type, al.message, al.is_read, al.created_at`, `alerts al`)
+	q.filter(`al.farm_id = ANY(?)`, pq.Array(filter.FarmIDs))
+	if filter.Type != "" {
+		q.filter(`al.type = ?`, filter.Type)
+	}
+	if filter.UnreadOnly {
+		q.filter(`al.is_read = FALSE`)
+	}
+
+	alerts, total, err := list(r.DB, q, page, sortColumns("al", models.AlertSortFields), "al.created_at DESC", "al.id",
+		func(rows *sql.Rows) (models.Alert, error) {
+			var alert models.Alert
+			if err := rows.Scan(&alert.ID, &alert.FarmID, &alert.Type, &alert.Message, &alert.IsRead, &alert.CreatedAt); err != nil {
+				return alert, fmt.Errorf("failed to scan alert: %v", err)
+			}
+			return alert, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve alerts: %v", err)
+	}
+	return alerts, total, nil
+}
+
+// SetAlertsReadStatus marks the alerts as read or unread and returns how many
+// of them exist.
+func (r *AlertRepository) SetAlertsReadStatus(alertIDs []uuid.UUID, isRead bool, actor *models.AuditActor) (updated int64, err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	for _, alertID := range alertIDs {
+		before, err := lockSnapshot(tx, models.AuditAlert, alertID)
+		if err != nil {
+			return 0, err
+		}
+		if before == nil {
+			continue
+		}
+		if _, err = tx.Exec(`UPDATE alerts SET is_read = $1 WHERE id = $2`, isRead, alertID); err != nil {
+			return 0, fmt.Errorf("failed to update alerts: %v", err)
+		}
+		if err = audit(tx, actor, models.AuditUpdate, models.AuditAlert, alertID, before); err != nil {
+			return 0, err
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+func (r *AlertRepository) DeleteAlert(alertID uuid.UUID, actor *models.AuditActor) error {
+	return auditedChange(r.DB, actor, models.AuditDelete, models.AuditAlert, alertID, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM alerts WHERE id = $1`, alertID)
+		if err != nil {
+			return fmt.Errorf("failed to delete alert: %v", err)
+		}
+		return requireRow(result, ErrAlertNotFound)
+	})
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type AnimalEventRepository struct {
+	db *sql.DB
+}
+
+func NewAnimalEventRepository(db *sql.DB) *AnimalEventRepository {
+	return &AnimalEventRepository{db: db}
+}
+
+var ErrAnimalNotActive = errors.New("animal is no longer active")
+
+// animalEventStatus is the status an animal takes after each event. A
+// transferred animal stays active on its new farm.
+var animalEventStatus = map[string]string{
+	models.AnimalEventSold:        models.AnimalSold,
+	models.AnimalEventDied:        models.AnimalDead,
+	models.AnimalEventCulled:      models.AnimalCulled,
+	models.AnimalEventTransferred: models.AnimalActive,
+}
+
+// CreateEvent stores the event and applies it to the animal: its status
+// changes, or it moves to the new farm. Schedules set up for the animal alone
+// are deactivated and its pending feeding tasks and treatment events skipped,
+// as they belong to the farm it leaves. Its records are kept.
+func (r *AnimalEventRepository) CreateEvent(event *models.AnimalEvent, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditAnimal, event.AnimalID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	UPDATE animals
+	SET status = $2, farm_id = COALESCE($3, farm_id)
+	WHERE id = $1 AND status = 'active' AND deleted_at IS NULL
+	`
+	result, err := tx.Exec(query, event.AnimalID, animalEventStatus[event.EventType], nullUUID(event.ToFarmID))
+	if err != nil {
+		return fmt.Errorf("failed to update animal: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrAnimalNotActive
+	}
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditAnimal, event.AnimalID, before); err != nil {
+		return err
+	}
+
+	query = `
+	INSERT INTO animal_events (id, animal_id, event_type, occurred_at, from_farm_id, to_farm_id, buyer, price, cause, notes, recorded_by)
+	VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), $8, NULLIF($9, ''), $10, $11)
+	RETURNING created_at
+	`
+	err = tx.QueryRow(query, event.ID, event.AnimalID, event.EventType, event.OccurredAt, nullUUID(event.FromFarmID),
+		nullUUID(event.ToFarmID), event.Buyer, event.Price, event.Cause, event.Notes, nullUUID(event.RecordedBy)).Scan(&event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create animal event: %v", err)
+	}
+	if err = audit(tx, actor, models.AuditCreate, models.AuditAnimalEvent, event.ID, nil); err != nil {
+		return err
+	}
+
+	err = updateEach(tx, actor, models.AuditFeedingSchedule,
+		`SELECT id FROM feeding_schedules WHERE animal_id = $1 AND active`,
+		`UPDATE feeding_schedules SET active = FALSE WHERE id = $1`, event.AnimalID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate feeding schedules: %v", err)
+	}
+	err = updateEach(tx, actor, models.AuditFeedingTask,
+		`SELECT id FROM feeding_tasks WHERE animal_id = $1 AND status = 'pending'`,
+		`UPDATE feeding_tasks SET status = 'skipped' WHERE id = $1`, event.AnimalID)
+	if err != nil {
+		return fmt.Errorf("failed to skip feeding tasks: %v", err)
+	}
+	err = updateEach(tx, actor, models.AuditTreatmentEvent,
+		`SELECT id FROM treatment_events WHERE animal_id = $1 AND status = 'pending'`,
+		`UPDATE treatment_events SET status = 'skipped' WHERE id = $1`, event.AnimalID)
+	if err != nil {
+		return fmt.Errorf("failed to skip treatment events: %v", err)
+	}
+
+	return nil
+}
+
+// updateEach runs update on every entity of the type that query returns for
+// the animal, auditing each one.
+func updateEach(tx *sql.Tx, actor *models.AuditActor, entityType, query, update string, animalID uuid.UUID) error {
+	ids, err := queryIDs(tx, query, animalID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		before, err := lockSnapshot(tx, entityType, id)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(update, id); err != nil {
+			return err
+		}
+		if err = audit(tx, actor, models.AuditUpdate, entityType, id, before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetEventsByAnimalID returns a page of the events of the animal, in the order
+// they occurred unless sorted otherwise, together with their total number.
+func (r *AnimalEventRepository) GetEventsByAnimalID(animalID uuid.UUID, page *models.PageQuery) ([]models.AnimalEvent, int, error) {
+	q := newListQuery(`e.id, e.animal_id, e.event_type, e.occurred_at, e.from_farm_id, e.to_farm_id, COALESCE(e.buyer, ''), e.price,
+	  COALESCE(e.cause, ''), COALESCE(e.notes, ''), e.recorded_by, e.created_at`, `animal_events e`)
+	q.filter(`e.animal_id = ?`, animalID)
+
+	events, total, err := list(r.db, q, page, sortColumns("e", models.AnimalEventSortFields), "e.occurred_at, e.created_at", "e.id",
+		func(rows *sql.Rows) (models.AnimalEvent, error) {
+			var event models.AnimalEvent
+			var fromFarmID, toFarmID, recordedBy uuid.NullUUID
+			var price sql.NullFloat64
+			err := rows.Scan(&event.ID, &event.AnimalID, &event.EventType, &event.OccurredAt, &fromFarmID, &toFarmID,
+				&event.Buyer, &price, &event.Cause, &event.Notes, &recordedBy, &event.CreatedAt)
+			if err != nil {
+				return event, fmt.Errorf("failed to scan animal event: %v", err)
+			}
+			if fromFarmID.Valid {
+				event.FromFarmID = &fromFarmID.UUID
+			}
+			if toFarmID.Valid {
+				event.ToFarmID = &toFarmID.UUID
+			}
+			if price.Valid {
+				event.Price = &price.Float64
+			}
+			if recordedBy.Valid {
+				event.RecordedBy = &recordedBy.UUID
+			}
+			return event, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get animal events: %v", err)
+	}
+	return events, total, nil
+}
+
+// GetMortality counts, per animal type, the animals that were on the farm at
+// some point between From and To, and the deaths, cullings, sales and
+// transfers out of the farm in that period.
+func (r *AnimalEventRepository) GetMortality(filter *models.ReportFilter) ([]models.MortalityReport, error) {
+	query := `
+	WITH population AS (
+	  SELECT a.id, a.type
+	  FROM animals a
+	  WHERE a.farm_id = $1 AND a.deleted_at IS NULL AND a.created_at < $3
+	    AND NOT EXISTS (
+	      SELECT 1 FROM animal_events e
+	      WHERE e.animal_id = a.id
+	        AND ((e.event_type <> 'transferred' AND e.occurred_at < $2)
+	          OR (e.event_type = 'transferred' AND e.to_farm_id = $1 AND e.occurred_at >= $3))
+	    )
+	  UNION
+	  SELECT a.id, a.type
+	  FROM animal_events e
+	  INNER JOIN animals a ON e.animal_id = a.id
+	  WHERE e.event_type = 'transferred' AND e.from_farm_id = $1 AND e.occurred_at >= $2
+	    AND a.deleted_at IS NULL AND a.created_at < $3
+	)
+	SELECT p.type, COUNT(DISTINCT p.id),
+	  COUNT(e.id) FILTER (WHERE e.event_type = 'died'),
+	  COUNT(e.id) FILTER (WHERE e.event_type = 'culled'),
+	  COUNT(e.id) FILTER (WHERE e.event_type = 'sold'),
+	  COUNT(e.id) FILTER (WHERE e.event_type = 'transferred')
+	FROM population p
+	LEFT JOIN animal_events e ON e.animal_id = p.id AND e.from_farm_id = $1 AND e.occurred_at >= $2 AND e.occurred_at < $3
+	WHERE $4::text = '' OR p.type = $4
+	GROUP BY p.type
+	ORDER BY p.type
+	`
+	rows, err := r.db.Query(query, filter.FarmID, filter.From, filter.To, filter.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mortality: %v", err)
+	}
+	defer rows.Close()
+
+	var report []models.MortalityReport
+	for rows.Next() {
+		var row models.MortalityReport
+		if err := rows.Scan(&row.GroupID, &row.Animals, &row.Deaths, &row.Culls, &row.Sales, &row.TransfersOut); err != nil {
+			return nil, fmt.Errorf("failed to scan mortality: %v", err)
+		}
+		row.GroupName = row.GroupID
+		report = append(report, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return report, nil
+}
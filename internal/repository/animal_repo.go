@@ -3,10 +3,12 @@ package repository
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"farmish/internal/models"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type AnimalRepository struct {
@@ -17,26 +19,73 @@ func NewAnimalRepository(db *sql.DB) *AnimalRepository {
 	return &AnimalRepository{DB: db}
 }
 
-func (r *AnimalRepository) CreateAnimal(animal *models.AnimalWithoutTime) error {
+// CreateAnimal stores the animal and its weight as the first measurement.
+func (r *AnimalRepository) CreateAnimal(animal *models.AnimalWithoutTime, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	return insertAnimal(tx, actor, animal, nil)
+}
+
+// insertAnimal stores the animal and its weight as the first measurement.
+// Animals born on the farm reference their birth.
+func insertAnimal(tx *sql.Tx, actor *models.AuditActor, animal *models.AnimalWithoutTime, birthID *uuid.UUID) error {
 	query := `
-    INSERT INTO animals (id, farm_id, name, type, weight, health_status, date_of_birth)
-    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    INSERT INTO animals (id, farm_id, name, type, weight, health_status, date_of_birth, sex, sire_id, dam_id, birth_id)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
   `
-	_, err := r.DB.Exec(query, animal.ID, animal.FarmID, animal.Name, animal.Type, animal.Weight,
-		animal.HealthStatus, animal.DateOfBirth)
+	_, err := tx.Exec(query, animal.ID, animal.FarmID, animal.Name, animal.Type, animal.Weight,
+		animal.HealthStatus, animal.DateOfBirth, animal.Sex, nullUUID(animal.SireID), nullUUID(animal.DamID), nullUUID(birthID))
 	if err != nil {
 		return fmt.Errorf("failed to create animal: %v", err)
 	}
+
+	if err = audit(tx, actor, models.AuditCreate, models.AuditAnimal, animal.ID, nil); err != nil {
+		return err
+	}
+
+	return insertWeightMeasurement(tx, actor, &models.WeightMeasurement{
+		ID:         uuid.New(),
+		AnimalID:   animal.ID,
+		Weight:     animal.Weight,
+		MeasuredAt: time.Now(),
+	})
+}
+
+const animalColumns = `id, farm_id, name, type, weight, health_status, date_of_birth, sex, sire_id, dam_id, status, last_fed, last_watered, created_at, updated_at`
+
+func scanAnimal(row interface{ Scan(...interface{}) error }, animal *models.Animal) error {
+	var sireID, damID uuid.NullUUID
+	err := row.Scan(&animal.ID, &animal.FarmID, &animal.Name, &animal.Type, &animal.Weight, &animal.HealthStatus, &animal.DateOfBirth,
+		&animal.Sex, &sireID, &damID, &animal.Status, &animal.LastFed, &animal.LastWatered, &animal.CreatedAt, &animal.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if sireID.Valid {
+		animal.SireID = &sireID.UUID
+	}
+	if damID.Valid {
+		animal.DamID = &damID.UUID
+	}
 	return nil
 }
 
 func (r *AnimalRepository) GetAnimalByID(id uuid.UUID) (*models.Animal, error) {
-	query := `SELECT id, farm_id, name, type, weight, health_status, date_of_birth, last_fed, last_watered, created_at, updated_at FROM animals WHERE id = $1`
+	query := `SELECT ` + animalColumns + ` FROM animals WHERE id = $1 AND deleted_at IS NULL`
 	row := r.DB.QueryRow(query, id)
 
 	var animal models.Animal
-	if err := row.Scan(&animal.ID, &animal.FarmID, &animal.Name, &animal.Type, &animal.Weight,
-		&animal.HealthStatus, &animal.DateOfBirth, &animal.LastFed, &animal.LastWatered, &animal.CreatedAt, &animal.UpdatedAt); err != nil {
+	if err := scanAnimal(row, &animal); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -46,50 +95,301 @@ func (r *AnimalRepository) GetAnimalByID(id uuid.UUID) (*models.Animal, error) {
 	return &animal, nil
 }
 
-func (r *AnimalRepository) GetAnimalsByFarmID(farmID uuid.UUID) ([]*models.Animal, error) {
-	query := `SELECT id, farm_id, name, type, weight, health_status, date_of_birth, last_fed, last_watered, created_at, updated_at FROM animals WHERE farm_id = $1`
+// GetAnimalsByFarmID returns a page of the animals of a farm that match the
+// filter, together with their total number.
+func (r *AnimalRepository) GetAnimalsByFarmID(filter *models.AnimalFilter, page *models.PageQuery) ([]*models.Animal, int, error) {
+	q := newListQuery(animalColumns, `animals a`)
+	q.filter(`farm_id = ?`, filter.FarmID)
+	q.filter(`deleted_at IS NULL`)
+	if filter.Status != "" {
+		q.filter(`status = ?`, filter.Status)
+	}
+	if filter.Type != "" {
+		q.filter(`type = ?`, filter.Type)
+	}
+	if filter.HealthStatus != "" {
+		q.filter(`health_status = ?`, filter.HealthStatus)
+	}
+	if filter.MinAge != nil {
+		q.filter(`date_of_birth <= CURRENT_DATE - ?::int`, *filter.MinAge)
+	}
+	if filter.MaxAge != nil {
+		q.filter(`date_of_birth >= CURRENT_DATE - ?::int`, *filter.MaxAge)
+	}
+
+	animals, total, err := list(r.DB, q, page, sortColumns("a", models.AnimalSortFields), "a.name", "a.id",
+		func(rows *sql.Rows) (*models.Animal, error) {
+			var animal models.Animal
+			if err := scanAnimal(rows, &animal); err != nil {
+				return nil, fmt.Errorf("failed to scan animal: %v", err)
+			}
+			return &animal, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get animals by farm ID: %v", err)
+	}
+	return animals, total, nil
+}
+
+// UpdateAnimal updates the animal. A changed weight is appended to its weight
+// history as a measurement taken now.
+func (r *AnimalRepository) UpdateAnimal(animal *models.UpdateAnimalReq, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var currentWeight float64
+	err = tx.QueryRow(`SELECT weight FROM animals WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, animal.ID).Scan(&currentWeight)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		return fmt.Errorf("failed to update animal: %v", err)
+	}
+
+	before, err := lockSnapshot(tx, models.AuditAnimal, animal.ID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+    UPDATE animals
+    SET name = $1, type = $2, weight = $3, health_status = $4, date_of_birth = $5, last_fed = $6, last_watered = $7,
+      sex = $8, sire_id = $9, dam_id = $10
+    WHERE id = $11
+  `
+	_, err = tx.Exec(query, animal.Name, animal.Type, animal.Weight, animal.HealthStatus, animal.DateOfBirth,
+		animal.LastFed, animal.LastWatered, animal.Sex, nullUUID(animal.SireID), nullUUID(animal.DamID), animal.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update animal: %v", err)
+	}
+
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditAnimal, animal.ID, before); err != nil {
+		return err
+	}
+
+	if animal.Weight == currentWeight {
+		return nil
+	}
+
+	return insertWeightMeasurement(tx, actor, &models.WeightMeasurement{
+		ID:         uuid.New(),
+		AnimalID:   animal.ID,
+		Weight:     animal.Weight,
+		MeasuredAt: time.Now(),
+		Notes:      "animal updated",
+	})
+}
+
+// CountActiveByType returns how many active animals of each type the farm has.
+func (r *AnimalRepository) CountActiveByType(farmID uuid.UUID) (map[string]int, error) {
+	query := `SELECT type, COUNT(*) FROM animals WHERE farm_id = $1 AND status = 'active' AND deleted_at IS NULL GROUP BY type`
 	rows, err := r.DB.Query(query, farmID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get animals by farm ID: %v", err)
+		return nil, fmt.Errorf("failed to count animals: %v", err)
 	}
 	defer rows.Close()
 
-	var animals []*models.Animal
+	counts := make(map[string]int)
 	for rows.Next() {
-		var animal models.Animal
-		if err := rows.Scan(&animal.ID, &animal.FarmID, &animal.Name, &animal.Type, &animal.Weight,
-			&animal.HealthStatus, &animal.DateOfBirth, &animal.LastFed, &animal.LastWatered, &animal.CreatedAt, &animal.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan animal: %v", err)
+		var animalType string
+		var count int
+		if err := rows.Scan(&animalType, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan animal count: %v", err)
 		}
-		animals = append(animals, &animal)
+		counts[animalType] = count
 	}
 
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
 	}
 
-	return animals, nil
+	return counts, nil
 }
 
-func (r *AnimalRepository) UpdateAnimal(animal *models.UpdateAnimalReq) error {
+// IsDescendant reports whether the animal descends from the given ancestor
+// through its recorded sires and dams.
+func (r *AnimalRepository) IsDescendant(animalID, ancestorID uuid.UUID) (bool, error) {
 	query := `
-    UPDATE animals
-    SET name = $1, type = $2, weight = $3, health_status = $4, date_of_birth = $5, last_fed = $6, last_watered = $7
-    WHERE id = $8
+    WITH RECURSIVE ancestors(id) AS (
+      SELECT unnest(ARRAY[sire_id, dam_id]) FROM animals WHERE id = $1
+      UNION
+      SELECT unnest(ARRAY[a.sire_id, a.dam_id]) FROM animals a INNER JOIN ancestors p ON a.id = p.id
+    )
+    SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2)
   `
-	_, err := r.DB.Exec(query, animal.Name, animal.Type, animal.Weight, animal.HealthStatus, animal.DateOfBirth,
-		animal.LastFed, animal.LastWatered, animal.ID)
-	if err != nil {
-		return fmt.Errorf("failed to update animal: %v", err)
+	var descendant bool
+	if err := r.DB.QueryRow(query, animalID, ancestorID).Scan(&descendant); err != nil {
+		return false, fmt.Errorf("failed to check animal ancestry: %v", err)
+	}
+	return descendant, nil
+}
+
+const pedigreeColumns = `x.id, COALESCE(x.name, ''), x.type, x.sex, x.date_of_birth, x.sire_id, x.dam_id`
+
+func scanPedigreeAnimal(row interface{ Scan(...interface{}) error }, animal *models.PedigreeAnimal, extra ...interface{}) error {
+	var dateOfBirth sql.NullTime
+	var sireID, damID uuid.NullUUID
+	dest := append([]interface{}{&animal.ID, &animal.Name, &animal.Type, &animal.Sex, &dateOfBirth, &sireID, &damID}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return err
+	}
+	if dateOfBirth.Valid {
+		animal.DateOfBirth = &dateOfBirth.Time
+	}
+	if sireID.Valid {
+		animal.SireID = &sireID.UUID
+	}
+	if damID.Valid {
+		animal.DamID = &damID.UUID
 	}
 	return nil
 }
 
-func (r *AnimalRepository) DeleteAnimal(id uuid.UUID) error {
-	query := `DELETE FROM animals WHERE id = $1`
-	_, err := r.DB.Exec(query, id)
+// GetAncestry returns the animals and their ancestors up to the given number
+// of generations back.
+func (r *AnimalRepository) GetAncestry(animalIDs []uuid.UUID, generations int) (map[uuid.UUID]models.PedigreeAnimal, error) {
+	query := `
+    WITH RECURSIVE ancestry(id, depth) AS (
+      SELECT id, 0 FROM animals WHERE id = ANY($1)
+      UNION
+      SELECT p.parent_id, a.depth + 1
+      FROM ancestry a
+      INNER JOIN animals x ON x.id = a.id
+      CROSS JOIN LATERAL unnest(ARRAY[x.sire_id, x.dam_id]) AS p(parent_id)
+      WHERE p.parent_id IS NOT NULL AND a.depth < $2
+    )
+    SELECT DISTINCT ` + pedigreeColumns + `
+    FROM ancestry a
+    INNER JOIN animals x ON x.id = a.id
+  `
+	rows, err := r.DB.Query(query, pq.Array(animalIDs), generations)
 	if err != nil {
-		return fmt.Errorf("failed to delete animal: %v", err)
+		return nil, fmt.Errorf("failed to get ancestry: %v", err)
 	}
-	return nil
+	defer rows.Close()
+
+	ancestry := make(map[uuid.UUID]models.PedigreeAnimal)
+	for rows.Next() {
+		var animal models.PedigreeAnimal
+		if err := scanPedigreeAnimal(rows, &animal); err != nil {
+			return nil, fmt.Errorf("failed to scan ancestor: %v", err)
+		}
+		ancestry[animal.ID] = animal
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return ancestry, nil
+}
+
+// GetDescendants returns the offspring of the animal down to the given number
+// of generations, each at the closest generation it appears in.
+func (r *AnimalRepository) GetDescendants(animalID uuid.UUID, generations int) ([]models.Descendant, error) {
+	query := `
+    WITH RECURSIVE descendants(id, generation) AS (
+      SELECT id, 1 FROM animals WHERE sire_id = $1 OR dam_id = $1
+      UNION
+      SELECT x.id, d.generation + 1
+      FROM descendants d
+      INNER JOIN animals x ON x.sire_id = d.id OR x.dam_id = d.id
+      WHERE d.generation < $2
+    )
+    SELECT ` + pedigreeColumns + `, MIN(d.generation)
+    FROM descendants d
+    INNER JOIN animals x ON x.id = d.id
+    WHERE x.deleted_at IS NULL
+    GROUP BY x.id
+    ORDER BY MIN(d.generation), x.date_of_birth
+  `
+	rows, err := r.DB.Query(query, animalID, generations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get descendants: %v", err)
+	}
+	defer rows.Close()
+
+	var descendants []models.Descendant
+	for rows.Next() {
+		var descendant models.Descendant
+		if err := scanPedigreeAnimal(rows, &descendant.PedigreeAnimal, &descendant.Generation); err != nil {
+			return nil, fmt.Errorf("failed to scan descendant: %v", err)
+		}
+		descendants = append(descendants, descendant)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return descendants, nil
+}
+
+// DeleteAnimal moves the animal and its records to the trash.
+func (r *AnimalRepository) DeleteAnimal(id uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditAnimal, id)
+	if err != nil {
+		return err
+	}
+
+	if err = trashAnimals(tx, `id = $1`, id); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditDelete, models.AuditAnimal, id, before)
+}
+
+// GetOverdueAnimals returns the active animals of the farm last fed before
+// fedBefore or last watered before wateredBefore.
+func (r *AnimalRepository) GetOverdueAnimals(farmID uuid.UUID, fedBefore, wateredBefore time.Time) ([]models.OverdueAnimal, error) {
+	query := `
+	SELECT id, COALESCE(name, ''), type, last_fed, last_watered, last_fed < $2, last_watered < $3
+	FROM animals
+	WHERE farm_id = $1 AND status = 'active' AND deleted_at IS NULL AND (last_fed < $2 OR last_watered < $3)
+	ORDER BY LEAST(last_fed, last_watered)
+	`
+	rows, err := r.DB.Query(query, farmID, fedBefore, wateredBefore)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overdue animals: %v", err)
+	}
+	defer rows.Close()
+
+	var animals []models.OverdueAnimal
+	for rows.Next() {
+		var animal models.OverdueAnimal
+		if err := rows.Scan(&animal.ID, &animal.Name, &animal.Type, &animal.LastFed, &animal.LastWatered,
+			&animal.FeedingOverdue, &animal.WateringOverdue); err != nil {
+			return nil, fmt.Errorf("failed to scan overdue animal: %v", err)
+		}
+		animals = append(animals, animal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return animals, nil
 }
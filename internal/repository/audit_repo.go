@@ -0,0 +1,336 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+type AuditRepository struct {
+	db *sql.DB
+}
+
+func NewAuditRepository(db *sql.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+var ErrAuditHistoryNotFound = errors.New("no audit history found for this entity")
+
+// auditSnapshotQueries return the farm and the stored row of each kind of
+// audited entity, whether it is deleted or not.
+var auditSnapshotQueries = map[string]string{
+	models.AuditUser:            `SELECT NULL::uuid, to_jsonb(t) - 'password_hash' FROM users t WHERE t.id = $1`,
+	models.AuditFarm:            `SELECT t.id, to_jsonb(t) FROM farms t WHERE t.id = $1`,
+	models.AuditFarmInvitation:  `SELECT t.farm_id, to_jsonb(t) FROM farm_invitations t WHERE t.id = $1`,
+	models.AuditAnimal:          `SELECT t.farm_id, to_jsonb(t) FROM animals t WHERE t.id = $1`,
+	models.AuditAnimalEvent:     `SELECT t.from_farm_id, to_jsonb(t) FROM animal_events t WHERE t.id = $1`,
+	models.AuditFood:            `SELECT t.farm_id, to_jsonb(t) FROM foods t WHERE t.id = $1`,
+	models.AuditMedicine:        `SELECT t.farm_id, to_jsonb(t) FROM medicines t WHERE t.id = $1`,
+	models.AuditSupplier:        `SELECT t.farm_id, to_jsonb(t) FROM suppliers t WHERE t.id = $1`,
+	models.AuditFeedingSchedule: `SELECT t.farm_id, to_jsonb(t) FROM feeding_schedules t WHERE t.id = $1`,
+	models.AuditTreatmentPlan:   `SELECT t.farm_id, to_jsonb(t) FROM treatment_plans t WHERE t.id = $1`,
+	models.AuditAlert:           `SELECT t.farm_id, to_jsonb(t) FROM alerts t WHERE t.id = $1`,
+	models.AuditProductionRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id), to_jsonb(t) FROM production_records t LEFT JOIN animals a ON t.animal_id = a.id WHERE t.id = $1`,
+	models.AuditPurchaseOrder: `
+	SELECT t.farm_id, to_jsonb(t) || jsonb_build_object('items', COALESCE(
+		(SELECT jsonb_agg(to_jsonb(i) ORDER BY i.id) FROM purchase_order_items i WHERE i.order_id = t.id), '[]'))
+	FROM purchase_orders t WHERE t.id = $1`,
+	models.AuditWeightMeasurement: `
+	SELECT a.farm_id, to_jsonb(t) FROM weight_measurements t INNER JOIN animals a ON t.animal_id = a.id WHERE t.id = $1`,
+	models.AuditFeedingRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id), to_jsonb(t) FROM feeding_records t LEFT JOIN animals a ON t.animal_id = a.id WHERE t.id = $1`,
+	models.AuditWateringRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id), to_jsonb(t) FROM watering_records t LEFT JOIN animals a ON t.animal_id = a.id WHERE t.id = $1`,
+	models.AuditMedicalRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id), to_jsonb(t) FROM medical_records t LEFT JOIN animals a ON t.animal_id = a.id WHERE t.id = $1`,
+	models.AuditBreedingEvent: `
+	SELECT a.farm_id, to_jsonb(t) FROM breeding_events t INNER JOIN animals a ON t.dam_id = a.id WHERE t.id = $1`,
+	models.AuditPregnancyCheck: `
+	SELECT a.farm_id, to_jsonb(t) FROM pregnancy_checks t
+	INNER JOIN breeding_events b ON t.breeding_event_id = b.id
+	INNER JOIN animals a ON b.dam_id = a.id
+	WHERE t.id = $1`,
+	models.AuditBirth: `
+	SELECT a.farm_id, to_jsonb(t) FROM births t
+	INNER JOIN breeding_events b ON t.breeding_event_id = b.id
+	INNER JOIN animals a ON b.dam_id = a.id
+	WHERE t.id = $1`,
+	models.AuditMedicineBatch: `
+	SELECT m.farm_id, to_jsonb(t) FROM medicine_batches t INNER JOIN medicines m ON t.medicine_id = m.id WHERE t.id = $1`,
+	models.AuditFeedingTask: `
+	SELECT s.farm_id, to_jsonb(t) FROM feeding_tasks t INNER JOIN feeding_schedules s ON t.schedule_id = s.id WHERE t.id = $1`,
+	models.AuditTreatmentEvent: `
+	SELECT p.farm_id, to_jsonb(t) FROM treatment_events t INNER JOIN treatment_plans p ON t.plan_id = p.id WHERE t.id = $1`,
+}
+
+// IsAuditedType reports whether changes to the entity type are audited.
+func (r *AuditRepository) IsAuditedType(entityType string) bool {
+	_, ok := auditSnapshotQueries[entityType]
+	return ok || entityType == models.AuditFarmMember
+}
+
+// memberSnapshotQuery returns the farm and the stored row of a membership,
+// which is audited under the ID of the member.
+const memberSnapshotQuery = `SELECT t.farm_id, to_jsonb(t) FROM farm_members t WHERE t.farm_id = $1 AND t.user_id = $2`
+
+// auditIgnoredFields change on every update and are left out of the changes.
+var auditIgnoredFields = map[string]bool{"updated_at": true}
+
+// lockSnapshot locks the entity for the rest of the transaction and returns
+// its state before a change, or nil when it does not exist. Pass it to audit
+// once the change is made.
+func lockSnapshot(tx *sql.Tx, entityType string, id uuid.UUID) (*models.AuditSnapshot, error) {
+	query, ok := auditSnapshotQueries[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type %q", entityType)
+	}
+	return getSnapshot(tx, entityType, id, query+` FOR UPDATE OF t`, id)
+}
+
+// audit records in the audit log what the action changed on the entity,
+// within the transaction that changed it, so that the change and its entry
+// are committed or rolled back together. before is the state taken with
+// lockSnapshot, nil for created entities.
+func audit(tx *sql.Tx, actor *models.AuditActor, action, entityType string, id uuid.UUID, before *models.AuditSnapshot) error {
+	query, ok := auditSnapshotQueries[entityType]
+	if !ok {
+		return fmt.Errorf("unknown entity type %q", entityType)
+	}
+	after, err := getSnapshot(tx, entityType, id, query, id)
+	if err != nil {
+		return err
+	}
+	return createAuditEntry(tx, actor, action, before, after)
+}
+
+// lockMemberSnapshot is lockSnapshot for the membership of the user on the farm.
+func lockMemberSnapshot(tx *sql.Tx, farmID, userID uuid.UUID) (*models.AuditSnapshot, error) {
+	return getSnapshot(tx, models.AuditFarmMember, userID, memberSnapshotQuery+` FOR UPDATE`, farmID, userID)
+}
+
+// auditMember is audit for the membership of the user on the farm.
+func auditMember(tx *sql.Tx, actor *models.AuditActor, action string, farmID, userID uuid.UUID, before *models.AuditSnapshot) error {
+	after, err := getSnapshot(tx, models.AuditFarmMember, userID, memberSnapshotQuery, farmID, userID)
+	if err != nil {
+		return err
+	}
+	return createAuditEntry(tx, actor, action, before, after)
+}
+
+// auditedChange runs change, which changes a single entity, in a transaction
+// of its own and audits it. An error returned by change rolls it back.
+func auditedChange(db *sql.DB, actor *models.AuditActor, action, entityType string, id uuid.UUID,
+	change func(tx *sql.Tx) error) (err error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var before *models.AuditSnapshot
+	if action != models.AuditCreate {
+		if before, err = lockSnapshot(tx, entityType, id); err != nil {
+			return err
+		}
+	}
+
+	if err = change(tx); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, action, entityType, id, before)
+}
+
+// requireRow returns notFound when the statement changed no row.
+func requireRow(result sql.Result, notFound error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+func getSnapshot(tx *sql.Tx, entityType string, id uuid.UUID, query string, args ...interface{}) (*models.AuditSnapshot, error) {
+	var farmID uuid.NullUUID
+	var data []byte
+	if err := tx.QueryRow(query, args...).Scan(&farmID, &data); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s snapshot: %v", entityType, err)
+	}
+
+	snapshot := &models.AuditSnapshot{EntityType: entityType, EntityID: id}
+	if farmID.Valid {
+		snapshot.FarmID = &farmID.UUID
+	}
+	if err := json.Unmarshal(data, &snapshot.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s snapshot: %v", entityType, err)
+	}
+	return snapshot, nil
+}
+
+// createAuditEntry writes an entry with the fields that differ between the
+// snapshots taken before and after a change. before is nil for created
+// entities and after is nil for entities that no longer exist. Changes that
+// changed nothing are not recorded.
+func createAuditEntry(tx *sql.Tx, actor *models.AuditActor, action string, before, after *models.AuditSnapshot) error {
+	subject := after
+	if subject == nil {
+		subject = before
+	}
+	if subject == nil {
+		return nil
+	}
+
+	changes := diffSnapshots(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit changes: %v", err)
+	}
+
+	var userID *uuid.UUID
+	if actor.UserID != uuid.Nil {
+		userID = &actor.UserID
+	}
+
+	query := `
+	INSERT INTO audit_log (id, user_id, farm_id, entity_type, entity_id, action, changes, request_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
+	`
+	_, err = tx.Exec(query, uuid.New(), nullUUID(userID), nullUUID(subject.FarmID), subject.EntityType, subject.EntityID,
+		action, data, actor.RequestID)
+	if err != nil {
+		return fmt.Errorf("failed to create audit entry: %v", err)
+	}
+	return nil
+}
+
+// diffSnapshots returns the fields whose value differs between the snapshots.
+func diffSnapshots(before, after *models.AuditSnapshot) map[string]models.AuditChange {
+	var old, current map[string]interface{}
+	if before != nil {
+		old = before.Data
+	}
+	if after != nil {
+		current = after.Data
+	}
+
+	changes := make(map[string]models.AuditChange)
+	for field, value := range old {
+		if next, ok := current[field]; !ok || !reflect.DeepEqual(value, next) {
+			changes[field] = models.AuditChange{Before: value, After: current[field]}
+		}
+	}
+	for field, value := range current {
+		if _, ok := old[field]; !ok {
+			changes[field] = models.AuditChange{After: value}
+		}
+	}
+
+	for field := range auditIgnoredFields {
+		delete(changes, field)
+	}
+	return changes
+}
+
+const auditEntryColumns = `l.id, l.user_id, l.farm_id, l.entity_type, l.entity_id, l.action, l.changes, COALESCE(l.request_id, ''), l.created_at`
+
+func scanAuditEntry(rows *sql.Rows) (models.AuditEntry, error) {
+	var entry models.AuditEntry
+	var userID, farmID uuid.NullUUID
+	var changes []byte
+	if err := rows.Scan(&entry.ID, &userID, &farmID, &entry.EntityType, &entry.EntityID, &entry.Action,
+		&changes, &entry.RequestID, &entry.CreatedAt); err != nil {
+		return entry, fmt.Errorf("failed to scan audit entry: %v", err)
+	}
+	if userID.Valid {
+		entry.UserID = &userID.UUID
+	}
+	if farmID.Valid {
+		entry.FarmID = &farmID.UUID
+	}
+	if err := json.Unmarshal(changes, &entry.Changes); err != nil {
+		return entry, fmt.Errorf("failed to decode audit changes: %v", err)
+	}
+	return entry, nil
+}
+
+// GetEntityHistory returns the audit entries of an entity, latest first
+// unless the page is sorted otherwise.
+func (r *AuditRepository) GetEntityHistory(entityType string, entityID uuid.UUID, page *models.PageQuery) ([]models.AuditEntry, int, error) {
+	q := newListQuery(auditEntryColumns, `audit_log l`)
+	q.filter(`l.entity_type = ?`, entityType)
+	q.filter(`l.entity_id = ?`, entityID)
+
+	entries, total, err := list(r.db, q, page, sortColumns("l", models.AuditSortFields), "l.created_at DESC", "l.id", scanAuditEntry)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve audit entries: %v", err)
+	}
+	return entries, total, nil
+}
+
+// GetEntityFarmID returns the farm the entity belonged to at its latest audit
+// entry, or nil for entities outside a farm.
+func (r *AuditRepository) GetEntityFarmID(entityType string, entityID uuid.UUID) (*uuid.UUID, error) {
+	query := `
+	SELECT farm_id FROM audit_log WHERE entity_type = $1 AND entity_id = $2
+	ORDER BY created_at DESC LIMIT 1
+	`
+	var farmID uuid.NullUUID
+	if err := r.db.QueryRow(query, entityType, entityID).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrAuditHistoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get audit history: %v", err)
+	}
+
+	if !farmID.Valid {
+		return nil, nil
+	}
+	return &farmID.UUID, nil
+}
+
+// GetFarmTimeline returns the audit entries of a farm, latest first unless
+// the page is sorted otherwise.
+func (r *AuditRepository) GetFarmTimeline(filter *models.AuditFilter, page *models.PageQuery) ([]models.AuditEntry, int, error) {
+	q := newListQuery(auditEntryColumns, `audit_log l`)
+	q.filter(`l.farm_id = ?`, filter.FarmID)
+	if filter.EntityType != "" {
+		q.filter(`l.entity_type = ?`, filter.EntityType)
+	}
+	if filter.UserID != uuid.Nil {
+		q.filter(`l.user_id = ?`, filter.UserID)
+	}
+	if !filter.From.IsZero() {
+		q.filter(`l.created_at >= ?`, filter.From)
+	}
+	if !filter.To.IsZero() {
+		q.filter(`l.created_at < ?`, filter.To)
+	}
+
+	entries, total, err := list(r.db, q, page, sortColumns("l", models.AuditSortFields), "l.created_at DESC", "l.id", scanAuditEntry)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve audit entries: %v", err)
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"farmish/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestStockSideEffectsAreAudited feeds an animal, trashes the record and the
+// food, and purges them the way the background job does. Every change,
+// including the stock the record consumed and gave back, must leave an entry
+// under the actor that made it.
+func TestStockSideEffectsAreAudited(t *testing.T) {
+	db := openTestDB(t)
+
+	f := seedStock(t, db, 10)
+	record := &models.FeedingRecordWithoutTime{ID: uuid.New()}
+	record.AnimalID = f.animalID
+	record.FoodID = f.foodID
+	record.Quantity = 1
+	record.FedAt = time.Now()
+	if _, err := NewFeedingRecordRepository(db).CreateFeedingRecord(record, testActor); err != nil {
+		t.Fatalf("failed to create feeding record: %v", err)
+	}
+	if err := NewFeedingRecordRepository(db).DeleteFeedingRecord(record.ID, testActor); err != nil {
+		t.Fatalf("failed to delete feeding record: %v", err)
+	}
+	if err := NewFoodRepository(db).DeleteFood(f.foodID, testActor); err != nil {
+		t.Fatalf("failed to delete food: %v", err)
+	}
+	job := &models.AuditActor{RequestID: "job: trash purge"}
+	if _, err := NewTrashRepository(db).Purge(time.Now().Add(time.Hour), job); err != nil {
+		t.Fatalf("failed to purge trash: %v", err)
+	}
+
+	for _, want := range []struct {
+		entityType, action, requestID string
+		id                            uuid.UUID
+		entries                       int
+	}{
+		{models.AuditFeedingRecord, models.AuditCreate, testActor.RequestID, record.ID, 1},
+		{models.AuditFeedingRecord, models.AuditDelete, testActor.RequestID, record.ID, 1},
+		{models.AuditFood, models.AuditUpdate, testActor.RequestID, f.foodID, 2},
+		{models.AuditFood, models.AuditDelete, testActor.RequestID, f.foodID, 1},
+		{models.AuditFeedingRecord, models.AuditDelete, job.RequestID, record.ID, 1},
+		{models.AuditFood, models.AuditDelete, job.RequestID, f.foodID, 1},
+	} {
+		var count int
+		err := db.QueryRow(`SELECT COUNT(*) FROM audit_log
+			WHERE entity_type = $1 AND entity_id = $2 AND action = $3 AND request_id = $4 AND user_id IS NULL`,
+			want.entityType, want.id, want.action, want.requestID).Scan(&count)
+		if err != nil {
+			t.Fatalf("failed to read audit log: %v", err)
+		}
+		if count != want.entries {
+			t.Errorf("%s %s by %q: %d entries, want %d", want.action, want.entityType, want.requestID, count, want.entries)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BreedingRepository struct {
+	db *sql.DB
+}
+
+func NewBreedingRepository(db *sql.DB) *BreedingRepository {
+	return &BreedingRepository{db: db}
+}
+
+var (
+	ErrBreedingEventNotFound = errors.New("breeding event not found")
+	ErrBreedingDelivered     = errors.New("birth was already recorded for this breeding event")
+)
+
+func (r *BreedingRepository) CreateEvent(event *models.BreedingEvent, actor *models.AuditActor) error {
+	query := `
+	INSERT INTO breeding_events (id, dam_id, sire_id, sire_description, method, bred_at, expected_due_date, status, notes, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING created_at
+	`
+	err := auditedChange(r.db, actor, models.AuditCreate, models.AuditBreedingEvent, event.ID, func(tx *sql.Tx) error {
+		return tx.QueryRow(query, event.ID, event.DamID, nullUUID(event.SireID), event.SireDescription, event.Method, event.BredAt,
+			event.ExpectedDueDate.Format("2006-01-02"), event.Status, event.Notes, nullUUID(event.CreatedBy)).Scan(&event.CreatedAt)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create breeding event: %v", err)
+	}
+	return nil
+}
+
+const breedingEventQuery = `
+	SELECT e.id, d.farm_id, e.dam_id, COALESCE(d.name, ''), e.sire_id, COALESCE(s.name, ''), COALESCE(e.sire_description, ''),
+	  e.method, e.bred_at, e.expected_due_date, e.status, COALESCE(e.notes, ''), e.created_by, e.created_at,
+	  e.expected_due_date - CURRENT_DATE
+	FROM breeding_events e
+	INNER JOIN animals d ON e.dam_id = d.id
+	LEFT JOIN animals s ON e.sire_id = s.id
+	`
+
+func scanBreedingEvent(row interface{ Scan(...interface{}) error }, event *models.BreedingEvent, daysLeft *int) error {
+	var sireID, createdBy uuid.NullUUID
+	err := row.Scan(&event.ID, &event.FarmID, &event.DamID, &event.DamName, &sireID, &event.SireName, &event.SireDescription,
+		&event.Method, &event.BredAt, &event.ExpectedDueDate, &event.Status, &event.Notes, &createdBy, &event.CreatedAt, daysLeft)
+	if err != nil {
+		return err
+	}
+	if sireID.Valid {
+		event.SireID = &sireID.UUID
+	}
+	if createdBy.Valid {
+		event.CreatedBy = &createdBy.UUID
+	}
+	return nil
+}
+
+func (r *BreedingRepository) queryEvents(query string, args ...interface{}) ([]models.UpcomingBirth, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get breeding events: %v", err)
+	}
+	defer rows.Close()
+
+	var events []models.UpcomingBirth
+	for rows.Next() {
+		var event models.UpcomingBirth
+		if err := scanBreedingEvent(rows, &event.BreedingEvent, &event.DaysLeft); err != nil {
+			return nil, fmt.Errorf("failed to scan breeding event: %v", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return events, nil
+}
+
+// GetEventByID returns the breeding event with its pregnancy checks and birth.
+func (r *BreedingRepository) GetEventByID(id uuid.UUID) (*models.BreedingEvent, error) {
+	var event models.BreedingEvent
+	var daysLeft int
+	if err := scanBreedingEvent(r.db.QueryRow(breedingEventQuery+` WHERE e.id = $1`, id), &event, &daysLeft); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get breeding event: %v", err)
+	}
+
+	if err := r.loadEventDetails(&event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetEventsByDam returns every breeding event of the female, newest first,
+// with their pregnancy checks and births.
+func (r *BreedingRepository) GetEventsByDam(damID uuid.UUID) ([]models.BreedingEvent, error) {
+	rows, err := r.queryEvents(breedingEventQuery+` WHERE e.dam_id = $1 ORDER BY e.bred_at DESC`, damID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]models.BreedingEvent, 0, len(rows))
+	for _, row := range rows {
+		event := row.BreedingEvent
+		if err := r.loadEventDetails(&event); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// GetUpcomingBirths returns the breeding events of the farm that still await
+// their birth and are due up to the given day, overdue ones included.
+func (r *BreedingRepository) GetUpcomingBirths(farmID uuid.UUID, until time.Time) ([]models.UpcomingBirth, error) {
+	query := breedingEventQuery + `
+	WHERE d.farm_id = $1 AND d.deleted_at IS NULL AND e.status IN ('bred', 'pregnant') AND e.expected_due_date <= $2
+	ORDER BY e.expected_due_date
+	`
+	return r.queryEvents(query, farmID, until.Format("2006-01-02"))
+}
+
+func (r *BreedingRepository) loadEventDetails(event *models.BreedingEvent) error {
+	query := `
+	SELECT id, checked_at, result, COALESCE(notes, ''), created_at
+	FROM pregnancy_checks
+	WHERE breeding_event_id = $1
+	ORDER BY checked_at
+	`
+	rows, err := r.db.Query(query, event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get pregnancy checks: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		check := models.PregnancyCheck{BreedingEventID: event.ID}
+		if err := rows.Scan(&check.ID, &check.CheckedAt, &check.Result, &check.Notes, &check.CreatedAt); err != nil {
+			return fmt.Errorf("failed to scan pregnancy check: %v", err)
+		}
+		event.PregnancyChecks = append(event.PregnancyChecks, check)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	birth := models.Birth{BreedingEventID: event.ID}
+	query = `SELECT id, born_at, live_count, stillborn_count, COALESCE(notes, ''), created_at FROM births WHERE breeding_event_id = $1`
+	err = r.db.QueryRow(query, event.ID).Scan(&birth.ID, &birth.BornAt, &birth.LiveCount, &birth.StillbornCount, &birth.Notes, &birth.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get birth: %v", err)
+	}
+
+	if birth.Offspring, err = r.getOffspring(birth.ID); err != nil {
+		return err
+	}
+	event.Birth = &birth
+	return nil
+}
+
+func (r *BreedingRepository) getOffspring(birthID uuid.UUID) ([]models.AnimalWithoutTime, error) {
+	query := `
+	SELECT id, farm_id, COALESCE(name, ''), type, weight, health_status, date_of_birth, sex, sire_id, dam_id
+	FROM animals
+	WHERE birth_id = $1 AND deleted_at IS NULL
+	ORDER BY created_at
+	`
+	rows, err := r.db.Query(query, birthID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offspring: %v", err)
+	}
+	defer rows.Close()
+
+	offspring := []models.AnimalWithoutTime{}
+	for rows.Next() {
+		var animal models.AnimalWithoutTime
+		var sireID, damID uuid.NullUUID
+		err := rows.Scan(&animal.ID, &animal.FarmID, &animal.Name, &animal.Type, &animal.Weight, &animal.HealthStatus,
+			&animal.DateOfBirth, &animal.Sex, &sireID, &damID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan offspring: %v", err)
+		}
+		if sireID.Valid {
+			animal.SireID = &sireID.UUID
+		}
+		if damID.Valid {
+			animal.DamID = &damID.UUID
+		}
+		offspring = append(offspring, animal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return offspring, nil
+}
+
+func (r *BreedingRepository) GetFarmIDByEventID(id uuid.UUID) (uuid.UUID, error) {
+	query := `
+	SELECT a.farm_id
+	FROM breeding_events e
+	INNER JOIN animals a ON e.dam_id = a.id
+	WHERE e.id = $1 AND a.deleted_at IS NULL
+	`
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrBreedingEventNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+// DeleteEvent deletes a breeding event whose birth was not recorded yet.
+func (r *BreedingRepository) DeleteEvent(id uuid.UUID, actor *models.AuditActor) error {
+	return auditedChange(r.db, actor, models.AuditDelete, models.AuditBreedingEvent, id, func(tx *sql.Tx) error {
+		if _, _, err := lockOpenBreedingEvent(tx, id); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`DELETE FROM breeding_events WHERE id = $1`, id); err != nil {
+			return fmt.Errorf("failed to delete breeding event: %v", err)
+		}
+		return nil
+	})
+}
+
+// lockOpenBreedingEvent locks the event for the rest of the transaction. It
+// fails with ErrBreedingDelivered once the birth was recorded.
+func lockOpenBreedingEvent(tx *sql.Tx, id uuid.UUID) (damID uuid.UUID, sireID uuid.NullUUID, err error) {
+	var status string
+	query := `SELECT dam_id, sire_id, status FROM breeding_events WHERE id = $1 FOR UPDATE`
+	if err = tx.QueryRow(query, id).Scan(&damID, &sireID, &status); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, sireID, ErrBreedingEventNotFound
+		}
+		return uuid.Nil, sireID, fmt.Errorf("failed to get breeding event: %v", err)
+	}
+	if status == models.BreedingDelivered {
+		return uuid.Nil, sireID, ErrBreedingDelivered
+	}
+	return damID, sireID, nil
+}
+
+// AddPregnancyCheck stores the check and sets the status of the breeding
+// event from the latest check.
+func (r *BreedingRepository) AddPregnancyCheck(check *models.PregnancyCheck, userID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if _, _, err = lockOpenBreedingEvent(tx, check.BreedingEventID); err != nil {
+		return err
+	}
+	before, err := lockSnapshot(tx, models.AuditBreedingEvent, check.BreedingEventID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	INSERT INTO pregnancy_checks (id, breeding_event_id, checked_at, result, notes, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING created_at
+	`
+	err = tx.QueryRow(query, check.ID, check.BreedingEventID, check.CheckedAt, check.Result, check.Notes, userID).Scan(&check.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create pregnancy check: %v", err)
+	}
+	if err = audit(tx, actor, models.AuditCreate, models.AuditPregnancyCheck, check.ID, nil); err != nil {
+		return err
+	}
+
+	query = `
+	UPDATE breeding_events
+	SET status = CASE (
+	  SELECT result FROM pregnancy_checks WHERE breeding_event_id = $1 ORDER BY checked_at DESC, created_at DESC LIMIT 1
+	) WHEN 'pregnant' THEN 'pregnant' ELSE 'open' END
+	WHERE id = $1
+	`
+	if _, err = tx.Exec(query, check.BreedingEventID); err != nil {
+		return fmt.Errorf("failed to update breeding event: %v", err)
+	}
+	return audit(tx, actor, models.AuditUpdate, models.AuditBreedingEvent, check.BreedingEventID, before)
+}
+
+// RecordBirth stores the birth, adds every live offspring to the farm linked
+// to the sire and dam of the breeding event, and closes the event.
+func (r *BreedingRepository) RecordBirth(birth *models.Birth, userID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	damID, sireID, err := lockOpenBreedingEvent(tx, birth.BreedingEventID)
+	if err != nil {
+		return err
+	}
+	before, err := lockSnapshot(tx, models.AuditBreedingEvent, birth.BreedingEventID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	INSERT INTO births (id, breeding_event_id, born_at, live_count, stillborn_count, notes, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING created_at
+	`
+	err = tx.QueryRow(query, birth.ID, birth.BreedingEventID, birth.BornAt, len(birth.Offspring), birth.StillbornCount,
+		birth.Notes, userID).Scan(&birth.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create birth: %v", err)
+	}
+	birth.LiveCount = len(birth.Offspring)
+	if err = audit(tx, actor, models.AuditCreate, models.AuditBirth, birth.ID, nil); err != nil {
+		return err
+	}
+
+	for i := range birth.Offspring {
+		animal := &birth.Offspring[i]
+		animal.DamID = &damID
+		if sireID.Valid {
+			animal.SireID = &sireID.UUID
+		}
+		if err = insertAnimal(tx, actor, animal, &birth.ID); err != nil {
+			return err
+		}
+	}
+
+	if _, err = tx.Exec(`UPDATE breeding_events SET status = 'delivered' WHERE id = $1`, birth.BreedingEventID); err != nil {
+		return fmt.Errorf("failed to update breeding event: %v", err)
+	}
+	return audit(tx, actor, models.AuditUpdate, models.AuditBreedingEvent, birth.BreedingEventID, before)
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"farmish/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type FarmMemberRepository struct {
+	DB *sql.DB
+}
+
+func NewFarmMemberRepository(db *sql.DB) *FarmMemberRepository {
+	return &FarmMemberRepository{DB: db}
+}
+
+var (
+	ErrMemberNotFound     = errors.New("farm member not found")
+	ErrInvitationNotFound = errors.New("invitation not found")
+)
+
+// GetMemberRole returns the role of the user on the farm, or an empty string
+// when the user is not a member.
+func (r *FarmMemberRepository) GetMemberRole(farmID, userID uuid.UUID) (string, error) {
+	query := `SELECT role FROM farm_members WHERE farm_id = $1 AND user_id = $2`
+
+	var role string
+	if err := r.DB.QueryRow(query, farmID, userID).Scan(&role); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get member role: %v", err)
+	}
+
+	return role, nil
+}
+
+func (r *FarmMemberRepository) GetMember(farmID, userID uuid.UUID) (*models.FarmMember, error) {
+	query := `
+        SELECT fm.farm_id, fm.user_id, u.name, u.email, fm.role, fm.created_at
+        FROM farm_members fm
+        INNER JOIN users u ON fm.user_id = u.id
+        WHERE fm.farm_id = $1 AND fm.user_id = $2
+    `
+
+	var member models.FarmMember
+	err := r.DB.QueryRow(query, farmID, userID).Scan(&member.FarmID, &member.UserID, &member.Name, &member.Email, &member.Role, &member.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get farm member: %v", err)
+	}
+
+	return &member, nil
+}
+
+// memberSortColumns maps the fields farm members can be listed by to their
+// column. Names and emails come from the users table.
+var memberSortColumns = map[string]string{
+	"name":       "u.name",
+	"email":      "u.email",
+	"role":       "fm.role",
+	"created_at": "fm.created_at",
+}
+
+// GetMembersByFarmID returns a page of the members of the farm, together with
+// their total number.
+func (r *FarmMemberRepository) GetMembersByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.FarmMember, int, error) {
+	q := newListQuery(`fm.farm_id, fm.user_id, u.name, u.email, fm.role, fm.created_at`, `farm_members fm
+        INNER JOIN users u ON fm.user_id = u.id`)
+	q.filter(`fm.farm_id = ?`, farmID)
+
+	members, total, err := list(r.DB, q, page, memberSortColumns, "fm.created_at", "fm.user_id",
+		func(rows *sql.Rows) (models.FarmMember, error) {
+			var member models.FarmMember
+			if err := rows.Scan(&member.FarmID, &member.UserID, &member.Name, &member.Email, &member.Role, &member.CreatedAt); err != nil {
+				return member, fmt.Errorf("failed to scan farm member: %v", err)
+			}
+			return member, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve farm members: %v", err)
+	}
+	return members, total, nil
+}
+
+func (r *FarmMemberRepository) UpdateMemberRole(farmID, userID uuid.UUID, role string, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockMemberSnapshot(tx, farmID, userID)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return ErrMemberNotFound
+	}
+
+	query := `UPDATE farm_members SET role = $1 WHERE farm_id = $2 AND user_id = $3`
+	if _, err = tx.Exec(query, role, farmID, userID); err != nil {
+		return fmt.Errorf("failed to update member role: %v", err)
+	}
+
+	return auditMember(tx, actor, models.AuditUpdate, farmID, userID, before)
+}
+
+func (r *FarmMemberRepository) RemoveMember(farmID, userID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockMemberSnapshot(tx, farmID, userID)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		return ErrMemberNotFound
+	}
+
+	query := `DELETE FROM farm_members WHERE farm_id = $1 AND user_id = $2`
+	if _, err = tx.Exec(query, farmID, userID); err != nil {
+		return fmt.Errorf("failed to remove farm member: %v", err)
+	}
+
+	return auditMember(tx, actor, models.AuditDelete, farmID, userID, before)
+}
+
+func (r *FarmMemberRepository) CreateInvitation(invitation *models.FarmInvitation, actor *models.AuditActor) error {
+	query := `
+        INSERT INTO farm_invitations (id, farm_id, email, role, invited_by, status)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING created_at
+    `
+	err := auditedChange(r.DB, actor, models.AuditCreate, models.AuditFarmInvitation, invitation.ID, func(tx *sql.Tx) error {
+		return tx.QueryRow(query, invitation.ID, invitation.FarmID, invitation.Email, invitation.Role,
+			invitation.InvitedBy, invitation.Status).Scan(&invitation.CreatedAt)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create invitation: %v", err)
+	}
+	return nil
+}
+
+const invitationColumns = `
+        i.id, i.farm_id, f.name, i.email, i.role, i.invited_by, i.status, i.created_at, i.responded_at
+    `
+
+func scanInvitation(scanner interface{ Scan(...interface{}) error }) (*models.FarmInvitation, error) {
+	var invitation models.FarmInvitation
+	var invitedBy uuid.NullUUID
+	var respondedAt sql.NullTime
+	err := scanner.Scan(&invitation.ID, &invitation.FarmID, &invitation.FarmName, &invitation.Email, &invitation.Role,
+		&invitedBy, &invitation.Status, &invitation.CreatedAt, &respondedAt)
+	if err != nil {
+		return nil, err
+	}
+	invitation.InvitedBy = invitedBy.UUID
+	if respondedAt.Valid {
+		invitation.RespondedAt = &respondedAt.Time
+	}
+	return &invitation, nil
+}
+
+func (r *FarmMemberRepository) GetInvitationByID(invitationID uuid.UUID) (*models.FarmInvitation, error) {
+	query := `SELECT ` + invitationColumns + `
+        FROM farm_invitations i
+        INNER JOIN farms f ON i.farm_id = f.id AND f.deleted_at IS NULL
+        WHERE i.id = $1
+    `
+	invitation, err := scanInvitation(r.DB.QueryRow(query, invitationID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invitation: %v", err)
+	}
+	return invitation, nil
+}
+
+func (r *FarmMemberRepository) GetPendingInvitation(farmID uuid.UUID, email string) (*models.FarmInvitation, error) {
+	query := `SELECT ` + invitationColumns + `
+        FROM farm_invitations i
+        INNER JOIN farms f ON i.farm_id = f.id AND f.deleted_at IS NULL
+        WHERE i.farm_id = $1 AND LOWER(i.email) = LOWER($2) AND i.status = 'pending'
+    `
+	invitation, err := scanInvitation(r.DB.QueryRow(query, farmID, email))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get invitation: %v", err)
+	}
+	return invitation, nil
+}
+
+const invitationTables = `farm_invitations i
+        INNER JOIN farms f ON i.farm_id = f.id AND f.deleted_at IS NULL`
+
+func (r *FarmMemberRepository) listInvitations(q *listQuery, page *models.PageQuery) ([]models.FarmInvitation, int, error) {
+	invitations, total, err := list(r.DB, q, page, sortColumns("i", models.FarmInvitationSortFields), "i.created_at DESC", "i.id",
+		func(rows *sql.Rows) (models.FarmInvitation, error) {
+			invitation, err := scanInvitation(rows)
+			if err != nil {
+				return models.FarmInvitation{}, fmt.Errorf("failed to scan invitation: %v", err)
+			}
+			return *invitation, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve invitations: %v", err)
+	}
+	return invitations, total, nil
+}
+
+// GetInvitationsByFarmID returns a page of the invitations sent for the farm,
+// newest first unless sorted otherwise, together with their total number.
+func (r *FarmMemberRepository) GetInvitationsByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.FarmInvitation, int, error) {
+	q := newListQuery(invitationColumns, invitationTables)
+	q.filter(`i.farm_id = ?`, farmID)
+	return r.listInvitations(q, page)
+}
+
+// GetPendingInvitationsByEmail returns a page of the pending invitations sent
+// to the email, newest first unless sorted otherwise, together with their
+// total number.
+func (r *FarmMemberRepository) GetPendingInvitationsByEmail(email string, page *models.PageQuery) ([]models.FarmInvitation, int, error) {
+	q := newListQuery(invitationColumns, invitationTables)
+	q.filter(`LOWER(i.email) = LOWER(?)`, email)
+	q.filter(`i.status = 'pending'`)
+	return r.listInvitations(q, page)
+}
+
+// SetInvitationStatus moves a pending invitation to its final status.
+func (r *FarmMemberRepository) SetInvitationStatus(invitationID uuid.UUID, status string, actor *models.AuditActor) error {
+	query := `
+        UPDATE farm_invitations
+        SET status = $1, responded_at = CURRENT_TIMESTAMP
+        WHERE id = $2 AND status = 'pending'
+    `
+	return auditedChange(r.DB, actor, models.AuditUpdate, models.AuditFarmInvitation, invitationID, func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, status, invitationID)
+		if err != nil {
+			return fmt.Errorf("failed to update invitation: %v", err)
+		}
+		return requireRow(result, ErrInvitationNotFound)
+	})
+}
+
+// AcceptInvitation marks the invitation as accepted and adds the user to the
+// farm with the invited role in a single transaction.
+func (r *FarmMemberRepository) AcceptInvitation(invitation *models.FarmInvitation, userID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditFarmInvitation, invitation.ID)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `
+        UPDATE farm_invitations
+        SET status = 'accepted', responded_at = CURRENT_TIMESTAMP
+        WHERE id = $1 AND status = 'pending'
+    `
+	result, err := tx.Exec(updateQuery, invitation.ID)
+	if err != nil {
+		return fmt.Errorf("failed to accept invitation: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrInvitationNotFound
+	}
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditFarmInvitation, invitation.ID, before); err != nil {
+		return err
+	}
+
+	insertQuery := `
+        INSERT INTO farm_members (farm_id, user_id, role)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (farm_id, user_id) DO NOTHING
+    `
+	result, err = tx.Exec(insertQuery, invitation.FarmID, userID, invitation.Role)
+	if err != nil {
+		return fmt.Errorf("failed to add farm member: %v", err)
+	}
+
+	if rowsAffected, err = result.RowsAffected(); err != nil || rowsAffected == 0 {
+		return err
+	}
+	return auditMember(tx, actor, models.AuditCreate, invitation.FarmID, userID, nil)
+}
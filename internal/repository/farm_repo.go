@@ -17,20 +17,45 @@ func NewFarmRepository(db *sql.DB) *FarmRepository {
 	return &FarmRepository{DB: db}
 }
 
-func (r *FarmRepository) CreateFarm(farm *models.Farm) error {
+func (r *FarmRepository) CreateFarm(farm *models.Farm, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
 	query := `
         INSERT INTO farms (id, name, location, owner_id)
         VALUES ($1, $2, $3, $4)
     `
-	_, err := r.DB.Exec(query, farm.ID, farm.Name, farm.Location, farm.OwnerID)
+	_, err = tx.Exec(query, farm.ID, farm.Name, farm.Location, farm.OwnerID)
 	if err != nil {
 		return fmt.Errorf("failed to create farm: %v", err)
 	}
-	return nil
+	if err = audit(tx, actor, models.AuditCreate, models.AuditFarm, farm.ID, nil); err != nil {
+		return err
+	}
+
+	memberQuery := `
+        INSERT INTO farm_members (farm_id, user_id, role)
+        VALUES ($1, $2, 'owner')
+    `
+	_, err = tx.Exec(memberQuery, farm.ID, farm.OwnerID)
+	if err != nil {
+		return fmt.Errorf("failed to add farm owner: %v", err)
+	}
+	return auditMember(tx, actor, models.AuditCreate, farm.ID, farm.OwnerID, nil)
 }
 
 func (r *FarmRepository) GetFarmByID(farmID uuid.UUID) (*models.Farm, error) {
-	query := `SELECT id, name, location, owner_id, created_at FROM farms WHERE id = $1`
+	query := `SELECT id, name, location, owner_id, created_at FROM farms WHERE id = $1 AND deleted_at IS NULL`
 	row := r.DB.QueryRow(query, farmID)
 
 	var farm models.Farm
@@ -44,9 +69,34 @@ func (r *FarmRepository) GetFarmByID(farmID uuid.UUID) (*models.Farm, error) {
 	return &farm, nil
 }
 
-func (r *FarmRepository) GetAllFarms() ([]models.Farm, error) {
-	query := `SELECT id, name, location, owner_id, created_at FROM farms`
-	rows, err := r.DB.Query(query)
+// GetAllFarms returns a page of the farms the user owns or has joined as
+// staff, together with their total number.
+func (r *FarmRepository) GetAllFarms(userID uuid.UUID, page *models.PageQuery) ([]models.Farm, int, error) {
+	q := newListQuery(`f.id, f.name, f.location, f.owner_id, f.created_at`, `farms f`)
+	q.filter(`f.deleted_at IS NULL`)
+	q.filter(`(f.owner_id = ? OR f.id IN (SELECT farm_id FROM farm_members WHERE user_id = ?))`, userID, userID)
+
+	farms, total, err := list(r.DB, q, page, sortColumns("f", models.FarmSortFields), "f.name", "f.id",
+		func(rows *sql.Rows) (models.Farm, error) {
+			var farm models.Farm
+			if err := rows.Scan(&farm.ID, &farm.Name, &farm.Location, &farm.OwnerID, &farm.CreatedAt); err != nil {
+				return farm, fmt.Errorf("failed to scan farm: %v", err)
+			}
+			return farm, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve farms: %v", err)
+	}
+	return farms, total, nil
+}
+
+// GetFarmsByMemberID returns the farms the user owns or has joined as staff.
+func (r *FarmRepository) GetFarmsByMemberID(userID uuid.UUID) ([]models.Farm, error) {
+	query := `
+        SELECT id, name, location, owner_id, created_at FROM farms
+        WHERE deleted_at IS NULL AND (owner_id = $1 OR id IN (SELECT farm_id FROM farm_members WHERE user_id = $1))
+    `
+	rows, err := r.DB.Query(query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve farms: %v", err)
 	}
@@ -68,24 +118,90 @@ func (r *FarmRepository) GetAllFarms() ([]models.Farm, error) {
 	return farms, nil
 }
 
-func (r *FarmRepository) UpdateFarm(farm *models.UpdateFarmRequest) error {
+// UpdateFarm updates the farm and, when the owner changes, hands the owner role
+// to the new owner and demotes the previous one to manager.
+func (r *FarmRepository) UpdateFarm(farm *models.UpdateFarmRequest, previousOwnerID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditFarm, farm.ID)
+	if err != nil {
+		return err
+	}
+
 	query := `
         UPDATE farms
         SET name = $1, location = $2, owner_id = $3
         WHERE id = $4
     `
-	_, err := r.DB.Exec(query, farm.Name, farm.Location, farm.OwnerID, farm.ID)
+	_, err = tx.Exec(query, farm.Name, farm.Location, farm.OwnerID, farm.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update farm: %v", err)
 	}
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditFarm, farm.ID, before); err != nil {
+		return err
+	}
+
+	if farm.OwnerID == previousOwnerID {
+		return nil
+	}
+
+	if err = setMemberRole(tx, actor, farm.ID, previousOwnerID, models.RoleManager); err != nil {
+		return fmt.Errorf("failed to update previous owner role: %v", err)
+	}
+	if err = setMemberRole(tx, actor, farm.ID, farm.OwnerID, models.RoleOwner); err != nil {
+		return fmt.Errorf("failed to update new owner role: %v", err)
+	}
 	return nil
 }
 
-func (r *FarmRepository) DeleteFarm(farmID uuid.UUID) error {
-	query := `DELETE FROM farms WHERE id = $1`
-	_, err := r.DB.Exec(query, farmID)
+// setMemberRole changes the role of the member on the farm.
+func setMemberRole(tx *sql.Tx, actor *models.AuditActor, farmID, userID uuid.UUID, role string) error {
+	before, err := lockMemberSnapshot(tx, farmID, userID)
 	if err != nil {
-		return fmt.Errorf("failed to delete farm: %v", err)
+		return err
 	}
-	return nil
+	query := `UPDATE farm_members SET role = $1 WHERE farm_id = $2 AND user_id = $3`
+	if _, err = tx.Exec(query, role, farmID, userID); err != nil {
+		return err
+	}
+	return auditMember(tx, actor, models.AuditUpdate, farmID, userID, before)
+}
+
+// DeleteFarm moves the farm to the trash together with its animals, stock and
+// records. Restoring the farm brings them back.
+func (r *FarmRepository) DeleteFarm(farmID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditFarm, farmID)
+	if err != nil {
+		return err
+	}
+
+	if err = trashFarm(tx, farmID); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditDelete, models.AuditFarm, farmID, before)
 }
@@ -4,8 +4,10 @@ import (
 	"database/sql"
 	"errors"
 	"farmish/internal/models"
+	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type FeedingRecordRepository struct {
@@ -18,10 +20,14 @@ func NewFeedingRecordRepository(db *sql.DB) *FeedingRecordRepository {
 
 var ErrRecordNotFound = errors.New("feeding record not found")
 
-func (r *FeedingRecordRepository) CreateFeedingRecord(record *models.FeedingRecordWithoutTime, newFoodQuantity float64) error {
+// CreateFeedingRecord books the eaten food as a consumption and stores the
+// record. The stock is decremented atomically, so concurrent feedings can
+// neither lose updates nor overdraw the food; ErrInsufficientQuantity is
+// returned when not enough is left.
+func (r *FeedingRecordRepository) CreateFeedingRecord(record *models.FeedingRecordWithoutTime, actor *models.AuditActor) (movement *models.StockMovement, err error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer func() {
@@ -32,35 +38,50 @@ func (r *FeedingRecordRepository) CreateFeedingRecord(record *models.FeedingReco
 		}
 	}()
 
-	updateQuery := `
-		UPDATE foods
-		SET quantity = $1
-		WHERE id = $2
-	`
-	_, err = tx.Exec(updateQuery, newFoodQuantity, record.FoodID)
-	if err != nil {
-		return err
+	return insertFeedingRecord(tx, actor, record)
+}
+
+func insertFeedingRecord(tx *sql.Tx, actor *models.AuditActor, record *models.FeedingRecordWithoutTime) (*models.StockMovement, error) {
+	movement := &models.StockMovement{
+		ItemType:     models.StockItemFood,
+		ItemID:       record.FoodID,
+		MovementType: models.MovementConsumption,
+		Quantity:     -record.Quantity,
+		ReferenceID:  &record.ID,
+		Notes:        "feeding record",
+	}
+	if err := applyStockMovement(tx, actor, movement); err != nil {
+		return nil, err
 	}
 
 	insertQuery := `
-		INSERT INTO feeding_records (id, animal_id, food_id, quantity, fed_at, notes)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO feeding_records (id, farm_id, animal_id, food_id, quantity, fed_at, notes, recorded_by)
+		VALUES ($1, (SELECT farm_id FROM animals WHERE id = $2), $2, $3, $4, $5, $6, $7)
 	`
-	_, err = tx.Exec(insertQuery, record.ID, record.AnimalID, record.FoodID, record.Quantity, record.FedAt, record.Notes)
+	_, err := tx.Exec(insertQuery, record.ID, record.AnimalID, record.FoodID, record.Quantity, record.FedAt, record.Notes,
+		nullUUID(record.RecordedBy))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	if err := syncLastFed(tx, record.AnimalID); err != nil {
+		return nil, err
+	}
+
+	if err := audit(tx, actor, models.AuditCreate, models.AuditFeedingRecord, record.ID, nil); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
 }
 
-func (r *FeedingRecordRepository) GetFeedingRecordByID(id uuid.UUID) (*models.FeedingRecordDetailed, error) {
-	query := `
-	SELECT 
+const (
+	feedingRecordColumns = `
 	  fr.id AS feeding_record_id, 
 	  fr.quantity, 
 	  fr.fed_at, 
 	  fr.notes, 
+	  fr.recorded_by, 
 	  fr.created_at, 
 	  a.id AS animal_id, 
 	  a.name AS animal_name, 
@@ -70,19 +91,20 @@ func (r *FeedingRecordRepository) GetFeedingRecordByID(id uuid.UUID) (*models.Fe
 	  f.id AS food_id, 
 	  f.name AS food_name, 
 	  f.suitable_for AS food_suitable_for, 
-	  f.unit_of_measure AS food_unit_of_measure
-	FROM feeding_records fr
+	  f.unit_of_measure AS food_unit_of_measure`
+	feedingRecordTables = `feeding_records fr
 	INNER JOIN animals a ON fr.animal_id = a.id
-	INNER JOIN foods f ON fr.food_id = f.id
-	WHERE fr.id = $1;
-	`
+	INNER JOIN foods f ON fr.food_id = f.id`
+)
 
-	var detailedRecord models.FeedingRecordDetailed
-	err := r.db.QueryRow(query, id).Scan(
+func scanFeedingRecord(row interface{ Scan(...interface{}) error }, detailedRecord *models.FeedingRecordDetailed) error {
+	var recordedBy uuid.NullUUID
+	err := row.Scan(
 		&detailedRecord.FeedingRecordID,
 		&detailedRecord.Quantity,
 		&detailedRecord.FedAt,
 		&detailedRecord.Notes,
+		&recordedBy,
 		&detailedRecord.CreatedAt,
 		&detailedRecord.Animal.ID,
 		&detailedRecord.Animal.Name,
@@ -91,11 +113,23 @@ func (r *FeedingRecordRepository) GetFeedingRecordByID(id uuid.UUID) (*models.Fe
 		&detailedRecord.Animal.HealthStatus,
 		&detailedRecord.Food.ID,
 		&detailedRecord.Food.Name,
-		&detailedRecord.Food.SuitableFor,
+		pq.Array(&detailedRecord.Food.SuitableFor),
 		&detailedRecord.Food.UnitOfMeasure,
 	)
-
 	if err != nil {
+		return err
+	}
+	if recordedBy.Valid {
+		detailedRecord.RecordedBy = &recordedBy.UUID
+	}
+	return nil
+}
+
+func (r *FeedingRecordRepository) GetFeedingRecordByID(id uuid.UUID) (*models.FeedingRecordDetailed, error) {
+	query := `SELECT ` + feedingRecordColumns + ` FROM ` + feedingRecordTables + ` WHERE fr.id = $1 AND fr.deleted_at IS NULL`
+
+	var detailedRecord models.FeedingRecordDetailed
+	if err := scanFeedingRecord(r.db.QueryRow(query, id), &detailedRecord); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -105,101 +139,170 @@ func (r *FeedingRecordRepository) GetFeedingRecordByID(id uuid.UUID) (*models.Fe
 	return &detailedRecord, nil
 }
 
-func (r *FeedingRecordRepository) GetFeedingRecordsByAnimalID(animalID uuid.UUID) ([]models.FeedingRecordDetailed, error) {
+func (r *FeedingRecordRepository) GetFarmIDByRecordID(id uuid.UUID) (uuid.UUID, error) {
 	query := `
-	SELECT 
-	  fr.id AS feeding_record_id, 
-	  fr.quantity, 
-	  fr.fed_at, 
-	  fr.notes, 
-	  fr.created_at, 
-	  a.id AS animal_id, 
-	  a.name AS animal_name, 
-	  a.type AS animal_type, 
-	  a.weight AS animal_weight, 
-	  a.health_status AS animal_health_status, 
-	  f.id AS food_id, 
-	  f.name AS food_name, 
-	  f.suitable_for AS food_suitable_for, 
-	  f.unit_of_measure AS food_unit_of_measure
+	SELECT COALESCE(fr.farm_id, a.farm_id)
 	FROM feeding_records fr
-	INNER JOIN animals a ON fr.animal_id = a.id
-	INNER JOIN foods f ON fr.food_id = f.id
-	WHERE fr.animal_id = $1;
+	LEFT JOIN animals a ON fr.animal_id = a.id
+	WHERE fr.id = $1 AND fr.deleted_at IS NULL
 	`
 
-	rows, err := r.db.Query(query, animalID)
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrRecordNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	return farmID, nil
+}
+
+// GetFeedingRecords returns a page of the feeding records that match the
+// filter, together with their total number.
+func (r *FeedingRecordRepository) GetFeedingRecords(filter *models.RecordFilter, page *models.PageQuery) ([]models.FeedingRecordDetailed, int, error) {
+	q := newListQuery(feedingRecordColumns, feedingRecordTables)
+	filterRecords(q, "fr", "fed_at", filter)
+	if filter.ItemID != uuid.Nil {
+		q.filter(`fr.food_id = ?`, filter.ItemID)
+	}
+
+	return list(r.db, q, page, sortColumns("fr", models.FeedingRecordSortFields), "fr.fed_at DESC", "fr.id",
+		func(rows *sql.Rows) (models.FeedingRecordDetailed, error) {
+			var detailedRecord models.FeedingRecordDetailed
+			err := scanFeedingRecord(rows, &detailedRecord)
+			return detailedRecord, err
+		})
+}
+
+// UpdateFeedingRecord updates the record and books the difference to the
+// previous quantity on the food. It returns the stock movement, or nil when
+// the quantity did not change.
+func (r *FeedingRecordRepository) UpdateFeedingRecord(record *models.FeedingRecordWithoutTime, actor *models.AuditActor) (movement *models.StockMovement, err error) {
+	tx, err := r.db.Begin()
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var records []models.FeedingRecordDetailed
-	for rows.Next() {
-		var detailedRecord models.FeedingRecordDetailed
-		err := rows.Scan(
-			&detailedRecord.FeedingRecordID,
-			&detailedRecord.Quantity,
-			&detailedRecord.FedAt,
-			&detailedRecord.Notes,
-			&detailedRecord.CreatedAt,
-			&detailedRecord.Animal.ID,
-			&detailedRecord.Animal.Name,
-			&detailedRecord.Animal.Type,
-			&detailedRecord.Animal.Weight,
-			&detailedRecord.Animal.HealthStatus,
-			&detailedRecord.Food.ID,
-			&detailedRecord.Food.Name,
-			&detailedRecord.Food.SuitableFor,
-			&detailedRecord.Food.UnitOfMeasure,
-		)
+
+	defer func() {
 		if err != nil {
-			return nil, err
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var previousQuantity float64
+	selectQuery := `SELECT animal_id, food_id, quantity FROM feeding_records WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	err = tx.QueryRow(selectQuery, record.ID).Scan(&record.AnimalID, &record.FoodID, &previousQuantity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrRecordNotFound
 		}
-		records = append(records, detailedRecord)
+		return nil, err
 	}
 
-	return records, nil
-}
+	before, err := lockSnapshot(tx, models.AuditFeedingRecord, record.ID)
+	if err != nil {
+		return nil, err
+	}
 
-func (r *FeedingRecordRepository) UpdateFeedingRecord(record *models.FeedingRecordWithoutTime) error {
 	query := `
 		UPDATE feeding_records
 		SET quantity = $1, fed_at = $2, notes = $3
 		WHERE id = $4
 	`
-	result, err := r.db.Exec(query, record.Quantity, record.FedAt, record.Notes, record.ID)
+	_, err = tx.Exec(query, record.Quantity, record.FedAt, record.Notes, record.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
+	if record.Quantity != previousQuantity {
+		movement = &models.StockMovement{
+			ItemType:     models.StockItemFood,
+			ItemID:       record.FoodID,
+			MovementType: models.MovementConsumption,
+			Quantity:     previousQuantity - record.Quantity,
+			ReferenceID:  &record.ID,
+			Notes:        "feeding record updated",
+		}
+		if err = applyRecordCorrection(tx, actor, movement); err != nil {
+			return nil, err
+		}
 	}
 
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
+	if err = syncLastFed(tx, record.AnimalID); err != nil {
+		return nil, err
 	}
 
-	return nil
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditFeedingRecord, record.ID, before); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
 }
 
-func (r *FeedingRecordRepository) DeleteFeedingRecord(id uuid.UUID) error {
-	query := `DELETE FROM feeding_records WHERE id = $1`
-	result, err := r.db.Exec(query, id)
+// DeleteFeedingRecord moves the record to the trash and returns its quantity
+// to the food stock.
+func (r *FeedingRecordRepository) DeleteFeedingRecord(id uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditFeedingRecord, id)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	var animalID, foodID uuid.UUID
+	var quantity float64
+	query := `UPDATE feeding_records SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL RETURNING animal_id, food_id, quantity`
+	err = tx.QueryRow(query, id).Scan(&animalID, &foodID, &quantity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrRecordNotFound
+		}
+		return err
+	}
+
+	err = applyRecordCorrection(tx, actor, &models.StockMovement{
+		ItemType:     models.StockItemFood,
+		ItemID:       foodID,
+		MovementType: models.MovementConsumption,
+		Quantity:     quantity,
+		ReferenceID:  &id,
+		Notes:        "feeding record deleted",
+	})
 	if err != nil {
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return ErrRecordNotFound
+	if err = syncLastFed(tx, animalID); err != nil {
+		return err
 	}
 
+	return audit(tx, actor, models.AuditDelete, models.AuditFeedingRecord, id, before)
+}
+
+// syncLastFed sets the animal's last_fed to its most recent feeding record.
+// The current value is kept when the animal has no feeding records left.
+func syncLastFed(tx *sql.Tx, animalID uuid.UUID) error {
+	query := `
+	UPDATE animals
+	SET last_fed = COALESCE((SELECT MAX(fed_at) FROM feeding_records WHERE animal_id = $1 AND deleted_at IS NULL), last_fed)
+	WHERE id = $1
+	`
+	if _, err := tx.Exec(query, animalID); err != nil {
+		return fmt.Errorf("failed to update last fed time: %v", err)
+	}
 	return nil
 }
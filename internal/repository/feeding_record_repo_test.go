@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"farmish/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestRecordsStayWithFarm transfers a fed animal to another farm. The feeding
+// record must still belong to the farm it was taken on, and must not be listed
+// for the animal on its new farm.
+func TestRecordsStayWithFarm(t *testing.T) {
+	db := openTestDB(t)
+
+	f := seedStock(t, db, 10)
+	repo := NewFeedingRecordRepository(db)
+
+	record := &models.FeedingRecordWithoutTime{ID: uuid.New()}
+	record.AnimalID = f.animalID
+	record.FoodID = f.foodID
+	record.Quantity = 1
+	record.FedAt = time.Now()
+	if _, err := repo.CreateFeedingRecord(record, testActor); err != nil {
+		t.Fatalf("failed to create feeding record: %v", err)
+	}
+
+	newFarmID := uuid.New()
+	mustExec(t, db, `INSERT INTO farms (id, name, location, owner_id) SELECT $1, 'Other farm', 'Elsewhere', owner_id FROM farms WHERE id = $2`,
+		newFarmID, f.farmID)
+	mustExec(t, db, `UPDATE animals SET farm_id = $1 WHERE id = $2`, newFarmID, f.animalID)
+
+	farmID, err := repo.GetFarmIDByRecordID(record.ID)
+	if err != nil {
+		t.Fatalf("failed to get farm of record: %v", err)
+	}
+	if farmID != f.farmID {
+		t.Errorf("record belongs to farm %v, want %v", farmID, f.farmID)
+	}
+
+	page := &models.PageQuery{Limit: 10}
+	_, total, err := repo.GetFeedingRecords(&models.RecordFilter{AnimalID: f.animalID}, page)
+	if err != nil {
+		t.Fatalf("failed to list records of animal: %v", err)
+	}
+	if total != 0 {
+		t.Errorf("%d records listed for the animal on its new farm, want 0", total)
+	}
+
+	_, total, err = repo.GetFeedingRecords(&models.RecordFilter{FarmID: f.farmID}, page)
+	if err != nil {
+		t.Fatalf("failed to list records of farm: %v", err)
+	}
+	if total != 1 {
+		t.Errorf("%d records listed for the old farm, want 1", total)
+	}
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type FeedingScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewFeedingScheduleRepository(db *sql.DB) *FeedingScheduleRepository {
+	return &FeedingScheduleRepository{db: db}
+}
+
+var (
+	ErrFeedingScheduleNotFound = errors.New("feeding schedule not found")
+	ErrFeedingTaskNotFound     = errors.New("feeding task not found")
+	ErrTaskNotPending          = errors.New("feeding task is no longer pending")
+)
+
+const feedingScheduleColumns = `id, farm_id, animal_id, animal_type, food_id, quantity, times_of_day, days_of_week, active, created_at`
+
+func scanFeedingSchedule(scanner interface{ Scan(...interface{}) error }) (*models.FeedingSchedule, error) {
+	var schedule models.FeedingSchedule
+	var animalID uuid.NullUUID
+	var animalType sql.NullString
+	err := scanner.Scan(&schedule.ID, &schedule.FarmID, &animalID, &animalType, &schedule.FoodID, &schedule.Quantity,
+		pq.Array(&schedule.TimesOfDay), pq.Array(&schedule.DaysOfWeek), &schedule.Active, &schedule.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if animalID.Valid {
+		schedule.AnimalID = &animalID.UUID
+	}
+	schedule.AnimalType = animalType.String
+	return &schedule, nil
+}
+
+func (r *FeedingScheduleRepository) CreateSchedule(schedule *models.FeedingSchedule, actor *models.AuditActor) error {
+	query := `
+	INSERT INTO feeding_schedules (id, farm_id, animal_id, animal_type, food_id, quantity, times_of_day, days_of_week, active)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	RETURNING created_at
+	`
+	err := auditedChange(r.db, actor, models.AuditCreate, models.AuditFeedingSchedule, schedule.ID, func(tx *sql.Tx) error {
+		return tx.QueryRow(query, schedule.ID, schedule.FarmID, nullUUID(schedule.AnimalID), sql.NullString{String: schedule.AnimalType, Valid: schedule.AnimalType != ""},
+			schedule.FoodID, schedule.Quantity, pq.Array(schedule.TimesOfDay), pq.Array(schedule.DaysOfWeek), schedule.Active).Scan(&schedule.CreatedAt)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create feeding schedule: %v", err)
+	}
+	return nil
+}
+
+func (r *FeedingScheduleRepository) GetScheduleByID(id uuid.UUID) (*models.FeedingSchedule, error) {
+	query := `SELECT ` + feedingScheduleColumns + ` FROM feeding_schedules WHERE id = $1`
+	schedule, err := scanFeedingSchedule(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get feeding schedule: %v", err)
+	}
+	return schedule, nil
+}
+
+// GetSchedulesByFarmID returns a page of the schedules of the farm, together
+// with their total number.
+func (r *FeedingScheduleRepository) GetSchedulesByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.FeedingSchedule, int, error) {
+	q := newListQuery(feedingScheduleColumns, `feeding_schedules fs`)
+	q.filter(`fs.farm_id = ?`, farmID)
+
+	schedules, total, err := list(r.db, q, page, sortColumns("fs", models.FeedingScheduleSortFields), "fs.created_at", "fs.id",
+		func(rows *sql.Rows) (models.FeedingSchedule, error) {
+			schedule, err := scanFeedingSchedule(rows)
+			if err != nil {
+				return models.FeedingSchedule{}, fmt.Errorf("failed to scan feeding schedule: %v", err)
+			}
+			return *schedule, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get feeding schedules: %v", err)
+	}
+	return schedules, total, nil
+}
+
+func (r *FeedingScheduleRepository) GetFarmIDByScheduleID(id uuid.UUID) (uuid.UUID, error) {
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(`SELECT farm_id FROM feeding_schedules WHERE id = $1`, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrFeedingScheduleNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+func (r *FeedingScheduleRepository) UpdateSchedule(id uuid.UUID, req *models.UpdateFeedingScheduleReq, actor *models.AuditActor) error {
+	query := `
+	UPDATE feeding_schedules
+	SET food_id = $1, quantity = $2, times_of_day = $3, days_of_week = $4, active = $5
+	WHERE id = $6
+	`
+	return auditedChange(r.db, actor, models.AuditUpdate, models.AuditFeedingSchedule, id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, req.FoodID, req.Quantity, pq.Array(req.TimesOfDay), pq.Array(req.DaysOfWeek), req.Active, id)
+		if err != nil {
+			return fmt.Errorf("failed to update feeding schedule: %v", err)
+		}
+		return requireRow(result, ErrFeedingScheduleNotFound)
+	})
+}
+
+func (r *FeedingScheduleRepository) DeleteSchedule(id uuid.UUID, actor *models.AuditActor) error {
+	return auditedChange(r.db, actor, models.AuditDelete, models.AuditFeedingSchedule, id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM feeding_schedules WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete feeding schedule: %v", err)
+		}
+		return requireRow(result, ErrFeedingScheduleNotFound)
+	})
+}
+
+// GetDueTasks expands the active schedules into the feeding tasks of active
+// animals due on the given day. The returned tasks have no ID yet.
+func (r *FeedingScheduleRepository) GetDueTasks(day time.Time) ([]models.FeedingTask, error) {
+	query := `
+	SELECT s.id, a.id, s.food_id, s.quantity, $1::date + t::time
+	FROM feeding_schedules s
+	INNER JOIN animals a ON a.id = s.animal_id
+		OR (s.animal_id IS NULL AND a.farm_id = s.farm_id AND a.type = s.animal_type)
+	INNER JOIN foods f ON f.id = s.food_id
+	CROSS JOIN LATERAL unnest(s.times_of_day) AS t
+	WHERE s.active AND a.status = 'active' AND a.deleted_at IS NULL AND f.deleted_at IS NULL
+	  AND (cardinality(s.days_of_week) = 0 OR EXTRACT(DOW FROM $1::date)::smallint = ANY(s.days_of_week))
+	`
+	rows, err := r.db.Query(query, day.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due feeding tasks: %v", err)
+	}
+	defer rows.Close()
+
+	var tasks []models.FeedingTask
+	for rows.Next() {
+		var task models.FeedingTask
+		if err := rows.Scan(&task.ScheduleID, &task.AnimalID, &task.FoodID, &task.Quantity, &task.DueAt); err != nil {
+			return nil, fmt.Errorf("failed to scan due feeding task: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return tasks, nil
+}
+
+// CreateTasks stores the tasks, skipping those already materialized. It
+// returns the number of new tasks.
+func (r *FeedingScheduleRepository) CreateTasks(tasks []models.FeedingTask, actor *models.AuditActor) (created int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+	INSERT INTO feeding_tasks (id, schedule_id, animal_id, food_id, quantity, due_at)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	ON CONFLICT (schedule_id, animal_id, due_at) DO NOTHING
+	`
+	for _, task := range tasks {
+		result, err := tx.Exec(query, task.ID, task.ScheduleID, task.AnimalID, task.FoodID, task.Quantity, task.DueAt)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create feeding task: %v", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if rowsAffected == 0 {
+			continue
+		}
+		if err = audit(tx, actor, models.AuditCreate, models.AuditFeedingTask, task.ID, nil); err != nil {
+			return 0, err
+		}
+		created++
+	}
+
+	return created, nil
+}
+
+// GetTasks returns a page of the tasks of the farm's animals that match the
+// filter, together with their total number.
+func (r *FeedingScheduleRepository) GetTasks(filter *models.FeedingTaskFilter, page *models.PageQuery) ([]models.FeedingTask, int, error) {
+	q := newListQuery(`t.id, t.schedule_id, t.animal_id, COALESCE(a.name, ''), t.food_id, f.name, t.quantity, t.due_at, t.status,
+	  t.feeding_record_id, t.completed_by, t.completed_at, t.created_at`, `feeding_tasks t
+	INNER JOIN animals a ON t.animal_id = a.id
+	INNER JOIN foods f ON t.food_id = f.id`)
+	q.filter(`a.farm_id = ?`, filter.FarmID)
+	q.filter(`a.deleted_at IS NULL`)
+	if !filter.Date.IsZero() {
+		q.filter(`t.due_at::date = ?`, filter.Date.Format("2006-01-02"))
+	}
+	if filter.Status != "" {
+		q.filter(`t.status = ?`, filter.Status)
+	}
+
+	tasks, total, err := list(r.db, q, page, sortColumns("t", models.FeedingTaskSortFields), "t.due_at", "t.id",
+		func(rows *sql.Rows) (models.FeedingTask, error) {
+			var task models.FeedingTask
+			var feedingRecordID, completedBy uuid.NullUUID
+			var completedAt sql.NullTime
+			err := rows.Scan(&task.ID, &task.ScheduleID, &task.AnimalID, &task.AnimalName, &task.FoodID, &task.FoodName,
+				&task.Quantity, &task.DueAt, &task.Status, &feedingRecordID, &completedBy, &completedAt, &task.CreatedAt)
+			if err != nil {
+				return task, fmt.Errorf("failed to scan feeding task: %v", err)
+			}
+			if feedingRecordID.Valid {
+				task.FeedingRecordID = &feedingRecordID.UUID
+			}
+			if completedBy.Valid {
+				task.CompletedBy = &completedBy.UUID
+			}
+			if completedAt.Valid {
+				task.CompletedAt = &completedAt.Time
+			}
+			return task, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get feeding tasks: %v", err)
+	}
+	return tasks, total, nil
+}
+
+func (r *FeedingScheduleRepository) GetFarmIDByTaskID(id uuid.UUID) (uuid.UUID, error) {
+	query := `
+	SELECT a.farm_id
+	FROM feeding_tasks t
+	INNER JOIN animals a ON t.animal_id = a.id
+	WHERE t.id = $1 AND a.deleted_at IS NULL
+	`
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrFeedingTaskNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+// lockPendingTask locks the task row for the rest of the transaction and
+// returns its state before the change. It fails with ErrTaskNotPending when
+// the task was already completed or skipped.
+func lockPendingTask(tx *sql.Tx, taskID uuid.UUID, task *models.FeedingTask) (*models.AuditSnapshot, error) {
+	query := `SELECT animal_id, food_id, quantity, due_at, status FROM feeding_tasks WHERE id = $1 FOR UPDATE`
+	err := tx.QueryRow(query, taskID).Scan(&task.AnimalID, &task.FoodID, &task.Quantity, &task.DueAt, &task.Status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrFeedingTaskNotFound
+		}
+		return nil, err
+	}
+	if task.Status != models.TaskPending {
+		return nil, ErrTaskNotPending
+	}
+	return lockSnapshot(tx, models.AuditFeedingTask, taskID)
+}
+
+// CompleteTask creates the feeding record of a pending task, including the
+// stock decrement, and marks the task completed in a single transaction. The
+// planned quantity is used unless req overrides it; the record is fed now
+// unless req.FedAt is set.
+func (r *FeedingScheduleRepository) CompleteTask(taskID, recordID, userID uuid.UUID,
+	req *models.CompleteFeedingTaskReq, actor *models.AuditActor) (movement *models.StockMovement, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var task models.FeedingTask
+	before, err := lockPendingTask(tx, taskID, &task)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.FeedingRecordWithoutTime{ID: recordID, RecordedBy: &userID}
+	record.AnimalID = task.AnimalID
+	record.FoodID = task.FoodID
+	record.Quantity = task.Quantity
+	record.FedAt = time.Now()
+	record.Notes = req.Notes
+	if req.Quantity > 0 {
+		record.Quantity = req.Quantity
+	}
+	if req.FedAt != nil {
+		record.FedAt = *req.FedAt
+	}
+
+	if movement, err = insertFeedingRecord(tx, actor, record); err != nil {
+		return nil, err
+	}
+
+	query := `
+	UPDATE feeding_tasks
+	SET status = $1, feeding_record_id = $2, completed_by = $3, completed_at = CURRENT_TIMESTAMP
+	WHERE id = $4
+	`
+	if _, err = tx.Exec(query, models.TaskCompleted, record.ID, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditFeedingTask, taskID, before); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+func (r *FeedingScheduleRepository) SkipTask(taskID, userID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var task models.FeedingTask
+	before, err := lockPendingTask(tx, taskID, &task)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE feeding_tasks SET status = $1, completed_by = $2, completed_at = CURRENT_TIMESTAMP WHERE id = $3`
+	if _, err = tx.Exec(query, models.TaskSkipped, userID, taskID); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditUpdate, models.AuditFeedingTask, taskID, before)
+}
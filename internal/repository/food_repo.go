@@ -18,63 +18,94 @@ func NewFoodRepository(db *sql.DB) *FoodRepository {
 	return &FoodRepository{DB: db}
 }
 
-func (r *FoodRepository) CreateFood(food *models.FoodWithoutTime) error {
+// CreateFood stores the food with an empty stock and books its initial
+// quantity as a purchase so the ledger accounts for all of it.
+func (r *FoodRepository) CreateFood(food *models.FoodWithoutTime, createdBy uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
 	query := `
         INSERT INTO foods 
         (id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        VALUES ($1, $2, $3, $4, $5, 0, $6)
     `
-	_, err := r.DB.Exec(query, food.ID, food.FarmID, food.Name, pq.Array(food.SuitableFor), food.UnitOfMeasure, food.Quantity, food.MinThreshold)
+	_, err = tx.Exec(query, food.ID, food.FarmID, food.Name, pq.Array(food.SuitableFor), food.UnitOfMeasure, food.MinThreshold)
 	if err != nil {
 		return fmt.Errorf("failed to create warehouse food: %v", err)
 	}
-	return nil
+
+	if err = audit(tx, actor, models.AuditCreate, models.AuditFood, food.ID, nil); err != nil {
+		return err
+	}
+
+	return applyStockMovement(tx, actor, &models.StockMovement{
+		ItemType:     models.StockItemFood,
+		ItemID:       food.ID,
+		MovementType: models.MovementPurchase,
+		Quantity:     food.Quantity,
+		Notes:        "initial stock",
+		CreatedBy:    &createdBy,
+	})
 }
 
-func (r *FoodRepository) GetAllFoods(farmID uuid.UUID) ([]models.Food, error) {
-	query := `
-        SELECT id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold, created_at, updated_at
-        FROM foods
-        WHERE farm_id = $1
-    `
-	rows, err := r.DB.Query(query, farmID)
+// GetAllFoods returns a page of the foods of a farm that match the filter,
+// together with their total number.
+func (r *FoodRepository) GetAllFoods(filter *models.StockFilter, page *models.PageQuery) ([]models.Food, int, error) {
+	q := newListQuery(`id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold, created_at, updated_at`, `foods f`)
+	filterStock(q, filter)
+
+	foods, total, err := list(r.DB, q, page, sortColumns("f", models.StockSortFields), "f.name", "f.id",
+		func(rows *sql.Rows) (models.Food, error) {
+			var food models.Food
+			err := rows.Scan(
+				&food.ID,
+				&food.FarmID,
+				&food.Name,
+				pq.Array(&food.SuitableFor),
+				&food.UnitOfMeasure,
+				&food.Quantity,
+				&food.MinThreshold,
+				&food.CreatedAt,
+				&food.UpdatedAt,
+			)
+			if err != nil {
+				return food, fmt.Errorf("failed to scan food: %v", err)
+			}
+			return food, nil
+		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get foods: %v", err)
-	}
-	defer rows.Close()
-
-	var foods []models.Food
-	for rows.Next() {
-		var food models.Food
-		err := rows.Scan(
-			&food.ID,
-			&food.FarmID,
-			&food.Name,
-			pq.Array(&food.SuitableFor),
-			&food.UnitOfMeasure,
-			&food.Quantity,
-			&food.MinThreshold,
-			&food.CreatedAt,
-			&food.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan food: %v", err)
-		}
-		foods = append(foods, food)
+		return nil, 0, fmt.Errorf("failed to get foods: %v", err)
 	}
+	return foods, total, nil
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+// filterStock applies the filter to a list of the foods or medicines table.
+func filterStock(q *listQuery, filter *models.StockFilter) {
+	q.filter(`farm_id = ?`, filter.FarmID)
+	q.filter(`deleted_at IS NULL`)
+	if filter.SuitableFor != "" {
+		q.filter(`? = ANY(suitable_for)`, filter.SuitableFor)
+	}
+	if filter.BelowThreshold {
+		q.filter(`quantity < min_threshold`)
 	}
-
-	return foods, nil
 }
 
 func (r *FoodRepository) GetFoodByID(foodID uuid.UUID) (*models.Food, error) {
 	query := `
 	SELECT id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold, created_at, updated_at
 	FROM foods
-	WHERE id = $1
+	WHERE id = $1 AND deleted_at IS NULL
 `
 	row := r.DB.QueryRow(query, foodID)
 
@@ -90,22 +121,72 @@ func (r *FoodRepository) GetFoodByID(foodID uuid.UUID) (*models.Food, error) {
 	return &food, nil
 }
 
-func (r *FoodRepository) UpdateFood(food *models.UpdateFoodReq) error {
+// UpdateFood updates the food details. A changed quantity is booked as an
+// adjustment instead of being overwritten.
+func (r *FoodRepository) UpdateFood(food *models.UpdateFoodReq, updatedBy uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var currentQuantity float64
+	err = tx.QueryRow(`SELECT quantity FROM foods WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, food.ID).Scan(&currentQuantity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrStockItemNotFound
+		}
+		return fmt.Errorf("failed to get food: %v", err)
+	}
+
+	before, err := lockSnapshot(tx, models.AuditFood, food.ID)
+	if err != nil {
+		return err
+	}
+
 	query := `
         UPDATE foods
-        SET name = $1, suitable_for = $2, unit_of_measure = $3, quantity = $4, min_threshold = $5
-        WHERE id = $6
+        SET name = $1, suitable_for = $2, unit_of_measure = $3, min_threshold = $4
+        WHERE id = $5
     `
-	_, err := r.DB.Exec(query, food.Name, pq.Array(food.SuitableFor), food.UnitOfMeasure, food.Quantity, food.MinThreshold, food.ID)
+	_, err = tx.Exec(query, food.Name, pq.Array(food.SuitableFor), food.UnitOfMeasure, food.MinThreshold, food.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update food: %v", err)
 	}
-	return nil
+
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditFood, food.ID, before); err != nil {
+		return err
+	}
+
+	if food.Quantity == currentQuantity {
+		return nil
+	}
+
+	return applyStockMovement(tx, actor, &models.StockMovement{
+		ItemType:     models.StockItemFood,
+		ItemID:       food.ID,
+		MovementType: models.MovementAdjustment,
+		Quantity:     food.Quantity - currentQuantity,
+		Notes:        "quantity updated",
+		CreatedBy:    &updatedBy,
+	})
 }
 
-func (r *FoodRepository) DeleteFood(foodID uuid.UUID) error {
-	query := `DELETE FROM foods WHERE id = $1`
-	_, err := r.DB.Exec(query, foodID)
+// DeleteFood moves the food to the trash. Its stock and the feeding records
+// that used it are kept.
+func (r *FoodRepository) DeleteFood(foodID uuid.UUID, actor *models.AuditActor) error {
+	query := `UPDATE foods SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+	err := auditedChange(r.DB, actor, models.AuditDelete, models.AuditFood, foodID, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, foodID)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete food: %v", err)
 	}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"database/sql"
+	"farmish/internal/models"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// listQuery builds the query of a list endpoint from its columns and tables,
+// the filters asked for and the requested page. Filters use ? for their
+// arguments, which are numbered as the filters are added.
+type listQuery struct {
+	columns string
+	from    string
+	where   []string
+	args    []interface{}
+}
+
+func newListQuery(columns, from string) *listQuery {
+	return &listQuery{columns: columns, from: from}
+}
+
+// filter adds a condition every listed row must meet.
+func (q *listQuery) filter(condition string, args ...interface{}) {
+	for _, arg := range args {
+		q.args = append(q.args, arg)
+		condition = strings.Replace(condition, "?", "$"+strconv.Itoa(len(q.args)), 1)
+	}
+	q.where = append(q.where, condition)
+}
+
+func (q *listQuery) whereClause() string {
+	if len(q.where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.where, " AND ")
+}
+
+// count returns the number of rows on all pages.
+func (q *listQuery) count(db *sql.DB) (int, error) {
+	var total int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM `+q.from+q.whereClause(), q.args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count rows: %v", err)
+	}
+	return total, nil
+}
+
+// page runs the query for the requested page. sortColumns maps the fields the
+// list can be sorted by to their column; without a sort field the rows come in
+// the default order. The rows are ordered by tieBreaker last, so that pages
+// never overlap.
+func (q *listQuery) page(db *sql.DB, page *models.PageQuery, sortColumns map[string]string,
+	defaultOrder, tieBreaker string) (*sql.Rows, error) {
+	order := defaultOrder
+	if column, ok := sortColumns[page.Sort]; ok {
+		order = column
+		if page.Desc {
+			order += " DESC"
+		}
+	}
+
+	query := `SELECT ` + q.columns + ` FROM ` + q.from + q.whereClause() +
+		` ORDER BY ` + order + `, ` + tieBreaker +
+		` LIMIT $` + strconv.Itoa(len(q.args)+1) + ` OFFSET $` + strconv.Itoa(len(q.args)+2)
+
+	return db.Query(query, append(q.args, page.Limit, page.Offset)...)
+}
+
+// list returns the requested page of rows, scanned one by one, together with
+// the number of rows on all pages.
+func list[T any](db *sql.DB, q *listQuery, page *models.PageQuery, sortColumns map[string]string,
+	defaultOrder, tieBreaker string, scan func(rows *sql.Rows) (T, error)) ([]T, int, error) {
+	total, err := q.count(db)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := q.page(db, page, sortColumns, defaultOrder, tieBreaker)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve rows: %v", err)
+	}
+	defer rows.Close()
+
+	var items []T
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return items, total, nil
+}
+
+// sortColumns maps each sort field to the column of the same name in the
+// table with the given alias.
+func sortColumns(alias string, fields []string) map[string]string {
+	columns := make(map[string]string, len(fields))
+	for _, field := range fields {
+		columns[field] = alias + "." + field
+	}
+	return columns
+}
+
+// filterRecords applies the filter, except for its item, to a list of records
+// of the table with the given alias, whose dateColumn holds when each record
+// was taken. Records belong to the farm they were taken on, so the records an
+// animal got on a farm it was transferred from are not listed for it, unless
+// that farm was purged and they were detached from it. Filtering by animal type
+// needs the animals joined as a.
+func filterRecords(q *listQuery, alias, dateColumn string, filter *models.RecordFilter) {
+	q.filter(alias + `.deleted_at IS NULL`)
+	if filter.AnimalID != uuid.Nil {
+		q.filter(alias+`.animal_id = ?`, filter.AnimalID)
+	}
+	if filter.FarmID != uuid.Nil {
+		q.filter(alias+`.farm_id = ?`, filter.FarmID)
+	} else if filter.AnimalID != uuid.Nil {
+		q.filter(`(`+alias+`.farm_id IS NULL OR `+alias+`.farm_id = (SELECT farm_id FROM animals WHERE id = ?))`, filter.AnimalID)
+	}
+	if filter.AnimalType != "" {
+		q.filter(`a.type = ?`, filter.AnimalType)
+	}
+	if filter.RecordedBy != uuid.Nil {
+		q.filter(alias+`.recorded_by = ?`, filter.RecordedBy)
+	}
+	if !filter.From.IsZero() {
+		q.filter(alias+`.`+dateColumn+` >= ?`, filter.From)
+	}
+	if !filter.To.IsZero() {
+		q.filter(alias+`.`+dateColumn+` < ?`, filter.To)
+	}
+}
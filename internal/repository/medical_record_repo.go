@@ -5,8 +5,10 @@ import (
 	"errors"
 	"farmish/internal/models"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type MedicalRecordRepository struct {
@@ -19,10 +21,15 @@ func NewMedicalRecordRepository(db *sql.DB) *MedicalRecordRepository {
 
 var ErrMedicalRecordNotFound = errors.New("feeding record not found")
 
-func (r *MedicalRecordRepository) CreateMedicalRecord(record *models.MedicalRecordWithoutTime, newMedicineQuantity float64) error {
+// CreateMedicalRecord books the administered medicine as a consumption and
+// stores the record. The stock is decremented atomically, so concurrent
+// treatments can neither lose updates nor overdraw the medicine;
+// ErrInsufficientQuantity is returned when not enough is left. The quantity
+// is taken from the medicine's batches, first expiring first out.
+func (r *MedicalRecordRepository) CreateMedicalRecord(record *models.MedicalRecordWithoutTime, actor *models.AuditActor) (movement *models.StockMovement, err error) {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	defer func() {
@@ -33,57 +40,204 @@ func (r *MedicalRecordRepository) CreateMedicalRecord(record *models.MedicalReco
 		}
 	}()
 
-	updateQuery := `
-	UPDATE medicines
-	SET quantity = $1
-	WHERE id = $2
-`
-	_, err = tx.Exec(updateQuery, newMedicineQuantity, record.MedicineID)
-	if err != nil {
-		return err
+	return insertMedicalRecord(tx, actor, record)
+}
+
+func insertMedicalRecord(tx *sql.Tx, actor *models.AuditActor, record *models.MedicalRecordWithoutTime) (*models.StockMovement, error) {
+	movement := &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       record.MedicineID,
+		MovementType: models.MovementConsumption,
+		Quantity:     -record.Quantity,
+		ReferenceID:  &record.ID,
+		Notes:        "medical record",
+	}
+	if err := applyStockMovement(tx, actor, movement); err != nil {
+		return nil, err
 	}
 
 	insertQuery := `
-    INSERT INTO medical_records (id, animal_id, medicine_id, quantity, treatment_date, notes)
-    VALUES ($1, $2, $3, $4, $5, $6)
+    INSERT INTO medical_records (id, farm_id, animal_id, medicine_id, quantity, treatment_date, notes, recorded_by)
+    VALUES ($1, (SELECT farm_id FROM animals WHERE id = $2), $2, $3, $4, $5, $6, $7)
   `
-	_, err = tx.Exec(insertQuery, record.ID, record.AnimalID, record.MedicineID, record.Quantity, record.TreatmentDate, record.Notes)
+	_, err := tx.Exec(insertQuery, record.ID, record.AnimalID, record.MedicineID, record.Quantity, record.TreatmentDate, record.Notes,
+		nullUUID(record.RecordedBy))
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := setWithdrawalDates(tx, record.ID); err != nil {
+		return nil, err
+	}
+
+	if record.Batches, err = consumeBatches(tx, actor, record.ID, record.MedicineID, record.Quantity, record.TreatmentDate); err != nil {
+		return nil, err
+	}
+
+	if err = audit(tx, actor, models.AuditCreate, models.AuditMedicalRecord, record.ID, nil); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// setWithdrawalDates derives the end of each withdrawal period of a record
+// from its treatment date and the medicine's withdrawal days.
+func setWithdrawalDates(tx *sql.Tx, recordID uuid.UUID) error {
+	query := `
+    UPDATE medical_records mr
+    SET meat_withdrawal_until = CASE WHEN m.meat_withdrawal_days > 0 THEN mr.treatment_date::date + m.meat_withdrawal_days END,
+      milk_withdrawal_until = CASE WHEN m.milk_withdrawal_days > 0 THEN mr.treatment_date::date + m.milk_withdrawal_days END,
+      egg_withdrawal_until = CASE WHEN m.egg_withdrawal_days > 0 THEN mr.treatment_date::date + m.egg_withdrawal_days END
+    FROM medicines m
+    WHERE m.id = mr.medicine_id AND mr.id = $1
+  `
+	if _, err := tx.Exec(query, recordID); err != nil {
+		return fmt.Errorf("failed to set withdrawal dates: %v", err)
 	}
 	return nil
 }
 
-func (r *MedicalRecordRepository) GetMedicalRecordByID(recordID uuid.UUID) (*models.MedicalRecordDetailed, error) {
+func withdrawalDates(meatUntil, milkUntil, eggUntil sql.NullTime) models.WithdrawalDates {
+	var dates models.WithdrawalDates
+	if meatUntil.Valid {
+		dates.MeatWithdrawalUntil = &meatUntil.Time
+	}
+	if milkUntil.Valid {
+		dates.MilkWithdrawalUntil = &milkUntil.Time
+	}
+	if eggUntil.Valid {
+		dates.EggWithdrawalUntil = &eggUntil.Time
+	}
+	return dates
+}
+
+// GetWithdrawals returns the animals of the farm with at least one withdrawal
+// period still running on the given day, with the latest end per product.
+func (r *MedicalRecordRepository) GetWithdrawals(farmID uuid.UUID, day time.Time) ([]models.AnimalWithdrawal, error) {
 	query := `
-    SELECT
-      mr.id AS medical_record_id, 
-      mr.quantity, 
-	  mr.treatment_date, 
-	  mr.notes,
-	  mr.created_at,
-      a.id AS animal_id, 
-	  a.name AS animal_name, 
-	  a.type AS animal_type, 
-	  a.weight AS animal_weight, 
-	  a.health_status AS animal_health_status, 
-	  m.id AS medicine_id,
-	  m.name AS medicine_name,
-	  m.suitable_for AS medicine_suitable_for
-	  m.unit_of_measure AS medicine_unit_of_measure
+    SELECT a.id, COALESCE(a.name, ''), a.type,
+      MAX(mr.meat_withdrawal_until) FILTER (WHERE mr.meat_withdrawal_until > $2),
+      MAX(mr.milk_withdrawal_until) FILTER (WHERE mr.milk_withdrawal_until > $2),
+      MAX(mr.egg_withdrawal_until) FILTER (WHERE mr.egg_withdrawal_until > $2)
     FROM medical_records mr
     INNER JOIN animals a ON mr.animal_id = a.id
-    INNER JOIN medicines m ON mr.medicine_id = m.id
-    WHERE mr.id = $1
+    WHERE a.farm_id = $1 AND a.deleted_at IS NULL AND mr.deleted_at IS NULL
+      AND (mr.meat_withdrawal_until > $2 OR mr.milk_withdrawal_until > $2 OR mr.egg_withdrawal_until > $2)
+    GROUP BY a.id, a.name, a.type
+    ORDER BY a.name
   `
-	row := r.db.QueryRow(query, recordID)
+	rows, err := r.db.Query(query, farmID, day.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get withdrawals: %v", err)
+	}
+	defer rows.Close()
 
-	var record models.MedicalRecordDetailed
+	var withdrawals []models.AnimalWithdrawal
+	for rows.Next() {
+		var withdrawal models.AnimalWithdrawal
+		var meatUntil, milkUntil, eggUntil sql.NullTime
+		err := rows.Scan(&withdrawal.AnimalID, &withdrawal.AnimalName, &withdrawal.AnimalType, &meatUntil, &milkUntil, &eggUntil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan withdrawal: %v", err)
+		}
+		withdrawal.WithdrawalDates = withdrawalDates(meatUntil, milkUntil, eggUntil)
+		withdrawals = append(withdrawals, withdrawal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return withdrawals, nil
+}
+
+// GetWithdrawalEnd returns the day the animal's products of the given kind may
+// be used again, or nil when no withdrawal period is running on that day.
+func (r *MedicalRecordRepository) GetWithdrawalEnd(animalID uuid.UUID, product string, day time.Time) (*time.Time, error) {
+	column, ok := withdrawalColumns[product]
+	if !ok {
+		return nil, fmt.Errorf("unknown product %q", product)
+	}
+
+	query := `SELECT MAX(` + column + `) FROM medical_records WHERE animal_id = $1 AND deleted_at IS NULL AND ` + column + ` > $2`
+	var until sql.NullTime
+	if err := r.db.QueryRow(query, animalID, day.Format("2006-01-02")).Scan(&until); err != nil {
+		return nil, fmt.Errorf("failed to get withdrawal end: %v", err)
+	}
+	if !until.Valid {
+		return nil, nil
+	}
+	return &until.Time, nil
+}
+
+// GetGroupWithdrawalEnd returns how many active animals of the type on the
+// farm may not have their products of the given kind used on that day, and
+// the day the last of them is cleared.
+func (r *MedicalRecordRepository) GetGroupWithdrawalEnd(farmID uuid.UUID, animalType, product string, day time.Time) (int, *time.Time, error) {
+	column, ok := withdrawalColumns[product]
+	if !ok {
+		return 0, nil, fmt.Errorf("unknown product %q", product)
+	}
+
+	query := `
+    SELECT COUNT(DISTINCT a.id), MAX(mr.` + column + `)
+    FROM medical_records mr
+    INNER JOIN animals a ON mr.animal_id = a.id
+    WHERE a.farm_id = $1 AND a.type = $2 AND a.status = 'active' AND a.deleted_at IS NULL
+      AND mr.deleted_at IS NULL AND mr.` + column + ` > $3
+  `
+	var count int
+	var until sql.NullTime
+	if err := r.db.QueryRow(query, farmID, animalType, day.Format("2006-01-02")).Scan(&count, &until); err != nil {
+		return 0, nil, fmt.Errorf("failed to get group withdrawal end: %v", err)
+	}
+	if !until.Valid {
+		return 0, nil, nil
+	}
+	return count, &until.Time, nil
+}
+
+var withdrawalColumns = map[string]string{
+	models.ProductMeat: "meat_withdrawal_until",
+	models.ProductMilk: "milk_withdrawal_until",
+	models.ProductEggs: "egg_withdrawal_until",
+}
+
+const (
+	medicalRecordColumns = `
+	mr.id AS medical_record_id, 
+	mr.quantity, 
+	mr.treatment_date, 
+	mr.notes,
+	mr.recorded_by,
+	mr.created_at,
+	a.id AS animal_id, 
+	a.name AS animal_name, 
+	a.type AS animal_type, 
+	a.weight AS animal_weight, 
+	a.health_status AS animal_health_status, 
+	m.id AS medicine_id,
+	m.name AS medicine_name,
+	m.suitable_for AS medicine_suitable_for,
+	m.unit_of_measure AS medicine_unit_of_measure,
+	mr.meat_withdrawal_until,
+	mr.milk_withdrawal_until,
+	mr.egg_withdrawal_until`
+	medicalRecordTables = `medical_records mr
+  INNER JOIN animals a ON mr.animal_id = a.id
+  INNER JOIN medicines m ON mr.medicine_id = m.id`
+)
+
+func scanMedicalRecord(row interface{ Scan(...interface{}) error }, record *models.MedicalRecordDetailed) error {
+	var recordedBy uuid.NullUUID
+	var meatUntil, milkUntil, eggUntil sql.NullTime
 	err := row.Scan(
 		&record.ID,
 		&record.Quantity,
 		&record.TreatmentDate,
 		&record.Notes,
+		&recordedBy,
 		&record.CreatedAt,
 		&record.Animal.ID,
 		&record.Animal.Name,
@@ -92,113 +246,205 @@ func (r *MedicalRecordRepository) GetMedicalRecordByID(recordID uuid.UUID) (*mod
 		&record.Animal.HealthStatus,
 		&record.Medicine.ID,
 		&record.Medicine.Name,
-		&record.Medicine.SuitableFor,
+		pq.Array(&record.Medicine.SuitableFor),
 		&record.Medicine.UnitOfMeasure,
+		&meatUntil,
+		&milkUntil,
+		&eggUntil,
 	)
+	if err != nil {
+		return err
+	}
+	if recordedBy.Valid {
+		record.RecordedBy = &recordedBy.UUID
+	}
+	record.WithdrawalDates = withdrawalDates(meatUntil, milkUntil, eggUntil)
+	return nil
+}
+
+func (r *MedicalRecordRepository) GetMedicalRecordByID(recordID uuid.UUID) (*models.MedicalRecordDetailed, error) {
+	query := `SELECT ` + medicalRecordColumns + ` FROM ` + medicalRecordTables + ` WHERE mr.id = $1 AND mr.deleted_at IS NULL`
+
+	var record models.MedicalRecordDetailed
+	err := scanMedicalRecord(r.db.QueryRow(query, recordID), &record)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get medical record by ID: %v", err)
 	}
+
+	if record.Batches, err = getBatchUsages(r.db, recordID); err != nil {
+		return nil, err
+	}
 	return &record, nil
 }
 
-func (r *MedicalRecordRepository) GetMedicalRecordsByAnimalID(animalID uuid.UUID) ([]*models.MedicalRecordDetailed, error) {
+func (r *MedicalRecordRepository) GetFarmIDByRecordID(recordID uuid.UUID) (uuid.UUID, error) {
 	query := `
-  SELECT
-	mr.id AS medical_record_id, 
-	mr.quantity, 
-	mr.treatment_date, 
-	mr.notes,
-	mr.created_at,
-	a.id AS animal_id, 
-	a.name AS animal_name, 
-	a.type AS animal_type, 
-	a.weight AS animal_weight, 
-	a.health_status AS animal_health_status, 
-	m.id AS medicine_id,
-	m.name AS medicine_name,
-	m.suitable_for AS medicine_suitable_for
-	m.unit_of_measure AS medicine_unit_of_measure
-  FROM medical_records mr
-  INNER JOIN animals a ON mr.animal_id = a.id
-  INNER JOIN medicines m ON mr.medicine_id = m.id
-  WHERE mr.animal_id = $1
-  ORDER BY mr.treatment_date DESC
-`
-	rows, err := r.db.Query(query, animalID)
+    SELECT COALESCE(mr.farm_id, a.farm_id)
+    FROM medical_records mr
+    LEFT JOIN animals a ON mr.animal_id = a.id
+    WHERE mr.id = $1 AND mr.deleted_at IS NULL
+  `
+
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, recordID).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrMedicalRecordNotFound
+		}
+		return uuid.Nil, fmt.Errorf("failed to get farm of medical record: %v", err)
+	}
+
+	return farmID, nil
+}
+
+// GetMedicalRecords returns a page of the medical records that match the
+// filter, together with their total number.
+func (r *MedicalRecordRepository) GetMedicalRecords(filter *models.RecordFilter, page *models.PageQuery) ([]*models.MedicalRecordDetailed, int, error) {
+	q := newListQuery(medicalRecordColumns, medicalRecordTables)
+	filterRecords(q, "mr", "treatment_date", filter)
+	if filter.ItemID != uuid.Nil {
+		q.filter(`mr.medicine_id = ?`, filter.ItemID)
+	}
+
+	records, total, err := list(r.db, q, page, sortColumns("mr", models.MedicalRecordSortFields), "mr.treatment_date DESC", "mr.id",
+		func(rows *sql.Rows) (*models.MedicalRecordDetailed, error) {
+			var record models.MedicalRecordDetailed
+			if err := scanMedicalRecord(rows, &record); err != nil {
+				return nil, fmt.Errorf("failed to scan medical record: %v", err)
+			}
+			return &record, nil
+		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get medical records by animal ID: %v", err)
+		return nil, 0, fmt.Errorf("failed to get medical records: %v", err)
 	}
-	defer rows.Close()
+	return records, total, nil
+}
 
-	var records []*models.MedicalRecordDetailed
-	for rows.Next() {
-		var record models.MedicalRecordDetailed
-		err := rows.Scan(
-			&record.ID,
-			&record.Quantity,
-			&record.TreatmentDate,
-			&record.Notes,
-			&record.CreatedAt,
-			&record.Animal.ID,
-			&record.Animal.Name,
-			&record.Animal.Type,
-			&record.Animal.Weight,
-			&record.Animal.HealthStatus,
-			&record.Medicine.ID,
-			&record.Medicine.Name,
-			&record.Medicine.SuitableFor,
-			&record.Medicine.UnitOfMeasure,
-		)
+// UpdateMedicalRecord updates the record and books the difference to the
+// previous quantity on the medicine. The batches used are allocated again. It
+// returns the stock movement, or nil when the quantity did not change.
+func (r *MedicalRecordRepository) UpdateMedicalRecord(record *models.MedicalRecordWithoutTime, actor *models.AuditActor) (movement *models.StockMovement, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan medical record: %v", err)
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var previousQuantity float64
+	selectQuery := `SELECT animal_id, medicine_id, quantity FROM medical_records WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	err = tx.QueryRow(selectQuery, record.ID).Scan(&record.AnimalID, &record.MedicineID, &previousQuantity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrMedicalRecordNotFound
 		}
-		records = append(records, &record)
+		return nil, err
+	}
+
+	before, err := lockSnapshot(tx, models.AuditMedicalRecord, record.ID)
+	if err != nil {
+		return nil, err
 	}
-	return records, nil
-}
 
-func (r *MedicalRecordRepository) UpdateMedicalRecord(record *models.MedicalRecordWithoutTime) error {
 	query := `
     UPDATE medical_records
     SET quantity = $1,
       treatment_date = $2, notes = $3
     WHERE id = $4
   `
-	result, err := r.db.Exec(query, record.Quantity, record.TreatmentDate, record.Notes, record.ID)
+	_, err = tx.Exec(query, record.Quantity, record.TreatmentDate, record.Notes, record.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
+	if err = setWithdrawalDates(tx, record.ID); err != nil {
+		return nil, err
 	}
 
-	if rowsAffected == 0 {
-		return ErrMedicalRecordNotFound
+	if err = restoreBatches(tx, actor, record.ID); err != nil {
+		return nil, err
 	}
 
-	return nil
+	if record.Quantity != previousQuantity {
+		movement = &models.StockMovement{
+			ItemType:     models.StockItemMedicine,
+			ItemID:       record.MedicineID,
+			MovementType: models.MovementConsumption,
+			Quantity:     previousQuantity - record.Quantity,
+			ReferenceID:  &record.ID,
+			Notes:        "medical record updated",
+		}
+		if err = applyRecordCorrection(tx, actor, movement); err != nil {
+			return nil, err
+		}
+	}
+
+	if record.Batches, err = consumeBatches(tx, actor, record.ID, record.MedicineID, record.Quantity, record.TreatmentDate); err != nil {
+		return nil, err
+	}
+
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditMedicalRecord, record.ID, before); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
 }
 
-func (r *MedicalRecordRepository) DeleteMedicalRecord(recordID uuid.UUID) error {
-	query := `DELETE FROM medical_records WHERE id = $1`
-	result, err := r.db.Exec(query, recordID)
+// DeleteMedicalRecord moves the record to the trash and returns its quantity
+// to the medicine stock and the batches it was taken from.
+func (r *MedicalRecordRepository) DeleteMedicalRecord(recordID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditMedicalRecord, recordID)
 	if err != nil {
 		return err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	if err = restoreBatches(tx, actor, recordID); err != nil {
+		return err
+	}
+
+	var medicineID uuid.UUID
+	var quantity float64
+	query := `UPDATE medical_records SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL RETURNING medicine_id, quantity`
+	err = tx.QueryRow(query, recordID).Scan(&medicineID, &quantity)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrMedicalRecordNotFound
+		}
 		return err
 	}
 
-	if rowsAffected == 0 {
-		return ErrMedicalRecordNotFound
+	err = applyRecordCorrection(tx, actor, &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       medicineID,
+		MovementType: models.MovementConsumption,
+		Quantity:     quantity,
+		ReferenceID:  &recordID,
+		Notes:        "medical record deleted",
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return audit(tx, actor, models.AuditDelete, models.AuditMedicalRecord, recordID, before)
 }
@@ -0,0 +1,482 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type MedicineBatchRepository struct {
+	db *sql.DB
+}
+
+func NewMedicineBatchRepository(db *sql.DB) *MedicineBatchRepository {
+	return &MedicineBatchRepository{db: db}
+}
+
+var (
+	ErrMedicineBatchNotFound = errors.New("medicine batch not found")
+	ErrBatchEmpty            = errors.New("medicine batch has no stock left")
+	ErrStockExpired          = errors.New("remaining medicine stock has expired")
+)
+
+// CreateBatch stores the batch and books its quantity as a purchase of the
+// medicine.
+func (r *MedicineBatchRepository) CreateBatch(batch *models.MedicineBatch, unitCost *float64, createdBy uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = applyStockMovement(tx, actor, &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       batch.MedicineID,
+		MovementType: models.MovementPurchase,
+		Quantity:     batch.InitialQuantity,
+		UnitCost:     unitCost,
+		ReferenceID:  &batch.ID,
+		Notes:        "batch " + batch.LotNumber,
+		CreatedBy:    &createdBy,
+	})
+	if err != nil {
+		return err
+	}
+
+	return insertMedicineBatch(tx, actor, batch)
+}
+
+// insertMedicineBatch stores a new batch holding its whole initial quantity.
+// The caller books the matching purchase on the medicine.
+func insertMedicineBatch(tx *sql.Tx, actor *models.AuditActor, batch *models.MedicineBatch) error {
+	query := `
+	INSERT INTO medicine_batches (id, medicine_id, lot_number, expiry_date, initial_quantity, quantity)
+	VALUES ($1, $2, $3, $4, $5, $5)
+	RETURNING quantity, expiry_date < CURRENT_DATE, created_at
+	`
+	err := tx.QueryRow(query, batch.ID, batch.MedicineID, batch.LotNumber, batch.ExpiryDate.Format("2006-01-02"), batch.InitialQuantity).
+		Scan(&batch.Quantity, &batch.Expired, &batch.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create medicine batch: %v", err)
+	}
+	return audit(tx, actor, models.AuditCreate, models.AuditMedicineBatch, batch.ID, nil)
+}
+
+const medicineBatchColumns = `b.id, b.medicine_id, b.lot_number, b.expiry_date, b.initial_quantity, b.quantity,
+	b.expiry_date < CURRENT_DATE, b.created_at`
+
+func scanMedicineBatch(row interface{ Scan(...interface{}) error }, batch *models.MedicineBatch, extra ...interface{}) error {
+	dest := append([]interface{}{&batch.ID, &batch.MedicineID, &batch.LotNumber, &batch.ExpiryDate, &batch.InitialQuantity,
+		&batch.Quantity, &batch.Expired, &batch.CreatedAt}, extra...)
+	return row.Scan(dest...)
+}
+
+// GetBatches returns a page of the batches of the medicine, in the order they
+// are consumed unless sorted otherwise, together with their total number. Used
+// up batches are left out unless includeEmpty is set.
+func (r *MedicineBatchRepository) GetBatches(medicineID uuid.UUID, includeEmpty bool, page *models.PageQuery) ([]models.MedicineBatch, int, error) {
+	q := newListQuery(medicineBatchColumns, `medicine_batches b`)
+	q.filter(`b.medicine_id = ?`, medicineID)
+	if !includeEmpty {
+		q.filter(`b.quantity > 0`)
+	}
+
+	batches, total, err := list(r.db, q, page, sortColumns("b", models.MedicineBatchSortFields), "b.expiry_date, b.created_at", "b.id",
+		func(rows *sql.Rows) (models.MedicineBatch, error) {
+			var batch models.MedicineBatch
+			if err := scanMedicineBatch(rows, &batch); err != nil {
+				return batch, fmt.Errorf("failed to scan medicine batch: %v", err)
+			}
+			return batch, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get medicine batches: %v", err)
+	}
+	return batches, total, nil
+}
+
+func (r *MedicineBatchRepository) GetBatchByID(id uuid.UUID) (*models.MedicineBatch, error) {
+	query := `SELECT ` + medicineBatchColumns + ` FROM medicine_batches b WHERE b.id = $1`
+
+	var batch models.MedicineBatch
+	if err := scanMedicineBatch(r.db.QueryRow(query, id), &batch); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get medicine batch: %v", err)
+	}
+	return &batch, nil
+}
+
+func (r *MedicineBatchRepository) GetFarmIDByBatchID(id uuid.UUID) (uuid.UUID, error) {
+	query := `
+	SELECT m.farm_id
+	FROM medicine_batches b
+	INNER JOIN medicines m ON b.medicine_id = m.id
+	WHERE b.id = $1 AND m.deleted_at IS NULL
+	`
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrMedicineBatchNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+const (
+	expiringBatchColumns = medicineBatchColumns + `, m.farm_id, m.name, m.unit_of_measure, b.expiry_date - CURRENT_DATE`
+	expiringBatchTables  = `medicine_batches b
+	INNER JOIN medicines m ON b.medicine_id = m.id`
+	expiringBatchQuery = `SELECT ` + expiringBatchColumns + ` FROM ` + expiringBatchTables
+)
+
+func scanExpiringBatch(row interface{ Scan(...interface{}) error }) (models.ExpiringBatch, error) {
+	var batch models.ExpiringBatch
+	err := scanMedicineBatch(row, &batch.MedicineBatch, &batch.FarmID, &batch.MedicineName, &batch.UnitOfMeasure, &batch.DaysLeft)
+	if err != nil {
+		return batch, fmt.Errorf("failed to scan expiring batch: %v", err)
+	}
+	return batch, nil
+}
+
+func (r *MedicineBatchRepository) queryExpiringBatches(query string, args ...interface{}) ([]models.ExpiringBatch, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring batches: %v", err)
+	}
+	defer rows.Close()
+
+	var batches []models.ExpiringBatch
+	for rows.Next() {
+		batch, err := scanExpiringBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, batch)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return batches, nil
+}
+
+// GetExpiringBatches returns a page of the batches of the farm with stock left
+// that expire up to the given day, including those already expired, together
+// with their total number.
+func (r *MedicineBatchRepository) GetExpiringBatches(farmID uuid.UUID, until time.Time, page *models.PageQuery) ([]models.ExpiringBatch, int, error) {
+	q := newListQuery(expiringBatchColumns, expiringBatchTables)
+	q.filter(`m.farm_id = ?`, farmID)
+	q.filter(`m.deleted_at IS NULL`)
+	q.filter(`b.quantity > 0`)
+	q.filter(`b.expiry_date <= ?`, until.Format("2006-01-02"))
+
+	batches, total, err := list(r.db, q, page, sortColumns("b", models.MedicineBatchSortFields), "b.expiry_date, m.name", "b.id",
+		func(rows *sql.Rows) (models.ExpiringBatch, error) { return scanExpiringBatch(rows) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get expiring batches: %v", err)
+	}
+	return batches, total, nil
+}
+
+// GetBatchesToAlert returns the batches with stock left that expire up to the
+// given day and were not alerted about yet, and the ones that have expired
+// before today without an expiry alert.
+func (r *MedicineBatchRepository) GetBatchesToAlert(until time.Time) ([]models.ExpiringBatch, error) {
+	query := expiringBatchQuery + `
+	WHERE b.quantity > 0 AND m.deleted_at IS NULL
+	  AND ((b.expiry_date <= $1 AND b.expiring_alerted_at IS NULL)
+	    OR (b.expiry_date < CURRENT_DATE AND b.expired_alerted_at IS NULL))
+	ORDER BY b.expiry_date
+	`
+	return r.queryExpiringBatches(query, until.Format("2006-01-02"))
+}
+
+// MarkAlerted records that an alert was raised for the batches. Expired
+// batches are marked for both alerts, so no late expiry warning follows.
+func (r *MedicineBatchRepository) MarkAlerted(ids []uuid.UUID) error {
+	query := `
+	UPDATE medicine_batches
+	SET expiring_alerted_at = COALESCE(expiring_alerted_at, CURRENT_TIMESTAMP),
+	  expired_alerted_at = CASE WHEN expiry_date < CURRENT_DATE THEN CURRENT_TIMESTAMP ELSE expired_alerted_at END
+	WHERE id = ANY($1)
+	`
+	if _, err := r.db.Exec(query, pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to mark medicine batches as alerted: %v", err)
+	}
+	return nil
+}
+
+// DiscardBatch books what is left of the batch as waste and empties it.
+func (r *MedicineBatchRepository) DiscardBatch(id uuid.UUID, userID uuid.UUID, actor *models.AuditActor) (movement *models.StockMovement, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var medicineID uuid.UUID
+	var lotNumber string
+	var quantity float64
+	query := `SELECT medicine_id, lot_number, quantity FROM medicine_batches WHERE id = $1 FOR UPDATE`
+	if err = tx.QueryRow(query, id).Scan(&medicineID, &lotNumber, &quantity); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrMedicineBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to get medicine batch: %v", err)
+	}
+	if quantity <= 0 {
+		return nil, ErrBatchEmpty
+	}
+
+	if err = changeBatchQuantity(tx, actor, id, -quantity); err != nil {
+		return nil, err
+	}
+
+	movement = &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       medicineID,
+		MovementType: models.MovementWaste,
+		Quantity:     -quantity,
+		ReferenceID:  &id,
+		Notes:        "batch " + lotNumber + " discarded",
+		CreatedBy:    &userID,
+	}
+	if err = applyStockMovement(tx, actor, movement); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+// consumeBatches takes the quantity of a medical record out of the medicine's
+// batches, first expiring first out, and records which batches were used. It
+// must run after the consumption was booked on the medicine. Stock that is not
+// held in any batch is used after the batches; batches that expired before
+// the treatment day are never used, and ErrStockExpired is returned when the
+// quantity can only be covered with them.
+func consumeBatches(tx *sql.Tx, actor *models.AuditActor, recordID, medicineID uuid.UUID, quantity float64,
+	day time.Time) ([]models.BatchUsage, error) {
+	var remaining float64
+	if err := tx.QueryRow(`SELECT quantity FROM medicines WHERE id = $1`, medicineID).Scan(&remaining); err != nil {
+		return nil, fmt.Errorf("failed to get medicine quantity: %v", err)
+	}
+
+	rows, err := tx.Query(`
+	SELECT id, lot_number, expiry_date, quantity
+	FROM medicine_batches
+	WHERE medicine_id = $1 AND quantity > 0
+	ORDER BY expiry_date, created_at
+	FOR UPDATE
+	`, medicineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get medicine batches: %v", err)
+	}
+
+	type batchStock struct {
+		usage     models.BatchUsage
+		available float64
+	}
+	var usable []batchStock
+	unbatched := remaining + quantity
+	today := day.Format("2006-01-02")
+	for rows.Next() {
+		var batch batchStock
+		if err = rows.Scan(&batch.usage.BatchID, &batch.usage.LotNumber, &batch.usage.ExpiryDate, &batch.available); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan medicine batch: %v", err)
+		}
+		unbatched -= batch.available
+		if batch.usage.ExpiryDate.Format("2006-01-02") >= today {
+			usable = append(usable, batch)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	var usages []models.BatchUsage
+	left := quantity
+	for _, batch := range usable {
+		if left <= reconciliationTolerance {
+			break
+		}
+		used := batch.available
+		if used > left {
+			used = left
+		}
+		left -= used
+
+		if err = changeBatchQuantity(tx, actor, batch.usage.BatchID, -used); err != nil {
+			return nil, err
+		}
+		query := `INSERT INTO medical_record_batches (medical_record_id, batch_id, quantity) VALUES ($1, $2, $3)`
+		if _, err = tx.Exec(query, recordID, batch.usage.BatchID, used); err != nil {
+			return nil, fmt.Errorf("failed to record batch usage: %v", err)
+		}
+
+		batch.usage.Quantity = used
+		usages = append(usages, batch.usage)
+	}
+
+	// Batches holding more than the medicine's quantity leave no unbatched
+	// stock rather than a negative amount.
+	if left > math.Max(unbatched, 0)+reconciliationTolerance {
+		return nil, ErrStockExpired
+	}
+
+	return usages, nil
+}
+
+// drawDownBatches takes a quantity removed from a medicine other than by a
+// treatment, such as waste or a manual adjustment, out of its batches, first
+// expiring first out. Expired batches are included, as expired stock is what is
+// usually thrown away. What the batches cannot cover came from unbatched
+// stock. It must run after the removal was booked on the medicine, so that the
+// batches never hold more than the medicine's quantity.
+func drawDownBatches(tx *sql.Tx, actor *models.AuditActor, medicineID uuid.UUID, quantity float64) error {
+	rows, err := tx.Query(`
+	SELECT id, quantity
+	FROM medicine_batches
+	WHERE medicine_id = $1 AND quantity > 0
+	ORDER BY expiry_date, created_at
+	FOR UPDATE
+	`, medicineID)
+	if err != nil {
+		return fmt.Errorf("failed to get medicine batches: %v", err)
+	}
+
+	type batchStock struct {
+		id        uuid.UUID
+		available float64
+	}
+	var batches []batchStock
+	for rows.Next() {
+		var batch batchStock
+		if err = rows.Scan(&batch.id, &batch.available); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan medicine batch: %v", err)
+		}
+		batches = append(batches, batch)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	left := quantity
+	for _, batch := range batches {
+		if left <= reconciliationTolerance {
+			break
+		}
+		used := math.Min(batch.available, left)
+		left -= used
+		if err = changeBatchQuantity(tx, actor, batch.id, -used); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreBatches puts the quantities a medical record took from batches back
+// into them and forgets the usage.
+func restoreBatches(tx *sql.Tx, actor *models.AuditActor, recordID uuid.UUID) error {
+	rows, err := tx.Query(`DELETE FROM medical_record_batches WHERE medical_record_id = $1 RETURNING batch_id, quantity`, recordID)
+	if err != nil {
+		return fmt.Errorf("failed to restore medicine batches: %v", err)
+	}
+
+	type batchUsage struct {
+		batchID  uuid.UUID
+		quantity float64
+	}
+	var usages []batchUsage
+	for rows.Next() {
+		var usage batchUsage
+		if err = rows.Scan(&usage.batchID, &usage.quantity); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan batch usage: %v", err)
+		}
+		usages = append(usages, usage)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	for _, usage := range usages {
+		if err = changeBatchQuantity(tx, actor, usage.batchID, usage.quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeBatchQuantity adds delta to the quantity held in the batch. The caller
+// books the matching movement on the medicine.
+func changeBatchQuantity(tx *sql.Tx, actor *models.AuditActor, batchID uuid.UUID, delta float64) error {
+	before, err := lockSnapshot(tx, models.AuditMedicineBatch, batchID)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`UPDATE medicine_batches SET quantity = quantity + $1 WHERE id = $2`, delta, batchID); err != nil {
+		return fmt.Errorf("failed to update medicine batch: %v", err)
+	}
+	return audit(tx, actor, models.AuditUpdate, models.AuditMedicineBatch, batchID, before)
+}
+
+// getBatchUsages returns the batches the medical record consumed.
+func getBatchUsages(db *sql.DB, recordID uuid.UUID) ([]models.BatchUsage, error) {
+	query := `
+	SELECT b.id, b.lot_number, b.expiry_date, u.quantity
+	FROM medical_record_batches u
+	INNER JOIN medicine_batches b ON u.batch_id = b.id
+	WHERE u.medical_record_id = $1
+	ORDER BY b.expiry_date
+	`
+	rows, err := db.Query(query, recordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch usage: %v", err)
+	}
+	defer rows.Close()
+
+	usages := []models.BatchUsage{}
+	for rows.Next() {
+		var usage models.BatchUsage
+		if err := rows.Scan(&usage.BatchID, &usage.LotNumber, &usage.ExpiryDate, &usage.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan batch usage: %v", err)
+		}
+		usages = append(usages, usage)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return usages, nil
+}
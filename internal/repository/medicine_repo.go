@@ -17,49 +17,74 @@ func NewMedicineRepository(db *sql.DB) *MedicineRepository {
 	return &MedicineRepository{DB: db}
 }
 
-func (r *MedicineRepository) CreateMedicine(medicine *models.MedicineWithoutTime) error {
+// CreateMedicine stores the medicine with an empty stock and books its
+// initial quantity as a purchase so the ledger accounts for all of it.
+func (r *MedicineRepository) CreateMedicine(medicine *models.MedicineWithoutTime, createdBy uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
 	query := `
-    INSERT INTO medicines (id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold)
-    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    INSERT INTO medicines (id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold,
+      meat_withdrawal_days, milk_withdrawal_days, egg_withdrawal_days)
+    VALUES ($1, $2, $3, $4, $5, 0, $6, $7, $8, $9)
   `
-	_, err := r.DB.Exec(query, medicine.ID, medicine.FarmID, medicine.Name, pq.Array(medicine.SuitableFor), medicine.UnitOfMeasure, medicine.Quantity, medicine.MinThreshold)
+	_, err = tx.Exec(query, medicine.ID, medicine.FarmID, medicine.Name, pq.Array(medicine.SuitableFor), medicine.UnitOfMeasure, medicine.MinThreshold,
+		medicine.MeatWithdrawalDays, medicine.MilkWithdrawalDays, medicine.EggWithdrawalDays)
 	if err != nil {
 		return fmt.Errorf("failed to create medicine: %v", err)
 	}
-	return nil
-}
 
-func (r *MedicineRepository) GetAllMedicines(farmID uuid.UUID) ([]models.Medicine, error) {
-	query := `SELECT id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold, created_at, updated_at FROM medicines WHERE farm_id = $1`
-	rows, err := r.DB.Query(query, farmID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch medicines: %v", err)
+	if err = audit(tx, actor, models.AuditCreate, models.AuditMedicine, medicine.ID, nil); err != nil {
+		return err
 	}
-	defer rows.Close()
 
-	var medicines []models.Medicine
-	for rows.Next() {
-		var medicine models.Medicine
-		err := rows.Scan(&medicine.ID, &medicine.FarmID, &medicine.Name, pq.Array(&medicine.SuitableFor), &medicine.UnitOfMeasure, &medicine.Quantity, &medicine.MinThreshold, &medicine.CreatedAt, &medicine.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan medicine row: %v", err)
-		}
-		medicines = append(medicines, medicine)
-	}
+	return applyStockMovement(tx, actor, &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       medicine.ID,
+		MovementType: models.MovementPurchase,
+		Quantity:     medicine.Quantity,
+		Notes:        "initial stock",
+		CreatedBy:    &createdBy,
+	})
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
-	}
+// GetAllMedicines returns a page of the medicines of a farm that match the
+// filter, together with their total number.
+func (r *MedicineRepository) GetAllMedicines(filter *models.StockFilter, page *models.PageQuery) ([]models.Medicine, int, error) {
+	q := newListQuery(`id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold, meat_withdrawal_days, milk_withdrawal_days, egg_withdrawal_days, created_at, updated_at`, `medicines m`)
+	filterStock(q, filter)
 
-	return medicines, nil
+	medicines, total, err := list(r.DB, q, page, sortColumns("m", models.StockSortFields), "m.name", "m.id",
+		func(rows *sql.Rows) (models.Medicine, error) {
+			var medicine models.Medicine
+			err := rows.Scan(&medicine.ID, &medicine.FarmID, &medicine.Name, pq.Array(&medicine.SuitableFor), &medicine.UnitOfMeasure, &medicine.Quantity, &medicine.MinThreshold, &medicine.MeatWithdrawalDays, &medicine.MilkWithdrawalDays, &medicine.EggWithdrawalDays, &medicine.CreatedAt, &medicine.UpdatedAt)
+			if err != nil {
+				return medicine, fmt.Errorf("failed to scan medicine row: %v", err)
+			}
+			return medicine, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch medicines: %v", err)
+	}
+	return medicines, total, nil
 }
 
 func (r *MedicineRepository) GetMedicineByID(id uuid.UUID) (*models.Medicine, error) {
-	query := `SELECT id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold, created_at, updated_at FROM medicines WHERE id = $1`
+	query := `SELECT id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold, meat_withdrawal_days, milk_withdrawal_days, egg_withdrawal_days, created_at, updated_at FROM medicines WHERE id = $1 AND deleted_at IS NULL`
 	row := r.DB.QueryRow(query, id)
 
 	var medicine models.Medicine
-	err := row.Scan(&medicine.ID, &medicine.FarmID, &medicine.Name, pq.Array(&medicine.SuitableFor), &medicine.UnitOfMeasure, &medicine.Quantity, &medicine.MinThreshold, &medicine.CreatedAt, &medicine.UpdatedAt)
+	err := row.Scan(&medicine.ID, &medicine.FarmID, &medicine.Name, pq.Array(&medicine.SuitableFor), &medicine.UnitOfMeasure, &medicine.Quantity, &medicine.MinThreshold, &medicine.MeatWithdrawalDays, &medicine.MilkWithdrawalDays, &medicine.EggWithdrawalDays, &medicine.CreatedAt, &medicine.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -69,22 +94,81 @@ func (r *MedicineRepository) GetMedicineByID(id uuid.UUID) (*models.Medicine, er
 	return &medicine, nil
 }
 
-func (r *MedicineRepository) UpdateMedicine(medicine *models.MedicineWithoutTime) error {
+// UpdateMedicine updates the medicine details. A changed quantity is booked
+// as an adjustment instead of being overwritten.
+func (r *MedicineRepository) UpdateMedicine(medicine *models.MedicineWithoutTime, updatedBy uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var currentQuantity float64
+	err = tx.QueryRow(`SELECT quantity FROM medicines WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, medicine.ID).Scan(&currentQuantity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrStockItemNotFound
+		}
+		return fmt.Errorf("failed to fetch medicine by id: %v", err)
+	}
+
+	before, err := lockSnapshot(tx, models.AuditMedicine, medicine.ID)
+	if err != nil {
+		return err
+	}
+
 	query := `
     UPDATE medicines
-    SET name = $1, suitable_for = $2, unit_of_measure = $3, quantity = $4, min_threshold = $5
-    WHERE id = $6
+    SET name = $1, suitable_for = $2, unit_of_measure = $3, min_threshold = $4,
+      meat_withdrawal_days = $5, milk_withdrawal_days = $6, egg_withdrawal_days = $7
+    WHERE id = $8
   `
-	_, err := r.DB.Exec(query, medicine.Name, pq.Array(medicine.SuitableFor), medicine.UnitOfMeasure, medicine.Quantity, medicine.MinThreshold, medicine.ID)
+	_, err = tx.Exec(query, medicine.Name, pq.Array(medicine.SuitableFor), medicine.UnitOfMeasure, medicine.MinThreshold,
+		medicine.MeatWithdrawalDays, medicine.MilkWithdrawalDays, medicine.EggWithdrawalDays, medicine.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update medicine: %v", err)
 	}
+
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditMedicine, medicine.ID, before); err != nil {
+		return err
+	}
+
+	if medicine.Quantity == currentQuantity {
+		return nil
+	}
+
+	err = applyStockMovement(tx, actor, &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       medicine.ID,
+		MovementType: models.MovementAdjustment,
+		Quantity:     medicine.Quantity - currentQuantity,
+		Notes:        "quantity updated",
+		CreatedBy:    &updatedBy,
+	})
+	if err != nil {
+		return err
+	}
+	if medicine.Quantity < currentQuantity {
+		return drawDownBatches(tx, actor, medicine.ID, currentQuantity-medicine.Quantity)
+	}
 	return nil
 }
 
-func (r *MedicineRepository) DeleteMedicine(id uuid.UUID) error {
-	query := `DELETE FROM medicines WHERE id = $1`
-	_, err := r.DB.Exec(query, id)
+// DeleteMedicine moves the medicine to the trash. Its batches and the medical
+// records that used it are kept.
+func (r *MedicineRepository) DeleteMedicine(id uuid.UUID, actor *models.AuditActor) error {
+	query := `UPDATE medicines SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`
+	err := auditedChange(r.DB, actor, models.AuditDelete, models.AuditMedicine, id, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, id)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete medicine: %v", err)
 	}
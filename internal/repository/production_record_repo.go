@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type ProductionRecordRepository struct {
+	db *sql.DB
+}
+
+func NewProductionRecordRepository(db *sql.DB) *ProductionRecordRepository {
+	return &ProductionRecordRepository{db: db}
+}
+
+var ErrProductionRecordNotFound = errors.New("production record not found")
+
+func (r *ProductionRecordRepository) CreateRecord(record *models.ProductionRecord, actor *models.AuditActor) error {
+	query := `
+	INSERT INTO production_records (id, farm_id, animal_id, animal_type, product, quantity, unit, produced_at, notes, recorded_by)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10)
+	RETURNING created_at
+	`
+	var animalType string
+	if record.AnimalID == nil {
+		animalType = record.AnimalType
+	}
+	err := auditedChange(r.db, actor, models.AuditCreate, models.AuditProductionRecord, record.ID, func(tx *sql.Tx) error {
+		return tx.QueryRow(query, record.ID, record.FarmID, nullUUID(record.AnimalID), animalType, record.Product,
+			record.Quantity, record.Unit, record.ProducedAt, record.Notes, nullUUID(record.RecordedBy)).Scan(&record.CreatedAt)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create production record: %v", err)
+	}
+	return nil
+}
+
+const (
+	productionRecordColumns = `pr.id, COALESCE(pr.farm_id, a.farm_id), pr.animal_id, COALESCE(a.name, ''), COALESCE(a.type, pr.animal_type), pr.product,
+	  pr.quantity, pr.unit, pr.produced_at, COALESCE(pr.notes, ''), pr.recorded_by, pr.created_at`
+	productionRecordTables = `production_records pr
+	LEFT JOIN animals a ON pr.animal_id = a.id`
+	productionRecordSelect = `SELECT ` + productionRecordColumns + ` FROM ` + productionRecordTables
+)
+
+func scanProductionRecord(row interface{ Scan(...interface{}) error }, record *models.ProductionRecord) error {
+	var animalID, recordedBy uuid.NullUUID
+	err := row.Scan(&record.ID, &record.FarmID, &animalID, &record.AnimalName, &record.AnimalType, &record.Product,
+		&record.Quantity, &record.Unit, &record.ProducedAt, &record.Notes, &recordedBy, &record.CreatedAt)
+	if err != nil {
+		return err
+	}
+	if animalID.Valid {
+		record.AnimalID = &animalID.UUID
+	}
+	if recordedBy.Valid {
+		record.RecordedBy = &recordedBy.UUID
+	}
+	return nil
+}
+
+func (r *ProductionRecordRepository) GetRecordByID(id uuid.UUID) (*models.ProductionRecord, error) {
+	var record models.ProductionRecord
+	err := scanProductionRecord(r.db.QueryRow(productionRecordSelect+` WHERE pr.id = $1 AND pr.deleted_at IS NULL`, id), &record)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get production record: %v", err)
+	}
+	return &record, nil
+}
+
+// productionConditions appends the optional animal, type and product filters.
+func productionConditions(filter *models.ReportFilter, args []interface{}) (string, []interface{}) {
+	var conditions string
+	if filter.AnimalID != uuid.Nil {
+		args = append(args, filter.AnimalID)
+		conditions += ` AND pr.animal_id = $` + strconv.Itoa(len(args))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions += ` AND COALESCE(a.type, pr.animal_type) = $` + strconv.Itoa(len(args))
+	}
+	if filter.Product != "" {
+		args = append(args, filter.Product)
+		conditions += ` AND pr.product = $` + strconv.Itoa(len(args))
+	}
+	return conditions, args
+}
+
+// GetRecords returns a page of the production records of the farm that match
+// the filter, together with their total number.
+func (r *ProductionRecordRepository) GetRecords(filter *models.ReportFilter, page *models.PageQuery) ([]models.ProductionRecord, int, error) {
+	q := newListQuery(productionRecordColumns, productionRecordTables)
+	q.filter(`pr.farm_id = ?`, filter.FarmID)
+	q.filter(`pr.deleted_at IS NULL`)
+	q.filter(`pr.produced_at >= ?`, filter.From)
+	q.filter(`pr.produced_at < ?`, filter.To)
+	if filter.AnimalID != uuid.Nil {
+		q.filter(`pr.animal_id = ?`, filter.AnimalID)
+	}
+	if filter.Type != "" {
+		q.filter(`COALESCE(a.type, pr.animal_type) = ?`, filter.Type)
+	}
+	if filter.Product != "" {
+		q.filter(`pr.product = ?`, filter.Product)
+	}
+
+	records, total, err := list(r.db, q, page, sortColumns("pr", models.ProductionRecordSortFields), "pr.produced_at DESC", "pr.id",
+		func(rows *sql.Rows) (models.ProductionRecord, error) {
+			var record models.ProductionRecord
+			if err := scanProductionRecord(rows, &record); err != nil {
+				return record, fmt.Errorf("failed to scan production record: %v", err)
+			}
+			return record, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get production records: %v", err)
+	}
+	return records, total, nil
+}
+
+// productionGroupColumns maps a grouping to the ID and name columns it groups
+// by. Records of a group of animals are reported under their type when
+// grouping by animal.
+var productionGroupColumns = map[string]string{
+	models.ReportGroupFarm:   `pr.farm_id::text, ''`,
+	models.ReportGroupAnimal: `COALESCE(pr.animal_id::text, pr.animal_type), COALESCE(a.name, pr.animal_type)`,
+	models.ReportGroupType:   `COALESCE(a.type, pr.animal_type), COALESCE(a.type, pr.animal_type)`,
+}
+
+// GetYields sums the production per period, group, product and unit.
+func (r *ProductionRecordRepository) GetYields(filter *models.ReportFilter) ([]models.ProductionYield, error) {
+	groupColumns, ok := productionGroupColumns[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown grouping %q", filter.GroupBy)
+	}
+
+	args := []interface{}{filter.FarmID, filter.Interval, filter.From, filter.To}
+	conditions, args := productionConditions(filter, args)
+
+	query := `
+	SELECT date_trunc($2, pr.produced_at) AS period, ` + groupColumns + `, pr.product, pr.unit, SUM(pr.quantity), COUNT(*)
+	FROM production_records pr
+	LEFT JOIN animals a ON pr.animal_id = a.id
+	WHERE pr.farm_id = $1 AND pr.deleted_at IS NULL AND pr.produced_at >= $3 AND pr.produced_at < $4` + conditions + `
+	GROUP BY 1, 2, 3, 4, 5
+	ORDER BY 1, 3, 4, 5
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get production yields: %v", err)
+	}
+	defer rows.Close()
+
+	var yields []models.ProductionYield
+	for rows.Next() {
+		var yield models.ProductionYield
+		err := rows.Scan(&yield.PeriodStart, &yield.GroupID, &yield.GroupName, &yield.Product, &yield.Unit,
+			&yield.Quantity, &yield.Records)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan production yield: %v", err)
+		}
+		yields = append(yields, yield)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return yields, nil
+}
+
+// DeleteRecord moves the record to the trash.
+func (r *ProductionRecordRepository) DeleteRecord(id uuid.UUID, actor *models.AuditActor) error {
+	return auditedChange(r.db, actor, models.AuditDelete, models.AuditProductionRecord, id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`UPDATE production_records SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete production record: %v", err)
+		}
+		return requireRow(result, ErrProductionRecordNotFound)
+	})
+}
+
+func (r *ProductionRecordRepository) GetFarmIDByRecordID(id uuid.UUID) (uuid.UUID, error) {
+	var farmID uuid.UUID
+	query := `
+	SELECT COALESCE(pr.farm_id, a.farm_id)
+	FROM production_records pr
+	LEFT JOIN animals a ON pr.animal_id = a.id
+	WHERE pr.id = $1 AND pr.deleted_at IS NULL
+	`
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrProductionRecordNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
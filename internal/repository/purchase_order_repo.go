@@ -0,0 +1,455 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseOrderRepository struct {
+	db *sql.DB
+}
+
+func NewPurchaseOrderRepository(db *sql.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db: db}
+}
+
+var (
+	ErrPurchaseOrderNotFound = errors.New("purchase order not found")
+	ErrOrderStatus           = errors.New("purchase order status does not allow this action")
+	ErrOrderItemNotFound     = errors.New("item is not part of this purchase order")
+	ErrReceiveExceedsOrdered = errors.New("received quantity exceeds the outstanding quantity")
+)
+
+// receiveTolerance absorbs floating point noise when comparing received and
+// ordered quantities.
+const receiveTolerance = 1e-9
+
+func (r *PurchaseOrderRepository) CreateOrder(order *models.PurchaseOrder, items []models.PurchaseOrderItemReq,
+	actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+	INSERT INTO purchase_orders (id, farm_id, supplier_id, status, expected_delivery, notes, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING created_at
+	`
+	err = tx.QueryRow(query, order.ID, order.FarmID, order.SupplierID, order.Status, order.ExpectedDelivery, order.Notes,
+		nullUUID(order.CreatedBy)).Scan(&order.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create purchase order: %v", err)
+	}
+
+	if err = insertOrderItems(tx, order.ID, items); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditCreate, models.AuditPurchaseOrder, order.ID, nil)
+}
+
+func insertOrderItems(tx *sql.Tx, orderID uuid.UUID, items []models.PurchaseOrderItemReq) error {
+	query := `
+	INSERT INTO purchase_order_items (id, order_id, food_id, medicine_id, quantity, unit_cost)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for _, item := range items {
+		_, err := tx.Exec(query, uuid.New(), orderID, nullUUID(item.FoodID), nullUUID(item.MedicineID), item.Quantity, item.UnitCost)
+		if err != nil {
+			return fmt.Errorf("failed to create purchase order item: %v", err)
+		}
+	}
+	return nil
+}
+
+const (
+	purchaseOrderColumns = `po.id, po.farm_id, po.supplier_id, s.name, po.status, po.expected_delivery, COALESCE(po.notes, ''),
+	  COALESCE((SELECT SUM(i.quantity * i.unit_cost) FROM purchase_order_items i WHERE i.order_id = po.id), 0),
+	  po.created_by, po.ordered_at, po.received_at, po.created_at`
+	purchaseOrderTables = `purchase_orders po
+	INNER JOIN suppliers s ON po.supplier_id = s.id`
+	purchaseOrderQuery = `SELECT ` + purchaseOrderColumns + ` FROM ` + purchaseOrderTables
+)
+
+func scanPurchaseOrder(scanner interface{ Scan(...interface{}) error }) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	var expectedDelivery, orderedAt, receivedAt sql.NullTime
+	var createdBy uuid.NullUUID
+	err := scanner.Scan(&order.ID, &order.FarmID, &order.SupplierID, &order.SupplierName, &order.Status, &expectedDelivery,
+		&order.Notes, &order.TotalCost, &createdBy, &orderedAt, &receivedAt, &order.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if expectedDelivery.Valid {
+		order.ExpectedDelivery = &expectedDelivery.Time
+	}
+	if createdBy.Valid {
+		order.CreatedBy = &createdBy.UUID
+	}
+	if orderedAt.Valid {
+		order.OrderedAt = &orderedAt.Time
+	}
+	if receivedAt.Valid {
+		order.ReceivedAt = &receivedAt.Time
+	}
+	return &order, nil
+}
+
+// GetOrderByID returns the order with its items, or nil when it does not exist.
+func (r *PurchaseOrderRepository) GetOrderByID(id uuid.UUID) (*models.PurchaseOrder, error) {
+	order, err := scanPurchaseOrder(r.db.QueryRow(purchaseOrderQuery+` WHERE po.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get purchase order: %v", err)
+	}
+
+	if order.Items, err = r.getOrderItems(id); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (r *PurchaseOrderRepository) getOrderItems(orderID uuid.UUID) ([]models.PurchaseOrderItem, error) {
+	query := `
+	SELECT i.id, i.food_id, i.medicine_id, COALESCE(f.name, m.name, ''), COALESCE(f.unit_of_measure, m.unit_of_measure, ''),
+	  i.quantity, i.unit_cost, i.received_quantity
+	FROM purchase_order_items i
+	LEFT JOIN foods f ON i.food_id = f.id
+	LEFT JOIN medicines m ON i.medicine_id = m.id
+	WHERE i.order_id = $1
+	ORDER BY 4
+	`
+	rows, err := r.db.Query(query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase order items: %v", err)
+	}
+	defer rows.Close()
+
+	var items []models.PurchaseOrderItem
+	for rows.Next() {
+		var item models.PurchaseOrderItem
+		var foodID, medicineID uuid.NullUUID
+		err := rows.Scan(&item.ID, &foodID, &medicineID, &item.Name, &item.UnitOfMeasure, &item.Quantity, &item.UnitCost,
+			&item.ReceivedQuantity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan purchase order item: %v", err)
+		}
+		// The lines of a purged food or medicine keep their quantity and
+		// cost but no longer reference an item.
+		if foodID.Valid {
+			item.ItemType = models.StockItemFood
+			item.FoodID = &foodID.UUID
+		} else if medicineID.Valid {
+			item.ItemType = models.StockItemMedicine
+			item.MedicineID = &medicineID.UUID
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return items, nil
+}
+
+// GetOrders returns a page of the orders matching the filter, without their
+// items, together with their total number.
+func (r *PurchaseOrderRepository) GetOrders(filter *models.PurchaseOrderFilter, page *models.PageQuery) ([]models.PurchaseOrder, int, error) {
+	q := newListQuery(purchaseOrderColumns, purchaseOrderTables)
+	q.filter(`po.farm_id = ?`, filter.FarmID)
+	if filter.SupplierID != uuid.Nil {
+		q.filter(`po.supplier_id = ?`, filter.SupplierID)
+	}
+	if filter.Status != "" {
+		q.filter(`po.status = ?`, filter.Status)
+	}
+
+	orders, total, err := list(r.db, q, page, sortColumns("po", models.PurchaseOrderSortFields), "po.created_at DESC", "po.id",
+		func(rows *sql.Rows) (models.PurchaseOrder, error) {
+			order, err := scanPurchaseOrder(rows)
+			if err != nil {
+				return models.PurchaseOrder{}, fmt.Errorf("failed to scan purchase order: %v", err)
+			}
+			return *order, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get purchase orders: %v", err)
+	}
+	return orders, total, nil
+}
+
+func (r *PurchaseOrderRepository) GetFarmIDByOrderID(id uuid.UUID) (uuid.UUID, error) {
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(`SELECT farm_id FROM purchase_orders WHERE id = $1`, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrPurchaseOrderNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+// lockOrder locks the order for the rest of the transaction and returns its
+// state before the change. It fails with ErrOrderStatus unless the status of
+// the order is one of allowed.
+func lockOrder(tx *sql.Tx, id uuid.UUID, allowed ...string) (*models.AuditSnapshot, error) {
+	var status string
+	if err := tx.QueryRow(`SELECT status FROM purchase_orders WHERE id = $1 FOR UPDATE`, id).Scan(&status); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	for _, s := range allowed {
+		if status == s {
+			return lockSnapshot(tx, models.AuditPurchaseOrder, id)
+		}
+	}
+	return nil, ErrOrderStatus
+}
+
+// UpdateOrder replaces the supplier, delivery date, notes and items of a
+// draft order.
+func (r *PurchaseOrderRepository) UpdateOrder(id uuid.UUID, details *models.PurchaseOrderDetails, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockOrder(tx, id, models.PurchaseOrderDraft)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE purchase_orders SET supplier_id = $1, expected_delivery = $2, notes = $3 WHERE id = $4`
+	if _, err = tx.Exec(query, details.SupplierID, details.ExpectedDelivery, details.Notes, id); err != nil {
+		return fmt.Errorf("failed to update purchase order: %v", err)
+	}
+
+	if _, err = tx.Exec(`DELETE FROM purchase_order_items WHERE order_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to update purchase order: %v", err)
+	}
+
+	if err = insertOrderItems(tx, id, details.Items); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditUpdate, models.AuditPurchaseOrder, id, before)
+}
+
+// SetStatus moves the order to status, provided its current status is one of
+// from.
+func (r *PurchaseOrderRepository) SetStatus(id uuid.UUID, status string, actor *models.AuditActor, from ...string) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockOrder(tx, id, from...)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	UPDATE purchase_orders
+	SET status = $1, ordered_at = CASE WHEN $1 = 'ordered' THEN CURRENT_TIMESTAMP ELSE ordered_at END
+	WHERE id = $2
+	`
+	if _, err = tx.Exec(query, status, id); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditUpdate, models.AuditPurchaseOrder, id, before)
+}
+
+// DeleteOrder deletes a draft order.
+func (r *PurchaseOrderRepository) DeleteOrder(id uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockOrder(tx, id, models.PurchaseOrderDraft)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(`DELETE FROM purchase_orders WHERE id = $1`, id); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditDelete, models.AuditPurchaseOrder, id, before)
+}
+
+// ReceiveOrder books the delivered quantities as purchases at the unit cost of
+// their order line, in a single transaction. When receipts is empty everything
+// still outstanding is received. The order becomes received once every line
+// is complete and partially received otherwise. Medicine receipts with an
+// expiry date are stocked as batches.
+func (r *PurchaseOrderRepository) ReceiveOrder(id uuid.UUID, receipts []models.ReceiveOrderItemReq,
+	userID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockOrder(tx, id, models.PurchaseOrderOrdered, models.PurchaseOrderPartiallyReceived)
+	if err != nil {
+		return err
+	}
+
+	type orderLine struct {
+		foodID, medicineID uuid.NullUUID
+		outstanding        float64
+		unitCost           float64
+	}
+
+	rows, err := tx.Query(`
+	SELECT id, food_id, medicine_id, quantity - received_quantity, unit_cost
+	FROM purchase_order_items
+	WHERE order_id = $1
+	FOR UPDATE
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to get purchase order items: %v", err)
+	}
+	lines := make(map[uuid.UUID]*orderLine)
+	var lineIDs []uuid.UUID
+	for rows.Next() {
+		var lineID uuid.UUID
+		var line orderLine
+		if err = rows.Scan(&lineID, &line.foodID, &line.medicineID, &line.outstanding, &line.unitCost); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan purchase order item: %v", err)
+		}
+		lines[lineID] = &line
+		lineIDs = append(lineIDs, lineID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	if len(receipts) == 0 {
+		for _, lineID := range lineIDs {
+			if lines[lineID].outstanding > receiveTolerance {
+				receipts = append(receipts, models.ReceiveOrderItemReq{ItemID: lineID, Quantity: lines[lineID].outstanding})
+			}
+		}
+	}
+
+	for _, receipt := range receipts {
+		line, ok := lines[receipt.ItemID]
+		if !ok {
+			return ErrOrderItemNotFound
+		}
+		if receipt.Quantity > line.outstanding+receiveTolerance {
+			return ErrReceiveExceedsOrdered
+		}
+
+		movement := models.StockMovement{
+			ItemType:     models.StockItemFood,
+			ItemID:       line.foodID.UUID,
+			MovementType: models.MovementPurchase,
+			Quantity:     receipt.Quantity,
+			UnitCost:     &line.unitCost,
+			ReferenceID:  &id,
+			Notes:        "purchase order received",
+			CreatedBy:    &userID,
+		}
+		if line.medicineID.Valid {
+			movement.ItemType = models.StockItemMedicine
+			movement.ItemID = line.medicineID.UUID
+		}
+		if err = applyStockMovement(tx, actor, &movement); err != nil {
+			return err
+		}
+
+		if line.medicineID.Valid && receipt.ExpiryDate != nil {
+			batch := models.MedicineBatch{
+				ID:              uuid.New(),
+				MedicineID:      line.medicineID.UUID,
+				LotNumber:       receipt.LotNumber,
+				ExpiryDate:      *receipt.ExpiryDate,
+				InitialQuantity: receipt.Quantity,
+			}
+			if err = insertMedicineBatch(tx, actor, &batch); err != nil {
+				return err
+			}
+		}
+
+		line.outstanding -= receipt.Quantity
+		query := `UPDATE purchase_order_items SET received_quantity = received_quantity + $1 WHERE id = $2`
+		if _, err = tx.Exec(query, receipt.Quantity, receipt.ItemID); err != nil {
+			return fmt.Errorf("failed to update purchase order item: %v", err)
+		}
+	}
+
+	status := models.PurchaseOrderReceived
+	for _, line := range lines {
+		if line.outstanding > receiveTolerance {
+			status = models.PurchaseOrderPartiallyReceived
+			break
+		}
+	}
+
+	query := `
+	UPDATE purchase_orders
+	SET status = $1, received_at = CASE WHEN $1 = 'received' THEN CURRENT_TIMESTAMP ELSE received_at END
+	WHERE id = $2
+	`
+	if _, err = tx.Exec(query, status, id); err != nil {
+		return fmt.Errorf("failed to update purchase order: %v", err)
+	}
+
+	return audit(tx, actor, models.AuditUpdate, models.AuditPurchaseOrder, id, before)
+}
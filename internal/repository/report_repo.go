@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"farmish/internal/models"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type ReportRepository struct {
+	db *sql.DB
+}
+
+func NewReportRepository(db *sql.DB) *ReportRepository {
+	return &ReportRepository{db: db}
+}
+
+// feedingGroupColumns maps a grouping to the ID and name columns it groups by.
+var feedingGroupColumns = map[string]string{
+	models.ReportGroupFarm:   `fr.farm_id::text, ''`,
+	models.ReportGroupAnimal: `a.id::text, COALESCE(a.name, '')`,
+	models.ReportGroupType:   `a.type, a.type`,
+	models.ReportGroupFood:   `f.id::text, f.name`,
+}
+
+// feedingConditions appends the optional animal, type and food filters.
+func feedingConditions(filter *models.ReportFilter, args []interface{}) (string, []interface{}) {
+	var conditions string
+	if filter.AnimalID != uuid.Nil {
+		args = append(args, filter.AnimalID)
+		conditions += ` AND a.id = $` + strconv.Itoa(len(args))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions += ` AND a.type = $` + strconv.Itoa(len(args))
+	}
+	if filter.FoodID != uuid.Nil {
+		args = append(args, filter.FoodID)
+		conditions += ` AND fr.food_id = $` + strconv.Itoa(len(args))
+	}
+	return conditions, args
+}
+
+// GetFeedingConsumption sums the feed consumed per period and group.
+func (r *ReportRepository) GetFeedingConsumption(filter *models.ReportFilter) ([]models.FeedingReportRow, error) {
+	groupColumns, ok := feedingGroupColumns[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown grouping %q", filter.GroupBy)
+	}
+
+	args := []interface{}{filter.FarmID, filter.Interval, filter.From, filter.To}
+	conditions, args := feedingConditions(filter, args)
+
+	query := `
+	SELECT date_trunc($2, fr.fed_at) AS period, ` + groupColumns + `, f.unit_of_measure, SUM(fr.quantity), COUNT(*)
+	FROM feeding_records fr
+	INNER JOIN animals a ON fr.animal_id = a.id
+	INNER JOIN foods f ON fr.food_id = f.id
+	WHERE fr.farm_id = $1 AND fr.deleted_at IS NULL AND fr.fed_at >= $3 AND fr.fed_at < $4` + conditions + `
+	GROUP BY 1, 2, 3, 4
+	ORDER BY 1, 3, 4
+	`
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feeding consumption: %v", err)
+	}
+	defer rows.Close()
+
+	var report []models.FeedingReportRow
+	for rows.Next() {
+		var row models.FeedingReportRow
+		err := rows.Scan(&row.PeriodStart, &row.GroupID, &row.GroupName, &row.UnitOfMeasure, &row.Quantity, &row.Feedings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feeding consumption: %v", err)
+		}
+		report = append(report, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return report, nil
+}
+
+// GetFeedGains returns, for every animal of the farm matching the filter, the
+// feed consumed and the first and last weight measured in the period.
+func (r *ReportRepository) GetFeedGains(filter *models.ReportFilter) ([]models.AnimalFeedGain, error) {
+	args := []interface{}{filter.FarmID, filter.From, filter.To}
+
+	var foodCondition string
+	if filter.FoodID != uuid.Nil {
+		args = append(args, filter.FoodID)
+		foodCondition = ` AND food_id = $` + strconv.Itoa(len(args))
+	}
+
+	query := `
+	SELECT a.id, COALESCE(a.name, ''), a.type, COALESCE(fr.quantity, 0),
+	  COALESCE(wm.first_weight, 0), COALESCE(wm.last_weight, 0), COALESCE(wm.measurements, 0)
+	FROM animals a
+	LEFT JOIN (
+	  SELECT animal_id, SUM(quantity) AS quantity
+	  FROM feeding_records
+	  WHERE farm_id = $1 AND deleted_at IS NULL AND fed_at >= $2 AND fed_at < $3` + foodCondition + `
+	  GROUP BY animal_id
+	) fr ON fr.animal_id = a.id
+	LEFT JOIN (
+	  SELECT animal_id,
+	    (ARRAY_AGG(weight ORDER BY measured_at))[1] AS first_weight,
+	    (ARRAY_AGG(weight ORDER BY measured_at DESC))[1] AS last_weight,
+	    COUNT(*) AS measurements
+	  FROM weight_measurements
+	  WHERE measured_at >= $2 AND measured_at < $3
+	  GROUP BY animal_id
+	) wm ON wm.animal_id = a.id
+	WHERE a.farm_id = $1 AND a.deleted_at IS NULL`
+
+	if filter.AnimalID != uuid.Nil {
+		args = append(args, filter.AnimalID)
+		query += ` AND a.id = $` + strconv.Itoa(len(args))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		query += ` AND a.type = $` + strconv.Itoa(len(args))
+	}
+	query += ` ORDER BY a.type, a.name`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed gains: %v", err)
+	}
+	defer rows.Close()
+
+	var gains []models.AnimalFeedGain
+	for rows.Next() {
+		var gain models.AnimalFeedGain
+		err := rows.Scan(&gain.AnimalID, &gain.AnimalName, &gain.AnimalType, &gain.FeedConsumed,
+			&gain.FirstWeight, &gain.LastWeight, &gain.Measurements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan feed gain: %v", err)
+		}
+		gains = append(gains, gain)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return gains, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"farmish/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type SessionRepository struct {
+	DB *sql.DB
+}
+
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{DB: db}
+}
+
+var ErrRefreshTokenReused = errors.New("refresh token has already been used")
+
+// CreateSession starts a new session together with its first refresh token.
+func (r *SessionRepository) CreateSession(session *models.Session, token *models.RefreshToken) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	sessionQuery := `INSERT INTO sessions (id, user_id) VALUES ($1, $2)`
+	if _, err = tx.Exec(sessionQuery, session.ID, session.UserID); err != nil {
+		return fmt.Errorf("failed to create session: %v", err)
+	}
+
+	if err = insertRefreshToken(tx, token); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func insertRefreshToken(tx *sql.Tx, token *models.RefreshToken) error {
+	query := `
+        INSERT INTO refresh_tokens (id, session_id, token_hash, expires_at)
+        VALUES ($1, $2, $3, $4)
+    `
+	_, err := tx.Exec(query, token.ID, token.SessionID, token.TokenHash, token.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %v", err)
+	}
+	return nil
+}
+
+func (r *SessionRepository) GetRefreshTokenByHash(tokenHash string) (*models.RefreshToken, error) {
+	query := `
+        SELECT rt.id, rt.session_id, s.user_id, rt.token_hash, rt.expires_at, rt.used_at, s.revoked_at IS NOT NULL
+        FROM refresh_tokens rt
+        INNER JOIN sessions s ON rt.session_id = s.id
+        WHERE rt.token_hash = $1
+    `
+
+	var token models.RefreshToken
+	var usedAt sql.NullTime
+	err := r.DB.QueryRow(query, tokenHash).Scan(&token.ID, &token.SessionID, &token.UserID, &token.TokenHash,
+		&token.ExpiresAt, &usedAt, &token.SessionRevoked)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %v", err)
+	}
+	if usedAt.Valid {
+		token.UsedAt = &usedAt.Time
+	}
+
+	return &token, nil
+}
+
+// RotateRefreshToken marks the presented token as used and stores its
+// replacement. ErrRefreshTokenReused is returned when another request already
+// consumed the presented token.
+func (r *SessionRepository) RotateRefreshToken(usedTokenID uuid.UUID, next *models.RefreshToken) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `UPDATE refresh_tokens SET used_at = CURRENT_TIMESTAMP WHERE id = $1 AND used_at IS NULL`
+	result, err := tx.Exec(query, usedTokenID)
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrRefreshTokenReused
+	}
+
+	if err = insertRefreshToken(tx, next); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *SessionRepository) IsSessionActive(sessionID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM sessions WHERE id = $1 AND revoked_at IS NULL)`
+
+	var active bool
+	if err := r.DB.QueryRow(query, sessionID).Scan(&active); err != nil {
+		return false, fmt.Errorf("failed to check session: %v", err)
+	}
+
+	return active, nil
+}
+
+func (r *SessionRepository) RevokeSession(sessionID uuid.UUID) error {
+	query := `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL`
+	_, err := r.DB.Exec(query, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke session: %v", err)
+	}
+	return nil
+}
+
+const revokeUserSessionsQuery = `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL`
+
+func (r *SessionRepository) RevokeUserSessions(userID uuid.UUID) error {
+	_, err := r.DB.Exec(revokeUserSessionsQuery, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke sessions: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockMovementRepository struct {
+	DB *sql.DB
+}
+
+func NewStockMovementRepository(db *sql.DB) *StockMovementRepository {
+	return &StockMovementRepository{DB: db}
+}
+
+var (
+	ErrStockItemNotFound    = errors.New("stock item not found")
+	ErrInsufficientQuantity = errors.New("insufficient quantity in stock")
+)
+
+// reconciliationTolerance absorbs floating point noise when comparing the
+// stored quantity with the ledger total.
+const reconciliationTolerance = 1e-6
+
+// stockItemColumns returns the table holding the item and the stock_movements
+// column referencing it.
+func stockItemColumns(itemType string) (table, column string) {
+	if itemType == models.StockItemMedicine {
+		return "medicines", "medicine_id"
+	}
+	return "foods", "food_id"
+}
+
+// applyStockMovement changes the quantity of the item by movement.Quantity and
+// appends the matching ledger entry. Every change of a food or medicine
+// quantity must go through here so the ledger stays complete. A movement that
+// would take the stock below zero fails with ErrInsufficientQuantity. Items in
+// the trash can neither be consumed nor received into; they fail with
+// ErrStockItemNotFound. The change of the item is audited under the actor.
+func applyStockMovement(tx *sql.Tx, actor *models.AuditActor, movement *models.StockMovement) error {
+	return moveStock(tx, actor, movement, false)
+}
+
+// applyRecordCorrection books the stock difference of a feeding or medical
+// record that is updated or deleted. Unlike applyStockMovement, it also
+// applies to items in the trash: the record was taken while the item was in
+// use, so it can still be corrected or removed after the item was deleted.
+func applyRecordCorrection(tx *sql.Tx, actor *models.AuditActor, movement *models.StockMovement) error {
+	return moveStock(tx, actor, movement, true)
+}
+
+func moveStock(tx *sql.Tx, actor *models.AuditActor, movement *models.StockMovement, includeTrashed bool) error {
+	table, column := stockItemColumns(movement.ItemType)
+	live := ` AND deleted_at IS NULL`
+	if includeTrashed {
+		live = ``
+	}
+	entityType := models.AuditFood
+	if movement.ItemType == models.StockItemMedicine {
+		entityType = models.AuditMedicine
+	}
+
+	before, err := lockSnapshot(tx, entityType, movement.ItemID)
+	if err != nil {
+		return err
+	}
+
+	updateQuery := `UPDATE ` + table + ` SET quantity = quantity + $1 WHERE id = $2` + live + ` AND quantity + $1 >= 0 RETURNING farm_id, quantity`
+	err = tx.QueryRow(updateQuery, movement.Quantity, movement.ItemID).Scan(&movement.FarmID, &movement.BalanceAfter)
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM `+table+` WHERE id = $1`+live+`)`, movement.ItemID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check stock item: %v", err)
+		}
+		if exists {
+			return ErrInsufficientQuantity
+		}
+		return ErrStockItemNotFound
+	} else if err != nil {
+		return fmt.Errorf("failed to update stock quantity: %v", err)
+	}
+
+	movement.ID = uuid.New()
+	insertQuery := `
+	INSERT INTO stock_movements (id, farm_id, ` + column + `, movement_type, quantity, balance_after, unit_cost, reference_id, notes, created_by)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	RETURNING created_at
+	`
+	err = tx.QueryRow(insertQuery, movement.ID, movement.FarmID, movement.ItemID, movement.MovementType, movement.Quantity,
+		movement.BalanceAfter, movement.UnitCost, nullUUID(movement.ReferenceID), movement.Notes, nullUUID(movement.CreatedBy)).Scan(&movement.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record stock movement: %v", err)
+	}
+
+	return audit(tx, actor, models.AuditUpdate, entityType, movement.ItemID, before)
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func (r *StockMovementRepository) RecordMovement(movement *models.StockMovement, actor *models.AuditActor) (err error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if err = applyStockMovement(tx, actor, movement); err != nil {
+		return err
+	}
+	if movement.ItemType == models.StockItemMedicine && movement.Quantity < 0 {
+		return drawDownBatches(tx, actor, movement.ItemID, -movement.Quantity)
+	}
+	return nil
+}
+
+func (r *StockMovementRepository) GetMovements(itemType string, itemID uuid.UUID, page *models.PageQuery) ([]models.StockMovement, int, error) {
+	_, column := stockItemColumns(itemType)
+	q := newListQuery(`sm.id, sm.farm_id, sm.movement_type, sm.quantity, sm.balance_after, sm.unit_cost, sm.reference_id,
+	  COALESCE(sm.notes, ''), sm.created_by, sm.created_at`, `stock_movements sm`)
+	q.filter(`sm.`+column+` = ?`, itemID)
+
+	movements, total, err := list(r.DB, q, page, sortColumns("sm", models.MovementSortFields), "sm.created_at DESC", "sm.id",
+		func(rows *sql.Rows) (models.StockMovement, error) {
+			var movement models.StockMovement
+			var referenceID, createdBy uuid.NullUUID
+			var unitCost sql.NullFloat64
+			err := rows.Scan(&movement.ID, &movement.FarmID, &movement.MovementType, &movement.Quantity, &movement.BalanceAfter,
+				&unitCost, &referenceID, &movement.Notes, &createdBy, &movement.CreatedAt)
+			if err != nil {
+				return movement, fmt.Errorf("failed to scan stock movement: %v", err)
+			}
+			movement.ItemType = itemType
+			movement.ItemID = itemID
+			if unitCost.Valid {
+				movement.UnitCost = &unitCost.Float64
+			}
+			if referenceID.Valid {
+				movement.ReferenceID = &referenceID.UUID
+			}
+			if createdBy.Valid {
+				movement.CreatedBy = &createdBy.UUID
+			}
+			return movement, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get stock movements: %v", err)
+	}
+	return movements, total, nil
+}
+
+func (r *StockMovementRepository) GetReconciliation(itemType string, itemID uuid.UUID) (*models.StockReconciliation, error) {
+	table, column := stockItemColumns(itemType)
+	query := `
+	SELECT i.id, i.name, i.quantity, COALESCE(SUM(sm.quantity), 0)
+	FROM ` + table + ` i
+	LEFT JOIN stock_movements sm ON sm.` + column + ` = i.id
+	WHERE i.id = $1
+	GROUP BY i.id, i.name, i.quantity
+	`
+	reconciliation := models.StockReconciliation{ItemType: itemType}
+	err := r.DB.QueryRow(query, itemID).Scan(&reconciliation.ItemID, &reconciliation.Name, &reconciliation.Quantity, &reconciliation.LedgerTotal)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrStockItemNotFound
+		}
+		return nil, fmt.Errorf("failed to reconcile stock: %v", err)
+	}
+	reconcile(&reconciliation)
+
+	return &reconciliation, nil
+}
+
+// GetFarmReconciliation reconciles every food and medicine of the farm.
+func (r *StockMovementRepository) GetFarmReconciliation(farmID uuid.UUID) ([]models.StockReconciliation, error) {
+	query := `
+	SELECT 'food', f.id, f.name, f.quantity, COALESCE(SUM(sm.quantity), 0)
+	FROM foods f
+	LEFT JOIN stock_movements sm ON sm.food_id = f.id
+	WHERE f.farm_id = $1 AND f.deleted_at IS NULL
+	GROUP BY f.id, f.name, f.quantity
+	UNION ALL
+	SELECT 'medicine', m.id, m.name, m.quantity, COALESCE(SUM(sm.quantity), 0)
+	FROM medicines m
+	LEFT JOIN stock_movements sm ON sm.medicine_id = m.id
+	WHERE m.farm_id = $1 AND m.deleted_at IS NULL
+	GROUP BY m.id, m.name, m.quantity
+	`
+	rows, err := r.DB.Query(query, farmID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile farm stock: %v", err)
+	}
+	defer rows.Close()
+
+	var reconciliations []models.StockReconciliation
+	for rows.Next() {
+		var reconciliation models.StockReconciliation
+		err := rows.Scan(&reconciliation.ItemType, &reconciliation.ItemID, &reconciliation.Name, &reconciliation.Quantity, &reconciliation.LedgerTotal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock reconciliation: %v", err)
+		}
+		reconcile(&reconciliation)
+		reconciliations = append(reconciliations, reconciliation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return reconciliations, nil
+}
+
+func reconcile(reconciliation *models.StockReconciliation) {
+	reconciliation.Difference = reconciliation.Quantity - reconciliation.LedgerTotal
+	reconciliation.Consistent = math.Abs(reconciliation.Difference) < reconciliationTolerance
+}
+
+// GetStockUsage returns every food and medicine of the farm with its average
+// daily consumption since the given time and its planned daily consumption:
+// active feeding schedules for foods, and pending treatment events due within
+// the next windowDays for medicines.
+func (r *StockMovementRepository) GetStockUsage(farmID uuid.UUID, since time.Time, windowDays int) ([]models.StockUsage, error) {
+	query := `
+	SELECT 'food', f.id, f.name, f.unit_of_measure, f.quantity, f.min_threshold,
+	  COALESCE((
+	    SELECT -SUM(sm.quantity) FROM stock_movements sm
+	    WHERE sm.food_id = f.id AND sm.movement_type = 'consumption' AND sm.created_at >= $2
+	  ), 0) / $3::int,
+	  COALESCE((
+	    SELECT SUM(s.quantity * cardinality(s.times_of_day)
+	      * CASE WHEN cardinality(s.days_of_week) = 0 THEN 7 ELSE cardinality(s.days_of_week) END / 7.0
+	      * CASE WHEN s.animal_id IS NOT NULL THEN 1
+	        ELSE (SELECT COUNT(*) FROM animals a WHERE a.farm_id = s.farm_id AND a.type = s.animal_type AND a.status = 'active'
+	          AND a.deleted_at IS NULL) END)
+	    FROM feeding_schedules s
+	    WHERE s.food_id = f.id AND s.active
+	  ), 0)
+	FROM foods f
+	WHERE f.farm_id = $1 AND f.deleted_at IS NULL
+	UNION ALL
+	SELECT 'medicine', m.id, m.name, m.unit_of_measure, m.quantity, m.min_threshold,
+	  COALESCE((
+	    SELECT -SUM(sm.quantity) FROM stock_movements sm
+	    WHERE sm.medicine_id = m.id AND sm.movement_type = 'consumption' AND sm.created_at >= $2
+	  ), 0) / $3::int,
+	  COALESCE((
+	    SELECT SUM(p.quantity) FROM treatment_events e
+	    INNER JOIN treatment_plans p ON e.plan_id = p.id
+	    WHERE p.medicine_id = m.id AND e.status = 'pending' AND e.due_date < CURRENT_DATE + $3::int
+	  ), 0) / $3::int
+	FROM medicines m
+	WHERE m.farm_id = $1 AND m.deleted_at IS NULL
+	ORDER BY 1, 3
+	`
+	rows, err := r.DB.Query(query, farmID, since, windowDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock usage: %v", err)
+	}
+	defer rows.Close()
+
+	var usages []models.StockUsage
+	for rows.Next() {
+		var usage models.StockUsage
+		err := rows.Scan(&usage.ItemType, &usage.ItemID, &usage.Name, &usage.UnitOfMeasure, &usage.Quantity, &usage.MinThreshold,
+			&usage.HistoricalDailyUse, &usage.PlannedDailyUse)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock usage: %v", err)
+		}
+		usages = append(usages, usage)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return usages, nil
+}
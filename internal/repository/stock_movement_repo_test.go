@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type stockFixture struct {
+	farmID     uuid.UUID
+	animalID   uuid.UUID
+	foodID     uuid.UUID
+	medicineID uuid.UUID
+	batchID    uuid.UUID
+}
+
+// seedStock creates a farm with an animal, and a food and a medicine holding
+// quantity each. The medicine is held in a single unexpired batch.
+func seedStock(t *testing.T, db *sql.DB, quantity float64) stockFixture {
+	t.Helper()
+	f := stockFixture{
+		farmID:     uuid.New(),
+		animalID:   uuid.New(),
+		foodID:     uuid.New(),
+		medicineID: uuid.New(),
+		batchID:    uuid.New(),
+	}
+	userID := uuid.New()
+
+	mustExec(t, db, `INSERT INTO users (id, name, email, phone_number, password_hash) VALUES ($1, 'Owner', 'owner@example.com', '+10000000000', 'x')`, userID)
+	mustExec(t, db, `INSERT INTO farms (id, name, location, owner_id) VALUES ($1, 'Farm', 'Somewhere', $2)`, f.farmID, userID)
+	mustExec(t, db, `INSERT INTO animals (id, farm_id, name, type, weight) VALUES ($1, $2, 'Daisy', 'cow', 500)`, f.animalID, f.farmID)
+	mustExec(t, db, `INSERT INTO foods (id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold)
+		VALUES ($1, $2, 'Hay', '{cow}', 'kg', $3, 0)`, f.foodID, f.farmID, quantity)
+	mustExec(t, db, `INSERT INTO medicines (id, farm_id, name, suitable_for, unit_of_measure, quantity, min_threshold)
+		VALUES ($1, $2, 'Wormer', '{cow}', 'ml', $3, 0)`, f.medicineID, f.farmID, quantity)
+	mustExec(t, db, `INSERT INTO medicine_batches (id, medicine_id, lot_number, expiry_date, initial_quantity, quantity)
+		VALUES ($1, $2, 'LOT-1', $3, $4, $4)`, f.batchID, f.medicineID, time.Now().AddDate(1, 0, 0), quantity)
+
+	return f
+}
+
+// TestConcurrentConsumption runs more feedings and treatments at once than the
+// stock can cover. Every successful consumption must be booked exactly once and
+// every other one must fail with ErrInsufficientQuantity, never overdrawing.
+func TestConcurrentConsumption(t *testing.T) {
+	db := openTestDB(t)
+
+	const stock, attempts, dose = 10.0, 25, 1.0
+	f := seedStock(t, db, stock)
+	feedingRepo := NewFeedingRecordRepository(db)
+	medicalRepo := NewMedicalRecordRepository(db)
+
+	var (
+		mu                        sync.Mutex
+		fed, treated              int
+		feedRefused, treatRefused int
+		unexpected                []error
+	)
+	count := func(err error, succeeded, refused *int) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err == nil:
+			*succeeded++
+		case errors.Is(err, ErrInsufficientQuantity):
+			*refused++
+		default:
+			unexpected = append(unexpected, err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			record := &models.FeedingRecordWithoutTime{ID: uuid.New()}
+			record.AnimalID = f.animalID
+			record.FoodID = f.foodID
+			record.Quantity = dose
+			record.FedAt = time.Now()
+			_, err := feedingRepo.CreateFeedingRecord(record, testActor)
+			count(err, &fed, &feedRefused)
+		}()
+		go func() {
+			defer wg.Done()
+			record := &models.MedicalRecordWithoutTime{ID: uuid.New()}
+			record.AnimalID = f.animalID
+			record.MedicineID = f.medicineID
+			record.Quantity = dose
+			record.TreatmentDate = time.Now()
+			_, err := medicalRepo.CreateMedicalRecord(record, testActor)
+			count(err, &treated, &treatRefused)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range unexpected {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	checkConsumption(t, db, "foods", "food_id", "feeding_records", f.foodID, stock, dose, fed, feedRefused, attempts)
+	checkConsumption(t, db, "medicines", "medicine_id", "medical_records", f.medicineID, stock, dose, treated, treatRefused, attempts)
+
+	var batchQuantity float64
+	if err := db.QueryRow(`SELECT quantity FROM medicine_batches WHERE id = $1`, f.batchID).Scan(&batchQuantity); err != nil {
+		t.Fatalf("failed to read batch: %v", err)
+	}
+	if want := stock - float64(treated)*dose; math.Abs(batchQuantity-want) > reconciliationTolerance {
+		t.Errorf("batch quantity = %v, want %v", batchQuantity, want)
+	}
+}
+
+// checkConsumption compares the final quantity, the records and the ledger of
+// an item with the number of consumptions that succeeded.
+func checkConsumption(t *testing.T, db *sql.DB, table, column, recordTable string, itemID uuid.UUID,
+	stock, dose float64, succeeded, refused, attempts int) {
+	t.Helper()
+
+	if want := int(stock / dose); succeeded != want {
+		t.Errorf("%s: %d consumptions succeeded, want %d", table, succeeded, want)
+	}
+	if succeeded+refused != attempts {
+		t.Errorf("%s: %d consumptions refused, want %d", table, refused, attempts-succeeded)
+	}
+
+	var quantity float64
+	if err := db.QueryRow(`SELECT quantity FROM `+table+` WHERE id = $1`, itemID).Scan(&quantity); err != nil {
+		t.Fatalf("%s: failed to read quantity: %v", table, err)
+	}
+	if want := stock - float64(succeeded)*dose; math.Abs(quantity-want) > reconciliationTolerance {
+		t.Errorf("%s: final quantity = %v, want %v", table, quantity, want)
+	}
+	if quantity < 0 {
+		t.Errorf("%s: stock overdrawn to %v", table, quantity)
+	}
+
+	var records int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM `+recordTable+` WHERE `+column+` = $1`, itemID).Scan(&records); err != nil {
+		t.Fatalf("%s: failed to count records: %v", recordTable, err)
+	}
+	if records != succeeded {
+		t.Errorf("%s: %d records stored, want %d", recordTable, records, succeeded)
+	}
+
+	var ledger float64
+	if err := db.QueryRow(`SELECT COALESCE(SUM(quantity), 0) FROM stock_movements WHERE `+column+` = $1`, itemID).Scan(&ledger); err != nil {
+		t.Fatalf("%s: failed to sum ledger: %v", table, err)
+	}
+	if want := -float64(succeeded) * dose; math.Abs(ledger-want) > reconciliationTolerance {
+		t.Errorf("%s: ledger total = %v, want %v", table, ledger, want)
+	}
+}
+
+// TestWasteDrawsDownBatches throws away part of a batched medicine. The batch
+// must shrink with it, so the remaining stock can still be used for treatments.
+func TestWasteDrawsDownBatches(t *testing.T) {
+	db := openTestDB(t)
+
+	f := seedStock(t, db, 10)
+	movementRepo := NewStockMovementRepository(db)
+	medicalRepo := NewMedicalRecordRepository(db)
+
+	err := movementRepo.RecordMovement(&models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       f.medicineID,
+		MovementType: models.MovementWaste,
+		Quantity:     -4,
+	}, testActor)
+	if err != nil {
+		t.Fatalf("failed to record waste: %v", err)
+	}
+
+	record := &models.MedicalRecordWithoutTime{ID: uuid.New()}
+	record.AnimalID = f.animalID
+	record.MedicineID = f.medicineID
+	record.Quantity = 6
+	record.TreatmentDate = time.Now()
+	if _, err := medicalRepo.CreateMedicalRecord(record, testActor); err != nil {
+		t.Fatalf("treatment after waste failed: %v", err)
+	}
+
+	var batchQuantity float64
+	if err := db.QueryRow(`SELECT quantity FROM medicine_batches WHERE id = $1`, f.batchID).Scan(&batchQuantity); err != nil {
+		t.Fatalf("failed to read batch: %v", err)
+	}
+	if math.Abs(batchQuantity) > reconciliationTolerance {
+		t.Errorf("batch quantity = %v, want 0", batchQuantity)
+	}
+}
+
+// TestTrashedStockIsUntouched moves a food to the trash. Neither a feeding nor
+// a delivery may change its quantity any more, but a record taken before the
+// food was deleted can still be removed and gives its quantity back.
+func TestTrashedStockIsUntouched(t *testing.T) {
+	db := openTestDB(t)
+
+	f := seedStock(t, db, 10)
+	feedingRepo := NewFeedingRecordRepository(db)
+	earlier := &models.FeedingRecordWithoutTime{ID: uuid.New()}
+	earlier.AnimalID = f.animalID
+	earlier.FoodID = f.foodID
+	earlier.Quantity = 2
+	earlier.FedAt = time.Now()
+	if _, err := feedingRepo.CreateFeedingRecord(earlier, testActor); err != nil {
+		t.Fatalf("failed to create feeding record: %v", err)
+	}
+	if err := NewFoodRepository(db).DeleteFood(f.foodID, testActor); err != nil {
+		t.Fatalf("failed to delete food: %v", err)
+	}
+
+	record := &models.FeedingRecordWithoutTime{ID: uuid.New()}
+	record.AnimalID = f.animalID
+	record.FoodID = f.foodID
+	record.Quantity = 1
+	record.FedAt = time.Now()
+	if _, err := feedingRepo.CreateFeedingRecord(record, testActor); !errors.Is(err, ErrStockItemNotFound) {
+		t.Errorf("feeding from trashed food: got %v, want %v", err, ErrStockItemNotFound)
+	}
+
+	err := NewStockMovementRepository(db).RecordMovement(&models.StockMovement{
+		ItemType:     models.StockItemFood,
+		ItemID:       f.foodID,
+		MovementType: models.MovementPurchase,
+		Quantity:     5,
+	}, testActor)
+	if !errors.Is(err, ErrStockItemNotFound) {
+		t.Errorf("delivery into trashed food: got %v, want %v", err, ErrStockItemNotFound)
+	}
+
+	checkQuantity := func(want float64) {
+		t.Helper()
+		var quantity float64
+		if err := db.QueryRow(`SELECT quantity FROM foods WHERE id = $1`, f.foodID).Scan(&quantity); err != nil {
+			t.Fatalf("failed to read quantity: %v", err)
+		}
+		if quantity != want {
+			t.Errorf("quantity = %v, want %v", quantity, want)
+		}
+	}
+	checkQuantity(8)
+
+	if err := feedingRepo.DeleteFeedingRecord(earlier.ID, testActor); err != nil {
+		t.Errorf("deleting a record of trashed food: %v", err)
+	}
+	checkQuantity(10)
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type SupplierRepository struct {
+	db *sql.DB
+}
+
+func NewSupplierRepository(db *sql.DB) *SupplierRepository {
+	return &SupplierRepository{db: db}
+}
+
+var (
+	ErrSupplierNotFound = errors.New("supplier not found")
+	ErrSupplierInUse    = errors.New("supplier has purchase orders and cannot be deleted")
+)
+
+const supplierColumns = `id, farm_id, name, COALESCE(contact_name, ''), COALESCE(phone_number, ''), COALESCE(email, ''),
+	COALESCE(address, ''), COALESCE(notes, ''), created_at`
+
+func scanSupplier(scanner interface{ Scan(...interface{}) error }) (*models.Supplier, error) {
+	var supplier models.Supplier
+	err := scanner.Scan(&supplier.ID, &supplier.FarmID, &supplier.Name, &supplier.ContactName, &supplier.PhoneNumber,
+		&supplier.Email, &supplier.Address, &supplier.Notes, &supplier.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+func (r *SupplierRepository) CreateSupplier(supplier *models.Supplier, actor *models.AuditActor) error {
+	query := `
+	INSERT INTO suppliers (id, farm_id, name, contact_name, phone_number, email, address, notes)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	RETURNING created_at
+	`
+	err := auditedChange(r.db, actor, models.AuditCreate, models.AuditSupplier, supplier.ID, func(tx *sql.Tx) error {
+		return tx.QueryRow(query, supplier.ID, supplier.FarmID, supplier.Name, supplier.ContactName, supplier.PhoneNumber,
+			supplier.Email, supplier.Address, supplier.Notes).Scan(&supplier.CreatedAt)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create supplier: %v", err)
+	}
+	return nil
+}
+
+func (r *SupplierRepository) GetSupplierByID(id uuid.UUID) (*models.Supplier, error) {
+	query := `SELECT ` + supplierColumns + ` FROM suppliers WHERE id = $1`
+	supplier, err := scanSupplier(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get supplier: %v", err)
+	}
+	return supplier, nil
+}
+
+// GetSuppliersByFarmID returns a page of the suppliers of the farm, together
+// with their total number.
+func (r *SupplierRepository) GetSuppliersByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.Supplier, int, error) {
+	q := newListQuery(supplierColumns, `suppliers s`)
+	q.filter(`s.farm_id = ?`, farmID)
+
+	suppliers, total, err := list(r.db, q, page, sortColumns("s", models.SupplierSortFields), "s.name", "s.id",
+		func(rows *sql.Rows) (models.Supplier, error) {
+			supplier, err := scanSupplier(rows)
+			if err != nil {
+				return models.Supplier{}, fmt.Errorf("failed to scan supplier: %v", err)
+			}
+			return *supplier, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get suppliers: %v", err)
+	}
+	return suppliers, total, nil
+}
+
+func (r *SupplierRepository) GetFarmIDBySupplierID(id uuid.UUID) (uuid.UUID, error) {
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(`SELECT farm_id FROM suppliers WHERE id = $1`, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrSupplierNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+func (r *SupplierRepository) UpdateSupplier(id uuid.UUID, details *models.SupplierDetails, actor *models.AuditActor) error {
+	query := `
+	UPDATE suppliers
+	SET name = $1, contact_name = $2, phone_number = $3, email = $4, address = $5, notes = $6
+	WHERE id = $7
+	`
+	return auditedChange(r.db, actor, models.AuditUpdate, models.AuditSupplier, id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, details.Name, details.ContactName, details.PhoneNumber, details.Email, details.Address,
+			details.Notes, id)
+		if err != nil {
+			return fmt.Errorf("failed to update supplier: %v", err)
+		}
+		return requireRow(result, ErrSupplierNotFound)
+	})
+}
+
+func (r *SupplierRepository) DeleteSupplier(id uuid.UUID, actor *models.AuditActor) error {
+	return auditedChange(r.db, actor, models.AuditDelete, models.AuditSupplier, id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM suppliers WHERE id = $1`, id)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+				return ErrSupplierInUse
+			}
+			return fmt.Errorf("failed to delete supplier: %v", err)
+		}
+		return requireRow(result, ErrSupplierNotFound)
+	})
+}
@@ -0,0 +1,453 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TrashRepository struct {
+	db *sql.DB
+}
+
+func NewTrashRepository(db *sql.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+var (
+	ErrTrashItemNotFound  = errors.New("item not found in trash")
+	ErrTrashParentDeleted = errors.New("the animal this record belongs to is deleted, restore the animal instead")
+)
+
+// animalRecordTables hold the records kept per animal. They are deleted and
+// restored together with their animal.
+var animalRecordTables = []string{"feeding_records", "watering_records", "medical_records", "production_records"}
+
+// trashTables maps each kind of deletable item to its table.
+var trashTables = map[string]string{
+	models.TrashFarm:             "farms",
+	models.TrashAnimal:           "animals",
+	models.TrashFood:             "foods",
+	models.TrashMedicine:         "medicines",
+	models.TrashFeedingRecord:    "feeding_records",
+	models.TrashWateringRecord:   "watering_records",
+	models.TrashMedicalRecord:    "medical_records",
+	models.TrashProductionRecord: "production_records",
+}
+
+// trashAnimals moves the animals matching the condition and their records to
+// the trash. Everything is stamped with the transaction time, so restoring the
+// animals brings back exactly the records deleted with them and not those
+// deleted on their own before.
+func trashAnimals(tx *sql.Tx, condition string, arg interface{}) error {
+	for _, table := range animalRecordTables {
+		query := `
+		UPDATE ` + table + ` SET deleted_at = CURRENT_TIMESTAMP
+		WHERE deleted_at IS NULL AND animal_id IN (SELECT id FROM animals WHERE deleted_at IS NULL AND ` + condition + `)
+		`
+		if _, err := tx.Exec(query, arg); err != nil {
+			return fmt.Errorf("failed to delete %s: %v", table, err)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE animals SET deleted_at = CURRENT_TIMESTAMP WHERE deleted_at IS NULL AND `+condition, arg); err != nil {
+		return fmt.Errorf("failed to delete animals: %v", err)
+	}
+	return nil
+}
+
+// restoreAnimals brings back the animals matching the condition that were
+// deleted at the given time, along with the records deleted with them.
+func restoreAnimals(tx *sql.Tx, condition string, arg interface{}, deletedAt time.Time) error {
+	for _, table := range animalRecordTables {
+		query := `
+		UPDATE ` + table + ` SET deleted_at = NULL
+		WHERE deleted_at = $2 AND animal_id IN (SELECT id FROM animals WHERE deleted_at = $2 AND ` + condition + `)
+		`
+		if _, err := tx.Exec(query, arg, deletedAt); err != nil {
+			return fmt.Errorf("failed to restore %s: %v", table, err)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE animals SET deleted_at = NULL WHERE deleted_at = $2 AND `+condition, arg, deletedAt); err != nil {
+		return fmt.Errorf("failed to restore animals: %v", err)
+	}
+	return nil
+}
+
+// trashFarm moves the farm to the trash with its animals, stock and records.
+func trashFarm(tx *sql.Tx, farmID uuid.UUID) error {
+	if err := trashAnimals(tx, `farm_id = $1`, farmID); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"production_records", "foods", "medicines"} {
+		query := `UPDATE ` + table + ` SET deleted_at = CURRENT_TIMESTAMP WHERE farm_id = $1 AND deleted_at IS NULL`
+		if _, err := tx.Exec(query, farmID); err != nil {
+			return fmt.Errorf("failed to delete %s: %v", table, err)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE farms SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL`, farmID); err != nil {
+		return fmt.Errorf("failed to delete farm: %v", err)
+	}
+	return nil
+}
+
+const trashItemColumns = `t.type, t.id, t.farm_id, t.name, t.deleted_at`
+
+// trashItemTables lists the deleted items of every farm. Records of a deleted
+// animal are left out, as they can only be restored with it.
+const trashItemTables = `(
+	SELECT 'animal' AS type, id, farm_id, COALESCE(name, type) AS name, deleted_at
+	FROM animals
+	WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'food', id, farm_id, name, deleted_at
+	FROM foods
+	WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'medicine', id, farm_id, name, deleted_at
+	FROM medicines
+	WHERE deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'feeding_record', fr.id, COALESCE(fr.farm_id, a.farm_id), COALESCE(a.name, a.type) || ': ' || f.name, fr.deleted_at
+	FROM feeding_records fr
+	INNER JOIN animals a ON fr.animal_id = a.id
+	INNER JOIN foods f ON fr.food_id = f.id
+	WHERE a.deleted_at IS NULL AND fr.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'watering_record', wr.id, COALESCE(wr.farm_id, a.farm_id), COALESCE(a.name, a.type), wr.deleted_at
+	FROM watering_records wr
+	INNER JOIN animals a ON wr.animal_id = a.id
+	WHERE a.deleted_at IS NULL AND wr.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'medical_record', mr.id, COALESCE(mr.farm_id, a.farm_id), COALESCE(a.name, a.type) || ': ' || m.name, mr.deleted_at
+	FROM medical_records mr
+	INNER JOIN animals a ON mr.animal_id = a.id
+	INNER JOIN medicines m ON mr.medicine_id = m.id
+	WHERE a.deleted_at IS NULL AND mr.deleted_at IS NOT NULL
+	UNION ALL
+	SELECT 'production_record', pr.id, COALESCE(pr.farm_id, a.farm_id), COALESCE(a.name, a.type, pr.animal_type) || ': ' || pr.product, pr.deleted_at
+	FROM production_records pr
+	LEFT JOIN animals a ON pr.animal_id = a.id
+	WHERE a.deleted_at IS NULL AND pr.deleted_at IS NOT NULL
+) t`
+
+// GetItems returns a page of the deleted items of the farm, latest first
+// unless sorted otherwise, together with their total number.
+func (r *TrashRepository) GetItems(farmID uuid.UUID, page *models.PageQuery) ([]models.TrashItem, int, error) {
+	q := newListQuery(trashItemColumns, trashItemTables)
+	q.filter(`t.farm_id = ?`, farmID)
+	return r.listItems(q, page)
+}
+
+// GetFarms returns a page of the deleted farms owned by the user, latest first
+// unless sorted otherwise, together with their total number.
+func (r *TrashRepository) GetFarms(ownerID uuid.UUID, page *models.PageQuery) ([]models.TrashItem, int, error) {
+	q := newListQuery(trashItemColumns, `(
+	SELECT 'farm' AS type, id, id AS farm_id, name, deleted_at, owner_id
+	FROM farms
+	WHERE deleted_at IS NOT NULL
+) t`)
+	q.filter(`t.owner_id = ?`, ownerID)
+	return r.listItems(q, page)
+}
+
+func (r *TrashRepository) listItems(q *listQuery, page *models.PageQuery) ([]models.TrashItem, int, error) {
+	items, total, err := list(r.db, q, page, sortColumns("t", models.TrashSortFields), "t.deleted_at DESC", "t.id",
+		func(rows *sql.Rows) (models.TrashItem, error) {
+			var item models.TrashItem
+			if err := rows.Scan(&item.Type, &item.ID, &item.FarmID, &item.Name, &item.DeletedAt); err != nil {
+				return item, fmt.Errorf("failed to scan trash item: %v", err)
+			}
+			return item, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get trash: %v", err)
+	}
+	return items, total, nil
+}
+
+// GetFarmOwnerID returns the owner of a deleted farm.
+func (r *TrashRepository) GetFarmOwnerID(farmID uuid.UUID) (uuid.UUID, error) {
+	var ownerID uuid.UUID
+	err := r.db.QueryRow(`SELECT owner_id FROM farms WHERE id = $1 AND deleted_at IS NOT NULL`, farmID).Scan(&ownerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrTrashItemNotFound
+		}
+		return uuid.Nil, err
+	}
+	return ownerID, nil
+}
+
+// trashFarmQueries return the farm of each kind of deleted item. A record
+// detached from its purged farm belongs to the farm of its animal.
+var trashFarmQueries = map[string]string{
+	models.TrashAnimal:   `SELECT farm_id FROM animals WHERE id = $1 AND deleted_at IS NOT NULL`,
+	models.TrashFood:     `SELECT farm_id FROM foods WHERE id = $1 AND deleted_at IS NOT NULL`,
+	models.TrashMedicine: `SELECT farm_id FROM medicines WHERE id = $1 AND deleted_at IS NOT NULL`,
+	models.TrashFeedingRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id) FROM feeding_records t LEFT JOIN animals a ON t.animal_id = a.id
+	WHERE t.id = $1 AND t.deleted_at IS NOT NULL`,
+	models.TrashWateringRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id) FROM watering_records t LEFT JOIN animals a ON t.animal_id = a.id
+	WHERE t.id = $1 AND t.deleted_at IS NOT NULL`,
+	models.TrashMedicalRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id) FROM medical_records t LEFT JOIN animals a ON t.animal_id = a.id
+	WHERE t.id = $1 AND t.deleted_at IS NOT NULL`,
+	models.TrashProductionRecord: `
+	SELECT COALESCE(t.farm_id, a.farm_id) FROM production_records t LEFT JOIN animals a ON t.animal_id = a.id
+	WHERE t.id = $1 AND t.deleted_at IS NOT NULL`,
+}
+
+// GetFarmIDByItemID returns the farm a deleted item belongs to.
+func (r *TrashRepository) GetFarmIDByItemID(itemType string, id uuid.UUID) (uuid.UUID, error) {
+	query, ok := trashFarmQueries[itemType]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("unknown item type %q", itemType)
+	}
+
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrTrashItemNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+// RestoreItem takes the item out of the trash. Restoring a farm or an animal
+// also restores what was deleted with it. A restored feeding or medical record
+// consumes its quantity from the stock again; the stock movement is returned,
+// or nil for other items. ErrInsufficientQuantity is returned when the stock
+// no longer covers it.
+func (r *TrashRepository) RestoreItem(itemType string, id uuid.UUID, actor *models.AuditActor) (movement *models.StockMovement, err error) {
+	table, ok := trashTables[itemType]
+	if !ok {
+		return nil, fmt.Errorf("unknown item type %q", itemType)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var deletedAt time.Time
+	err = tx.QueryRow(`SELECT deleted_at FROM `+table+` WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id).Scan(&deletedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTrashItemNotFound
+		}
+		return nil, fmt.Errorf("failed to get deleted item: %v", err)
+	}
+
+	before, err := lockSnapshot(tx, itemType, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch itemType {
+	case models.TrashFarm:
+		if err = restoreAnimals(tx, `farm_id = $1`, id, deletedAt); err != nil {
+			return nil, err
+		}
+		for _, table := range []string{"production_records", "foods", "medicines"} {
+			query := `UPDATE ` + table + ` SET deleted_at = NULL WHERE farm_id = $1 AND deleted_at = $2`
+			if _, err = tx.Exec(query, id, deletedAt); err != nil {
+				return nil, fmt.Errorf("failed to restore %s: %v", table, err)
+			}
+		}
+	case models.TrashAnimal:
+		if err = restoreAnimals(tx, `id = $1`, id, deletedAt); err != nil {
+			return nil, err
+		}
+		return nil, audit(tx, actor, models.AuditRestore, itemType, id, before)
+	case models.TrashFeedingRecord, models.TrashWateringRecord, models.TrashMedicalRecord, models.TrashProductionRecord:
+		var animalDeleted bool
+		query := `SELECT EXISTS (SELECT 1 FROM ` + table + ` t INNER JOIN animals a ON t.animal_id = a.id WHERE t.id = $1 AND a.deleted_at IS NOT NULL)`
+		if err = tx.QueryRow(query, id).Scan(&animalDeleted); err != nil {
+			return nil, fmt.Errorf("failed to check animal of record: %v", err)
+		}
+		if animalDeleted {
+			return nil, ErrTrashParentDeleted
+		}
+	}
+
+	if _, err = tx.Exec(`UPDATE `+table+` SET deleted_at = NULL WHERE id = $1`, id); err != nil {
+		return nil, fmt.Errorf("failed to restore item: %v", err)
+	}
+
+	switch itemType {
+	case models.TrashFeedingRecord:
+		movement, err = restoreFeedingRecord(tx, actor, id)
+	case models.TrashWateringRecord:
+		var animalID uuid.UUID
+		if err = tx.QueryRow(`SELECT animal_id FROM watering_records WHERE id = $1`, id).Scan(&animalID); err != nil {
+			return nil, fmt.Errorf("failed to get watering record: %v", err)
+		}
+		err = syncLastWatered(tx, animalID)
+	case models.TrashMedicalRecord:
+		movement, err = restoreMedicalRecord(tx, actor, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return movement, audit(tx, actor, models.AuditRestore, itemType, id, before)
+}
+
+// restoreFeedingRecord books the food of a restored record as consumed again.
+func restoreFeedingRecord(tx *sql.Tx, actor *models.AuditActor, id uuid.UUID) (*models.StockMovement, error) {
+	var animalID, foodID uuid.UUID
+	var quantity float64
+	err := tx.QueryRow(`SELECT animal_id, food_id, quantity FROM feeding_records WHERE id = $1`, id).Scan(&animalID, &foodID, &quantity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feeding record: %v", err)
+	}
+
+	movement := &models.StockMovement{
+		ItemType:     models.StockItemFood,
+		ItemID:       foodID,
+		MovementType: models.MovementConsumption,
+		Quantity:     -quantity,
+		ReferenceID:  &id,
+		Notes:        "feeding record restored",
+	}
+	if err := applyStockMovement(tx, actor, movement); err != nil {
+		return nil, err
+	}
+
+	return movement, syncLastFed(tx, animalID)
+}
+
+// restoreMedicalRecord books the medicine of a restored record as consumed
+// again and takes it out of the batches, first expiring first out.
+func restoreMedicalRecord(tx *sql.Tx, actor *models.AuditActor, id uuid.UUID) (*models.StockMovement, error) {
+	var medicineID uuid.UUID
+	var quantity float64
+	var treatmentDate time.Time
+	err := tx.QueryRow(`SELECT medicine_id, quantity, treatment_date FROM medical_records WHERE id = $1`, id).Scan(&medicineID, &quantity, &treatmentDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get medical record: %v", err)
+	}
+
+	movement := &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       medicineID,
+		MovementType: models.MovementConsumption,
+		Quantity:     -quantity,
+		ReferenceID:  &id,
+		Notes:        "medical record restored",
+	}
+	if err := applyStockMovement(tx, actor, movement); err != nil {
+		return nil, err
+	}
+
+	if _, err := consumeBatches(tx, actor, id, medicineID, quantity, treatmentDate); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// purgeQueries select the items deleted before $1 that are to be removed for
+// good, children first. Foods and medicines still used by a record of an
+// animal that is not purged, such as one transferred to another farm, are
+// kept, and so is their farm. Purging removes only what belongs to the item:
+// the stock movements and purchase order lines of a food or medicine, and the
+// records taken on a farm for an animal that now lives on another, lose their
+// reference to it instead, so that the ledger, the order costs and the history
+// of the animal are kept.
+var purgeQueries = []struct {
+	itemType string
+	query    string
+}{
+	{models.TrashFeedingRecord, `SELECT id FROM feeding_records WHERE deleted_at < $1`},
+	{models.TrashWateringRecord, `SELECT id FROM watering_records WHERE deleted_at < $1`},
+	{models.TrashMedicalRecord, `SELECT id FROM medical_records WHERE deleted_at < $1`},
+	{models.TrashProductionRecord, `SELECT id FROM production_records WHERE deleted_at < $1`},
+	{models.TrashAnimal, `SELECT id FROM animals WHERE deleted_at < $1`},
+	{models.TrashFood, `SELECT id FROM foods f WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM feeding_records WHERE food_id = f.id)`},
+	{models.TrashMedicine, `SELECT id FROM medicines m WHERE deleted_at < $1 AND NOT EXISTS (SELECT 1 FROM medical_records WHERE medicine_id = m.id)`},
+	{models.TrashFarm, `SELECT id FROM farms f WHERE deleted_at < $1
+	  AND NOT EXISTS (SELECT 1 FROM foods WHERE farm_id = f.id)
+	  AND NOT EXISTS (SELECT 1 FROM medicines WHERE farm_id = f.id)
+	  AND NOT EXISTS (SELECT 1 FROM animals WHERE farm_id = f.id)`},
+}
+
+// Purge permanently deletes the items that were moved to the trash before the
+// given time and audits their removal. It returns the number of items removed.
+func (r *TrashRepository) Purge(before time.Time, actor *models.AuditActor) (purged int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	for _, purge := range purgeQueries {
+		ids, err := queryIDs(tx, purge.query, before)
+		if err != nil {
+			return 0, fmt.Errorf("failed to purge trash: %v", err)
+		}
+		for _, id := range ids {
+			if err = purgeItem(tx, actor, purge.itemType, id); err != nil {
+				return 0, err
+			}
+		}
+		purged += int64(len(ids))
+	}
+
+	return purged, nil
+}
+
+// purgeItem permanently deletes a single item of the trash.
+func purgeItem(tx *sql.Tx, actor *models.AuditActor, itemType string, id uuid.UUID) error {
+	before, err := lockSnapshot(tx, itemType, id)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec(`DELETE FROM `+trashTables[itemType]+` WHERE id = $1`, id); err != nil {
+		return fmt.Errorf("failed to purge trash: %v", err)
+	}
+	return audit(tx, actor, models.AuditDelete, itemType, id, before)
+}
+
+// queryIDs returns the IDs the query selects.
+func queryIDs(tx *sql.Tx, query string, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
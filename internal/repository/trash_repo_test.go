@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"farmish/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestPurgeKeepsHistory purges a food that was bought and consumed, then the
+// farm of an animal that was watered there and transferred away. The ledger and
+// the order of the food must survive without it, and the watering record must
+// stay with the animal.
+func TestPurgeKeepsHistory(t *testing.T) {
+	db := openTestDB(t)
+
+	f := seedStock(t, db, 10)
+	trash := NewTrashRepository(db)
+
+	supplierID, orderID := uuid.New(), uuid.New()
+	mustExec(t, db, `INSERT INTO suppliers (id, farm_id, name) VALUES ($1, $2, 'Mill')`, supplierID, f.farmID)
+	mustExec(t, db, `INSERT INTO purchase_orders (id, farm_id, supplier_id, status) VALUES ($1, $2, $3, 'ordered')`,
+		orderID, f.farmID, supplierID)
+	mustExec(t, db, `INSERT INTO purchase_order_items (id, order_id, food_id, quantity, unit_cost) VALUES ($1, $2, $3, 5, 2)`,
+		uuid.New(), orderID, f.foodID)
+	var ownerID uuid.UUID
+	if err := db.QueryRow(`SELECT owner_id FROM farms WHERE id = $1`, f.farmID).Scan(&ownerID); err != nil {
+		t.Fatalf("failed to get farm owner: %v", err)
+	}
+	if err := NewPurchaseOrderRepository(db).ReceiveOrder(orderID, nil, ownerID, testActor); err != nil {
+		t.Fatalf("failed to receive order: %v", err)
+	}
+
+	feeding := &models.FeedingRecordWithoutTime{ID: uuid.New()}
+	feeding.AnimalID = f.animalID
+	feeding.FoodID = f.foodID
+	feeding.Quantity = 1
+	feeding.FedAt = time.Now()
+	if _, err := NewFeedingRecordRepository(db).CreateFeedingRecord(feeding, testActor); err != nil {
+		t.Fatalf("failed to create feeding record: %v", err)
+	}
+	if err := NewFeedingRecordRepository(db).DeleteFeedingRecord(feeding.ID, testActor); err != nil {
+		t.Fatalf("failed to delete feeding record: %v", err)
+	}
+	if err := NewFoodRepository(db).DeleteFood(f.foodID, testActor); err != nil {
+		t.Fatalf("failed to delete food: %v", err)
+	}
+	if _, err := trash.Purge(time.Now().Add(time.Hour), testActor); err != nil {
+		t.Fatalf("failed to purge trash: %v", err)
+	}
+
+	var movements int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM stock_movements WHERE farm_id = $1 AND food_id IS NULL`, f.farmID).Scan(&movements); err != nil {
+		t.Fatalf("failed to count stock movements: %v", err)
+	}
+	if movements != 3 {
+		t.Errorf("%d stock movements kept for the purged food, want 3", movements)
+	}
+	order, err := NewPurchaseOrderRepository(db).GetOrderByID(orderID)
+	if err != nil {
+		t.Fatalf("failed to get order of purged food: %v", err)
+	}
+	if len(order.Items) != 1 || order.Items[0].FoodID != nil || order.Items[0].UnitCost != 2 {
+		t.Errorf("order items = %+v, want one detached line costing 2", order.Items)
+	}
+
+	watering := &models.WateringRecordWithoutTime{ID: uuid.New()}
+	watering.AnimalID = f.animalID
+	watering.Quantity = 20
+	watering.WateredAt = time.Now()
+	wateringRepo := NewWateringRecordRepository(db)
+	if err := wateringRepo.CreateWateringRecord(watering, testActor); err != nil {
+		t.Fatalf("failed to create watering record: %v", err)
+	}
+
+	newFarmID := uuid.New()
+	mustExec(t, db, `INSERT INTO farms (id, name, location, owner_id) SELECT $1, 'Other farm', 'Elsewhere', owner_id FROM farms WHERE id = $2`,
+		newFarmID, f.farmID)
+	mustExec(t, db, `UPDATE animals SET farm_id = $1 WHERE id = $2`, newFarmID, f.animalID)
+	if err := NewFarmRepository(db).DeleteFarm(f.farmID, testActor); err != nil {
+		t.Fatalf("failed to delete farm: %v", err)
+	}
+	if _, err := trash.Purge(time.Now().Add(time.Hour), testActor); err != nil {
+		t.Fatalf("failed to purge trash: %v", err)
+	}
+
+	var farms int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM farms WHERE id = $1`, f.farmID).Scan(&farms); err != nil {
+		t.Fatalf("failed to count farms: %v", err)
+	}
+	if farms != 0 {
+		t.Errorf("farm was not purged")
+	}
+
+	farmID, err := wateringRepo.GetFarmIDByRecordID(watering.ID)
+	if err != nil {
+		t.Fatalf("failed to get farm of watering record: %v", err)
+	}
+	if farmID != newFarmID {
+		t.Errorf("watering record belongs to farm %v, want %v", farmID, newFarmID)
+	}
+	_, total, err := wateringRepo.GetWateringRecordsByAnimalID(&models.RecordFilter{AnimalID: f.animalID}, &models.PageQuery{Limit: 10})
+	if err != nil {
+		t.Fatalf("failed to list records of animal: %v", err)
+	}
+	if total != 1 {
+		t.Errorf("%d watering records listed for the animal, want 1", total)
+	}
+}
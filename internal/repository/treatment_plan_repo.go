@@ -0,0 +1,459 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type TreatmentPlanRepository struct {
+	db *sql.DB
+}
+
+func NewTreatmentPlanRepository(db *sql.DB) *TreatmentPlanRepository {
+	return &TreatmentPlanRepository{db: db}
+}
+
+var (
+	ErrTreatmentPlanNotFound  = errors.New("treatment plan not found")
+	ErrTreatmentEventNotFound = errors.New("treatment event not found")
+	ErrEventNotPending        = errors.New("treatment event is no longer pending")
+)
+
+const treatmentPlanColumns = `id, farm_id, animal_type, medicine_id, name, quantity, first_dose_age_days, dose_count,
+	dose_interval_days, booster_interval_days, COALESCE(notes, ''), active, created_at`
+
+func scanTreatmentPlan(scanner interface{ Scan(...interface{}) error }) (*models.TreatmentPlan, error) {
+	var plan models.TreatmentPlan
+	var firstDoseAgeDays sql.NullInt64
+	err := scanner.Scan(&plan.ID, &plan.FarmID, &plan.AnimalType, &plan.MedicineID, &plan.Name, &plan.Quantity, &firstDoseAgeDays,
+		&plan.DoseCount, &plan.DoseIntervalDays, &plan.BoosterIntervalDays, &plan.Notes, &plan.Active, &plan.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if firstDoseAgeDays.Valid {
+		days := int(firstDoseAgeDays.Int64)
+		plan.FirstDoseAgeDays = &days
+	}
+	return &plan, nil
+}
+
+func nullInt(value *int) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*value), Valid: true}
+}
+
+func (r *TreatmentPlanRepository) CreatePlan(plan *models.TreatmentPlan, actor *models.AuditActor) error {
+	query := `
+	INSERT INTO treatment_plans (id, farm_id, animal_type, medicine_id, name, quantity, first_dose_age_days, dose_count,
+		dose_interval_days, booster_interval_days, notes, active)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	RETURNING created_at
+	`
+	err := auditedChange(r.db, actor, models.AuditCreate, models.AuditTreatmentPlan, plan.ID, func(tx *sql.Tx) error {
+		return tx.QueryRow(query, plan.ID, plan.FarmID, plan.AnimalType, plan.MedicineID, plan.Name, plan.Quantity,
+			nullInt(plan.FirstDoseAgeDays), plan.DoseCount, plan.DoseIntervalDays, plan.BoosterIntervalDays, plan.Notes,
+			plan.Active).Scan(&plan.CreatedAt)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create treatment plan: %v", err)
+	}
+	return nil
+}
+
+func (r *TreatmentPlanRepository) GetPlanByID(id uuid.UUID) (*models.TreatmentPlan, error) {
+	query := `SELECT ` + treatmentPlanColumns + ` FROM treatment_plans WHERE id = $1`
+	plan, err := scanTreatmentPlan(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get treatment plan: %v", err)
+	}
+	return plan, nil
+}
+
+// GetPlansByFarmID returns a page of the plans of the farm, together with
+// their total number.
+func (r *TreatmentPlanRepository) GetPlansByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.TreatmentPlan, int, error) {
+	q := newListQuery(treatmentPlanColumns, `treatment_plans p`)
+	q.filter(`p.farm_id = ?`, farmID)
+
+	plans, total, err := list(r.db, q, page, sortColumns("p", models.TreatmentPlanSortFields), "p.created_at", "p.id",
+		func(rows *sql.Rows) (models.TreatmentPlan, error) {
+			plan, err := scanTreatmentPlan(rows)
+			if err != nil {
+				return models.TreatmentPlan{}, fmt.Errorf("failed to scan treatment plan: %v", err)
+			}
+			return *plan, nil
+		})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get treatment plans: %v", err)
+	}
+	return plans, total, nil
+}
+
+func (r *TreatmentPlanRepository) GetFarmIDByPlanID(id uuid.UUID) (uuid.UUID, error) {
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(`SELECT farm_id FROM treatment_plans WHERE id = $1`, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrTreatmentPlanNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+func (r *TreatmentPlanRepository) UpdatePlan(id uuid.UUID, req *models.UpdateTreatmentPlanReq, actor *models.AuditActor) error {
+	query := `
+	UPDATE treatment_plans
+	SET name = $1, quantity = $2, first_dose_age_days = $3, dose_count = $4, dose_interval_days = $5,
+		booster_interval_days = $6, notes = $7, active = $8
+	WHERE id = $9
+	`
+	return auditedChange(r.db, actor, models.AuditUpdate, models.AuditTreatmentPlan, id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(query, req.Name, req.Quantity, nullInt(req.FirstDoseAgeDays), req.DoseCount, req.DoseIntervalDays,
+			req.BoosterIntervalDays, req.Notes, req.Active, id)
+		if err != nil {
+			return fmt.Errorf("failed to update treatment plan: %v", err)
+		}
+		return requireRow(result, ErrTreatmentPlanNotFound)
+	})
+}
+
+func (r *TreatmentPlanRepository) DeletePlan(id uuid.UUID, actor *models.AuditActor) error {
+	return auditedChange(r.db, actor, models.AuditDelete, models.AuditTreatmentPlan, id, func(tx *sql.Tx) error {
+		result, err := tx.Exec(`DELETE FROM treatment_plans WHERE id = $1`, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete treatment plan: %v", err)
+		}
+		return requireRow(result, ErrTreatmentPlanNotFound)
+	})
+}
+
+// GetEnrollments returns every active animal covered by an active plan along
+// with the latest event generated for it.
+func (r *TreatmentPlanRepository) GetEnrollments() ([]models.TreatmentEnrollment, error) {
+	query := `
+	SELECT p.id, p.farm_id, p.first_dose_age_days, p.dose_count, p.dose_interval_days, p.booster_interval_days, p.created_at,
+	  a.id, a.date_of_birth, a.created_at,
+	  COALESCE(e.dose_number, 0), COALESCE(e.status, ''), COALESCE(e.completed_at::date, e.due_date)
+	FROM treatment_plans p
+	INNER JOIN animals a ON a.farm_id = p.farm_id AND a.type = p.animal_type
+	INNER JOIN medicines m ON m.id = p.medicine_id
+	LEFT JOIN LATERAL (
+	  SELECT dose_number, status, completed_at, due_date
+	  FROM treatment_events
+	  WHERE plan_id = p.id AND animal_id = a.id
+	  ORDER BY dose_number DESC
+	  LIMIT 1
+	) e ON TRUE
+	WHERE p.active AND a.status = 'active' AND a.deleted_at IS NULL AND m.deleted_at IS NULL
+	`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get treatment enrollments: %v", err)
+	}
+	defer rows.Close()
+
+	var enrollments []models.TreatmentEnrollment
+	for rows.Next() {
+		var enrollment models.TreatmentEnrollment
+		var firstDoseAgeDays sql.NullInt64
+		var dateOfBirth, lastDoseDate sql.NullTime
+		err := rows.Scan(&enrollment.PlanID, &enrollment.FarmID, &firstDoseAgeDays, &enrollment.DoseCount,
+			&enrollment.DoseIntervalDays, &enrollment.BoosterIntervalDays, &enrollment.PlanCreatedAt,
+			&enrollment.AnimalID, &dateOfBirth, &enrollment.AnimalCreatedAt,
+			&enrollment.LastDoseNumber, &enrollment.LastStatus, &lastDoseDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan treatment enrollment: %v", err)
+		}
+		if firstDoseAgeDays.Valid {
+			days := int(firstDoseAgeDays.Int64)
+			enrollment.FirstDoseAgeDays = &days
+		}
+		if dateOfBirth.Valid {
+			enrollment.DateOfBirth = &dateOfBirth.Time
+		}
+		if lastDoseDate.Valid {
+			enrollment.LastDoseDate = &lastDoseDate.Time
+		}
+		enrollments = append(enrollments, enrollment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return enrollments, nil
+}
+
+// CreateEvents stores the events, skipping doses that already exist. It
+// returns the number of new events.
+func (r *TreatmentPlanRepository) CreateEvents(events []models.TreatmentEvent, actor *models.AuditActor) (created int64, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+	INSERT INTO treatment_events (id, plan_id, animal_id, dose_number, due_date)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (plan_id, animal_id, dose_number) DO NOTHING
+	`
+	for _, event := range events {
+		result, err := tx.Exec(query, event.ID, event.PlanID, event.AnimalID, event.DoseNumber, event.DueDate.Format("2006-01-02"))
+		if err != nil {
+			return 0, fmt.Errorf("failed to create treatment event: %v", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		if rowsAffected == 0 {
+			continue
+		}
+		if err = audit(tx, actor, models.AuditCreate, models.AuditTreatmentEvent, event.ID, nil); err != nil {
+			return 0, err
+		}
+		created++
+	}
+
+	return created, nil
+}
+
+const (
+	treatmentEventColumns = `e.id, e.plan_id, p.name, a.farm_id, e.animal_id, COALESCE(a.name, ''), p.medicine_id, m.name, e.dose_number,
+	  p.quantity, e.due_date, e.status, e.status = 'pending' AND e.due_date < CURRENT_DATE,
+	  e.medical_record_id, e.completed_at, e.created_at`
+	treatmentEventTables = `treatment_events e
+	INNER JOIN treatment_plans p ON e.plan_id = p.id
+	INNER JOIN animals a ON e.animal_id = a.id
+	INNER JOIN medicines m ON p.medicine_id = m.id`
+	treatmentEventQuery = `SELECT ` + treatmentEventColumns + ` FROM ` + treatmentEventTables
+)
+
+func scanTreatmentEvent(scanner interface{ Scan(...interface{}) error }) (models.TreatmentEvent, error) {
+	var event models.TreatmentEvent
+	var medicalRecordID uuid.NullUUID
+	var completedAt sql.NullTime
+	err := scanner.Scan(&event.ID, &event.PlanID, &event.PlanName, &event.FarmID, &event.AnimalID, &event.AnimalName,
+		&event.MedicineID, &event.MedicineName, &event.DoseNumber, &event.Quantity, &event.DueDate, &event.Status,
+		&event.Overdue, &medicalRecordID, &completedAt, &event.CreatedAt)
+	if err != nil {
+		return event, fmt.Errorf("failed to scan treatment event: %v", err)
+	}
+	if medicalRecordID.Valid {
+		event.MedicalRecordID = &medicalRecordID.UUID
+	}
+	if completedAt.Valid {
+		event.CompletedAt = &completedAt.Time
+	}
+	return event, nil
+}
+
+func (r *TreatmentPlanRepository) queryEvents(query string, args ...interface{}) ([]models.TreatmentEvent, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get treatment events: %v", err)
+	}
+	defer rows.Close()
+
+	var events []models.TreatmentEvent
+	for rows.Next() {
+		event, err := scanTreatmentEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return events, nil
+}
+
+// GetUpcomingEvents returns a page of the pending events matching the filter,
+// overdue ones first, together with their total number.
+func (r *TreatmentPlanRepository) GetUpcomingEvents(filter *models.TreatmentEventFilter, page *models.PageQuery) ([]models.TreatmentEvent, int, error) {
+	q := newListQuery(treatmentEventColumns, treatmentEventTables)
+	q.filter(`e.status = 'pending'`)
+	q.filter(`a.deleted_at IS NULL`)
+	if filter.AnimalID != uuid.Nil {
+		q.filter(`e.animal_id = ?`, filter.AnimalID)
+	}
+	if filter.FarmID != uuid.Nil {
+		q.filter(`a.farm_id = ?`, filter.FarmID)
+	}
+	if !filter.Until.IsZero() {
+		q.filter(`e.due_date <= ?`, filter.Until.Format("2006-01-02"))
+	}
+	if filter.OverdueOnly {
+		q.filter(`e.due_date < CURRENT_DATE`)
+	}
+
+	events, total, err := list(r.db, q, page, sortColumns("e", models.TreatmentEventSortFields), "e.due_date", "e.id",
+		func(rows *sql.Rows) (models.TreatmentEvent, error) { return scanTreatmentEvent(rows) })
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get treatment events: %v", err)
+	}
+	return events, total, nil
+}
+
+// GetEventsToRemind returns the pending events due up to the given day that
+// have not been reminded of yet.
+func (r *TreatmentPlanRepository) GetEventsToRemind(day time.Time) ([]models.TreatmentEvent, error) {
+	query := treatmentEventQuery + ` WHERE e.status = 'pending' AND e.reminded_at IS NULL AND e.due_date <= $1
+	  AND a.deleted_at IS NULL AND m.deleted_at IS NULL`
+	return r.queryEvents(query, day.Format("2006-01-02"))
+}
+
+func (r *TreatmentPlanRepository) MarkReminded(ids []uuid.UUID) error {
+	_, err := r.db.Exec(`UPDATE treatment_events SET reminded_at = CURRENT_TIMESTAMP WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to mark treatment events as reminded: %v", err)
+	}
+	return nil
+}
+
+func (r *TreatmentPlanRepository) GetFarmIDByEventID(id uuid.UUID) (uuid.UUID, error) {
+	query := `
+	SELECT a.farm_id
+	FROM treatment_events e
+	INNER JOIN animals a ON e.animal_id = a.id
+	WHERE e.id = $1 AND a.deleted_at IS NULL
+	`
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrTreatmentEventNotFound
+		}
+		return uuid.Nil, err
+	}
+	return farmID, nil
+}
+
+// lockPendingEvent locks the event for the rest of the transaction, loads
+// what is needed to record it and returns its state before the change. It
+// fails with ErrEventNotPending when the event was already completed or
+// skipped.
+func lockPendingEvent(tx *sql.Tx, eventID uuid.UUID, event *models.TreatmentEvent) (*models.AuditSnapshot, error) {
+	query := `
+	SELECT e.animal_id, p.medicine_id, p.quantity, e.status
+	FROM treatment_events e
+	INNER JOIN treatment_plans p ON e.plan_id = p.id
+	WHERE e.id = $1
+	FOR UPDATE OF e
+	`
+	err := tx.QueryRow(query, eventID).Scan(&event.AnimalID, &event.MedicineID, &event.Quantity, &event.Status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrTreatmentEventNotFound
+		}
+		return nil, err
+	}
+	if event.Status != models.TreatmentEventPending {
+		return nil, ErrEventNotPending
+	}
+	return lockSnapshot(tx, models.AuditTreatmentEvent, eventID)
+}
+
+// CompleteEvent creates the medical record of a pending event, including the
+// stock decrement, and links it to the event in a single transaction. The
+// planned quantity is used unless req overrides it; the treatment is dated now
+// unless req.TreatmentDate is set.
+func (r *TreatmentPlanRepository) CompleteEvent(eventID, recordID, userID uuid.UUID,
+	req *models.CompleteTreatmentEventReq, actor *models.AuditActor) (movement *models.StockMovement, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var event models.TreatmentEvent
+	before, err := lockPendingEvent(tx, eventID, &event)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.MedicalRecordWithoutTime{ID: recordID, RecordedBy: &userID}
+	record.AnimalID = event.AnimalID
+	record.MedicineID = event.MedicineID
+	record.Quantity = event.Quantity
+	record.TreatmentDate = time.Now()
+	record.Notes = req.Notes
+	if req.Quantity > 0 {
+		record.Quantity = req.Quantity
+	}
+	if req.TreatmentDate != nil {
+		record.TreatmentDate = *req.TreatmentDate
+	}
+
+	if movement, err = insertMedicalRecord(tx, actor, record); err != nil {
+		return nil, err
+	}
+
+	query := `UPDATE treatment_events SET status = $1, medical_record_id = $2, completed_at = $3 WHERE id = $4`
+	if _, err = tx.Exec(query, models.TreatmentEventCompleted, record.ID, record.TreatmentDate, eventID); err != nil {
+		return nil, err
+	}
+
+	if err = audit(tx, actor, models.AuditUpdate, models.AuditTreatmentEvent, eventID, before); err != nil {
+		return nil, err
+	}
+
+	return movement, nil
+}
+
+func (r *TreatmentPlanRepository) SkipEvent(eventID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var event models.TreatmentEvent
+	before, err := lockPendingEvent(tx, eventID, &event)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`UPDATE treatment_events SET status = $1, completed_at = CURRENT_TIMESTAMP WHERE id = $2`,
+		models.TreatmentEventSkipped, eventID)
+	if err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditUpdate, models.AuditTreatmentEvent, eventID, before)
+}
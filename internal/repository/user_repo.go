@@ -20,14 +20,20 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	return &UserRepository{DB: db}
 }
 
-var ErrEmailAlreadyInUse = errors.New("email is already in use")
+var (
+	ErrEmailAlreadyInUse = errors.New("email is already in use")
+	ErrUserOwnsFarms     = errors.New("user still owns farms, including deleted farms awaiting purge")
+)
 
-func (r *UserRepository) CreateUser(user *models.User) error {
+func (r *UserRepository) CreateUser(user *models.User, actor *models.AuditActor) error {
 	query := `
         INSERT INTO users (id, name, email, phone_number, password_hash)
         VALUES ($1, $2, $3, $4, $5)
     `
-	_, err := r.DB.Exec(query, user.ID, user.Name, user.Email, user.PhoneNumber, user.Password)
+	err := auditedChange(r.DB, actor, models.AuditCreate, models.AuditUser, user.ID, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, user.ID, user.Name, user.Email, user.PhoneNumber, user.Password)
+		return err
+	})
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
 			return ErrEmailAlreadyInUse
@@ -37,28 +43,27 @@ func (r *UserRepository) CreateUser(user *models.User) error {
 	return nil
 }
 
-func (r *UserRepository) GetAllUsers() ([]*models.User, error) {
-	query := `SELECT id, name, email, phone_number, created_at FROM users`
-	rows, err := r.DB.Query(query)
+// GetVisibleUsers returns a page of the user and the users sharing a farm with
+// them, together with their total number.
+func (r *UserRepository) GetVisibleUsers(userID uuid.UUID, page *models.PageQuery) ([]*models.User, int, error) {
+	q := newListQuery(`u.id, u.name, u.email, u.phone_number, u.created_at`, `users u`)
+	q.filter(`(u.id = ? OR u.id IN (
+		SELECT fm.user_id FROM farm_members fm
+		WHERE fm.farm_id IN (SELECT farm_id FROM farm_members WHERE user_id = ?)
+	))`, userID, userID)
+
+	users, total, err := list(r.DB, q, page, sortColumns("u", models.UserSortFields), "u.name", "u.id",
+		func(rows *sql.Rows) (*models.User, error) {
+			var user models.User
+			if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.PhoneNumber, &user.CreatedAt); err != nil {
+				return nil, fmt.Errorf("failed to scan user: %v", err)
+			}
+			return &user, nil
+		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve users: %v", err)
-	}
-	defer rows.Close()
-
-	var users []*models.User
-	for rows.Next() {
-		var user models.User
-		if err := rows.Scan(&user.ID, &user.Name, &user.Email, &user.PhoneNumber, &user.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan user: %v", err)
-		}
-		users = append(users, &user)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+		return nil, 0, fmt.Errorf("failed to retrieve users: %v", err)
 	}
-
-	return users, nil
+	return users, total, nil
 }
 
 func (r *UserRepository) GetUserByID(userID uuid.UUID) (*models.User, error) {
@@ -77,11 +82,11 @@ func (r *UserRepository) GetUserByID(userID uuid.UUID) (*models.User, error) {
 }
 
 func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
-	query := `SELECT id, name, email, phone_number, created_at FROM users WHERE email = $1`
+	query := `SELECT id, name, email, phone_number, password_hash, created_at FROM users WHERE email = $1`
 	row := r.DB.QueryRow(query, email)
 
 	var user models.User
-	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.PhoneNumber, &user.CreatedAt); err != nil {
+	if err := row.Scan(&user.ID, &user.Name, &user.Email, &user.PhoneNumber, &user.Password, &user.CreatedAt); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
 		}
@@ -91,7 +96,9 @@ func (r *UserRepository) GetUserByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
-func (r *UserRepository) UpdateUser(user *models.UpdateUser) error {
+// UpdateUser saves the user's details. Changing the password revokes every
+// session of the user in the same transaction, so no old token outlives it.
+func (r *UserRepository) UpdateUser(user *models.UpdateUser, actor *models.AuditActor) error {
 	query := `
         UPDATE users
         SET name = $1, email = $2, phone_number = $3
@@ -106,17 +113,33 @@ func (r *UserRepository) UpdateUser(user *models.UpdateUser) error {
 	query += " WHERE id = $" + strconv.Itoa(len(updateValues)+1)
 	updateValues = append(updateValues, user.ID)
 
-	_, err := r.DB.Exec(query, updateValues...)
+	err := auditedChange(r.DB, actor, models.AuditUpdate, models.AuditUser, user.ID, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(query, updateValues...); err != nil {
+			return err
+		}
+		if user.Password != "" {
+			if _, err := tx.Exec(revokeUserSessionsQuery, user.ID); err != nil {
+				return fmt.Errorf("failed to revoke sessions: %v", err)
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to update user: %v", err)
 	}
 	return nil
 }
 
-func (r *UserRepository) DeleteUser(userID uuid.UUID) error {
+func (r *UserRepository) DeleteUser(userID uuid.UUID, actor *models.AuditActor) error {
 	query := `DELETE FROM users WHERE id = $1`
-	_, err := r.DB.Exec(query, userID)
+	err := auditedChange(r.DB, actor, models.AuditDelete, models.AuditUser, userID, func(tx *sql.Tx) error {
+		_, err := tx.Exec(query, userID)
+		return err
+	})
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return ErrUserOwnsFarms
+		}
 		return fmt.Errorf("failed to delete user: %v", err)
 	}
 	return nil
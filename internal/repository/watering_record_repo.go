@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type WateringRecordRepository struct {
+	db *sql.DB
+}
+
+func NewWateringRecordRepository(db *sql.DB) *WateringRecordRepository {
+	return &WateringRecordRepository{db: db}
+}
+
+var ErrWateringRecordNotFound = errors.New("watering record not found")
+
+func (r *WateringRecordRepository) CreateWateringRecord(record *models.WateringRecordWithoutTime, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `
+		INSERT INTO watering_records (id, farm_id, animal_id, quantity, watered_at, notes)
+		VALUES ($1, (SELECT farm_id FROM animals WHERE id = $2), $2, $3, $4, $5)
+	`
+	_, err = tx.Exec(query, record.ID, record.AnimalID, record.Quantity, record.WateredAt, record.Notes)
+	if err != nil {
+		return err
+	}
+
+	if err = syncLastWatered(tx, record.AnimalID); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditCreate, models.AuditWateringRecord, record.ID, nil)
+}
+
+func (r *WateringRecordRepository) GetWateringRecordByID(id uuid.UUID) (*models.WateringRecord, error) {
+	query := `
+	SELECT id, animal_id, COALESCE(quantity, 0), watered_at, COALESCE(notes, ''), created_at
+	FROM watering_records
+	WHERE id = $1 AND deleted_at IS NULL
+	`
+
+	var record models.WateringRecord
+	err := r.db.QueryRow(query, id).Scan(&record.ID, &record.AnimalID, &record.Quantity, &record.WateredAt, &record.Notes, &record.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// GetWateringRecordsByAnimalID returns a page of the watering records of an
+// animal that match the filter, together with their total number.
+func (r *WateringRecordRepository) GetWateringRecordsByAnimalID(filter *models.RecordFilter, page *models.PageQuery) ([]models.WateringRecord, int, error) {
+	q := newListQuery(`wr.id, wr.animal_id, COALESCE(wr.quantity, 0), wr.watered_at, COALESCE(wr.notes, ''), wr.created_at`, `watering_records wr`)
+	filterRecords(q, "wr", "watered_at", filter)
+
+	return list(r.db, q, page, sortColumns("wr", models.WateringRecordSortFields), "wr.watered_at DESC", "wr.id",
+		func(rows *sql.Rows) (models.WateringRecord, error) {
+			var record models.WateringRecord
+			err := rows.Scan(&record.ID, &record.AnimalID, &record.Quantity, &record.WateredAt, &record.Notes, &record.CreatedAt)
+			return record, err
+		})
+}
+
+func (r *WateringRecordRepository) GetFarmIDByRecordID(id uuid.UUID) (uuid.UUID, error) {
+	query := `
+	SELECT COALESCE(wr.farm_id, a.farm_id)
+	FROM watering_records wr
+	LEFT JOIN animals a ON wr.animal_id = a.id
+	WHERE wr.id = $1 AND wr.deleted_at IS NULL
+	`
+
+	var farmID uuid.UUID
+	if err := r.db.QueryRow(query, id).Scan(&farmID); err != nil {
+		if err == sql.ErrNoRows {
+			return uuid.Nil, ErrWateringRecordNotFound
+		}
+		return uuid.Nil, err
+	}
+
+	return farmID, nil
+}
+
+func (r *WateringRecordRepository) UpdateWateringRecord(record *models.WateringRecordWithoutTime, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditWateringRecord, record.ID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE watering_records
+		SET quantity = $1, watered_at = $2, notes = $3
+		WHERE id = $4 AND deleted_at IS NULL
+		RETURNING animal_id
+	`
+	err = tx.QueryRow(query, record.Quantity, record.WateredAt, record.Notes, record.ID).Scan(&record.AnimalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWateringRecordNotFound
+		}
+		return err
+	}
+
+	if err = syncLastWatered(tx, record.AnimalID); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditUpdate, models.AuditWateringRecord, record.ID, before)
+}
+
+// DeleteWateringRecord moves the record to the trash.
+func (r *WateringRecordRepository) DeleteWateringRecord(id uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditWateringRecord, id)
+	if err != nil {
+		return err
+	}
+
+	var animalID uuid.UUID
+	err = tx.QueryRow(`UPDATE watering_records SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL RETURNING animal_id`, id).Scan(&animalID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return ErrWateringRecordNotFound
+		}
+		return err
+	}
+
+	if err = syncLastWatered(tx, animalID); err != nil {
+		return err
+	}
+
+	return audit(tx, actor, models.AuditDelete, models.AuditWateringRecord, id, before)
+}
+
+// syncLastWatered sets the animal's last_watered to its most recent watering
+// record. The current value is kept when the animal has no watering records.
+func syncLastWatered(tx *sql.Tx, animalID uuid.UUID) error {
+	query := `
+	UPDATE animals
+	SET last_watered = COALESCE((SELECT MAX(watered_at) FROM watering_records WHERE animal_id = $1 AND deleted_at IS NULL), last_watered)
+	WHERE id = $1
+	`
+	if _, err := tx.Exec(query, animalID); err != nil {
+		return fmt.Errorf("failed to update last watered time: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"farmish/internal/models"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+type WeightMeasurementRepository struct {
+	db *sql.DB
+}
+
+func NewWeightMeasurementRepository(db *sql.DB) *WeightMeasurementRepository {
+	return &WeightMeasurementRepository{db: db}
+}
+
+var ErrWeightMeasurementNotFound = errors.New("weight measurement not found")
+
+// CreateMeasurement stores the measurement and updates the animal's current
+// weight to its latest measurement.
+func (r *WeightMeasurementRepository) CreateMeasurement(measurement *models.WeightMeasurement, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	if err = insertWeightMeasurement(tx, actor, measurement); err != nil {
+		return err
+	}
+
+	return syncWeight(tx, actor, measurement.AnimalID)
+}
+
+func insertWeightMeasurement(tx *sql.Tx, actor *models.AuditActor, measurement *models.WeightMeasurement) error {
+	query := `
+	INSERT INTO weight_measurements (id, animal_id, weight, measured_at, notes)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING created_at
+	`
+	err := tx.QueryRow(query, measurement.ID, measurement.AnimalID, measurement.Weight, measurement.MeasuredAt,
+		measurement.Notes).Scan(&measurement.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create weight measurement: %v", err)
+	}
+	return audit(tx, actor, models.AuditCreate, models.AuditWeightMeasurement, measurement.ID, nil)
+}
+
+// syncWeight sets the animal's weight to its latest measurement, if any.
+func syncWeight(tx *sql.Tx, actor *models.AuditActor, animalID uuid.UUID) error {
+	before, err := lockSnapshot(tx, models.AuditAnimal, animalID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	UPDATE animals
+	SET weight = wm.weight
+	FROM (
+	  SELECT weight FROM weight_measurements WHERE animal_id = $1 ORDER BY measured_at DESC LIMIT 1
+	) wm
+	WHERE animals.id = $1
+	`
+	if _, err := tx.Exec(query, animalID); err != nil {
+		return fmt.Errorf("failed to update animal weight: %v", err)
+	}
+	return audit(tx, actor, models.AuditUpdate, models.AuditAnimal, animalID, before)
+}
+
+// GetMeasurements returns the measurements matching the filter, ordered by
+// animal and time.
+func (r *WeightMeasurementRepository) GetMeasurements(filter *models.GrowthFilter) ([]models.WeightMeasurement, error) {
+	query := `
+	SELECT wm.id, wm.animal_id, wm.weight, wm.measured_at, COALESCE(wm.notes, ''), wm.created_at, COALESCE(a.name, ''), a.type
+	FROM weight_measurements wm
+	INNER JOIN animals a ON wm.animal_id = a.id
+	WHERE a.deleted_at IS NULL`
+	var args []interface{}
+
+	if filter.AnimalID != uuid.Nil {
+		args = append(args, filter.AnimalID)
+		query += ` AND wm.animal_id = $` + strconv.Itoa(len(args))
+	}
+	if filter.FarmID != uuid.Nil {
+		args = append(args, filter.FarmID)
+		query += ` AND a.farm_id = $` + strconv.Itoa(len(args))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		query += ` AND a.type = $` + strconv.Itoa(len(args))
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		query += ` AND wm.measured_at >= $` + strconv.Itoa(len(args))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		query += ` AND wm.measured_at < $` + strconv.Itoa(len(args))
+	}
+	query += ` ORDER BY wm.animal_id, wm.measured_at`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weight measurements: %v", err)
+	}
+	defer rows.Close()
+
+	var measurements []models.WeightMeasurement
+	for rows.Next() {
+		var measurement models.WeightMeasurement
+		err := rows.Scan(&measurement.ID, &measurement.AnimalID, &measurement.Weight, &measurement.MeasuredAt,
+			&measurement.Notes, &measurement.CreatedAt, &measurement.AnimalName, &measurement.AnimalType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan weight measurement: %v", err)
+		}
+		measurements = append(measurements, measurement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error occurred during rows iteration: %v", err)
+	}
+
+	return measurements, nil
+}
+
+// DeleteMeasurement deletes a measurement of the animal and falls back to the
+// previous measurement as its current weight.
+func (r *WeightMeasurementRepository) DeleteMeasurement(animalID, measurementID uuid.UUID, actor *models.AuditActor) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	before, err := lockSnapshot(tx, models.AuditWeightMeasurement, measurementID)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(`DELETE FROM weight_measurements WHERE id = $1 AND animal_id = $2`, measurementID, animalID)
+	if err != nil {
+		return fmt.Errorf("failed to delete weight measurement: %v", err)
+	}
+	if err = requireRow(result, ErrWeightMeasurementNotFound); err != nil {
+		return err
+	}
+
+	if err = audit(tx, actor, models.AuditDelete, models.AuditWeightMeasurement, measurementID, before); err != nil {
+		return err
+	}
+
+	return syncWeight(tx, actor, animalID)
+}
@@ -0,0 +1,336 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// AccessService resolves the farm a resource belongs to and decides whether a
+// user's role on that farm allows the requested action.
+type AccessService struct {
+	farmRepo            *repository.FarmRepository
+	memberRepo          *repository.FarmMemberRepository
+	animalRepo          *repository.AnimalRepository
+	foodRepo            *repository.FoodRepository
+	medicineRepo        *repository.MedicineRepository
+	medicineBatchRepo   *repository.MedicineBatchRepository
+	feedingRecordRepo   *repository.FeedingRecordRepository
+	feedingScheduleRepo *repository.FeedingScheduleRepository
+	wateringRecordRepo  *repository.WateringRecordRepository
+	medicalRecordRepo   *repository.MedicalRecordRepository
+	treatmentPlanRepo   *repository.TreatmentPlanRepository
+	supplierRepo        *repository.SupplierRepository
+	purchaseOrderRepo   *repository.PurchaseOrderRepository
+	breedingRepo        *repository.BreedingRepository
+	productionRepo      *repository.ProductionRecordRepository
+	alertRepo           *repository.AlertRepository
+	trashRepo           *repository.TrashRepository
+	auditRepo           *repository.AuditRepository
+}
+
+func NewAccessService(
+	farmRepo *repository.FarmRepository,
+	memberRepo *repository.FarmMemberRepository,
+	animalRepo *repository.AnimalRepository,
+	foodRepo *repository.FoodRepository,
+	medicineRepo *repository.MedicineRepository,
+	medicineBatchRepo *repository.MedicineBatchRepository,
+	feedingRecordRepo *repository.FeedingRecordRepository,
+	feedingScheduleRepo *repository.FeedingScheduleRepository,
+	wateringRecordRepo *repository.WateringRecordRepository,
+	medicalRecordRepo *repository.MedicalRecordRepository,
+	treatmentPlanRepo *repository.TreatmentPlanRepository,
+	supplierRepo *repository.SupplierRepository,
+	purchaseOrderRepo *repository.PurchaseOrderRepository,
+	breedingRepo *repository.BreedingRepository,
+	productionRepo *repository.ProductionRecordRepository,
+	alertRepo *repository.AlertRepository,
+	trashRepo *repository.TrashRepository,
+	auditRepo *repository.AuditRepository,
+) *AccessService {
+	return &AccessService{
+		farmRepo:            farmRepo,
+		memberRepo:          memberRepo,
+		animalRepo:          animalRepo,
+		foodRepo:            foodRepo,
+		medicineRepo:        medicineRepo,
+		medicineBatchRepo:   medicineBatchRepo,
+		feedingRecordRepo:   feedingRecordRepo,
+		feedingScheduleRepo: feedingScheduleRepo,
+		wateringRecordRepo:  wateringRecordRepo,
+		medicalRecordRepo:   medicalRecordRepo,
+		treatmentPlanRepo:   treatmentPlanRepo,
+		supplierRepo:        supplierRepo,
+		purchaseOrderRepo:   purchaseOrderRepo,
+		breedingRepo:        breedingRepo,
+		productionRepo:      productionRepo,
+		alertRepo:           alertRepo,
+		trashRepo:           trashRepo,
+		auditRepo:           auditRepo,
+	}
+}
+
+var (
+	ErrForbidden    = errors.New("you do not have access to this resource")
+	ErrFarmNotFound = errors.New("farm not found")
+)
+
+// GetFarmRole returns the user's role on the farm, or an empty string when the
+// user is neither the owner nor a member.
+func (s *AccessService) GetFarmRole(userID, farmID uuid.UUID) (string, error) {
+	farm, err := s.farmRepo.GetFarmByID(farmID)
+	if err != nil {
+		return "", err
+	} else if farm == nil {
+		return "", ErrFarmNotFound
+	}
+
+	if farm.OwnerID == userID {
+		return models.RoleOwner, nil
+	}
+
+	return s.memberRepo.GetMemberRole(farmID, userID)
+}
+
+func (s *AccessService) CheckFarmAccess(userID, farmID uuid.UUID, perm Permission) error {
+	role, err := s.GetFarmRole(userID, farmID)
+	if err != nil {
+		return err
+	}
+
+	if role == "" || !RoleHasPermission(role, perm) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+func (s *AccessService) CheckAnimalAccess(userID, animalID uuid.UUID, perm Permission) error {
+	animal, err := s.animalRepo.GetAnimalByID(animalID)
+	if err != nil {
+		return err
+	} else if animal == nil {
+		return ErrAnimalNotFound
+	}
+
+	return s.CheckFarmAccess(userID, animal.FarmID, perm)
+}
+
+func (s *AccessService) CheckFoodAccess(userID, foodID uuid.UUID, perm Permission) error {
+	food, err := s.foodRepo.GetFoodByID(foodID)
+	if err != nil {
+		return err
+	} else if food == nil {
+		return ErrFoodNotFound
+	}
+
+	return s.CheckFarmAccess(userID, food.FarmID, perm)
+}
+
+func (s *AccessService) CheckMedicineAccess(userID, medicineID uuid.UUID, perm Permission) error {
+	medicine, err := s.medicineRepo.GetMedicineByID(medicineID)
+	if err != nil {
+		return err
+	} else if medicine == nil {
+		return ErrMedicineNotExist
+	}
+
+	return s.CheckFarmAccess(userID, medicine.FarmID, perm)
+}
+
+func (s *AccessService) CheckFeedingRecordAccess(userID, recordID uuid.UUID, perm Permission) error {
+	farmID, err := s.feedingRecordRepo.GetFarmIDByRecordID(recordID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckFeedingScheduleAccess(userID, scheduleID uuid.UUID, perm Permission) error {
+	farmID, err := s.feedingScheduleRepo.GetFarmIDByScheduleID(scheduleID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckFeedingTaskAccess(userID, taskID uuid.UUID, perm Permission) error {
+	farmID, err := s.feedingScheduleRepo.GetFarmIDByTaskID(taskID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckWateringRecordAccess(userID, recordID uuid.UUID, perm Permission) error {
+	farmID, err := s.wateringRecordRepo.GetFarmIDByRecordID(recordID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckMedicalRecordAccess(userID, recordID uuid.UUID, perm Permission) error {
+	farmID, err := s.medicalRecordRepo.GetFarmIDByRecordID(recordID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckTreatmentPlanAccess(userID, planID uuid.UUID, perm Permission) error {
+	farmID, err := s.treatmentPlanRepo.GetFarmIDByPlanID(planID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckTreatmentEventAccess(userID, eventID uuid.UUID, perm Permission) error {
+	farmID, err := s.treatmentPlanRepo.GetFarmIDByEventID(eventID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckMedicineBatchAccess(userID, batchID uuid.UUID, perm Permission) error {
+	farmID, err := s.medicineBatchRepo.GetFarmIDByBatchID(batchID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckBreedingEventAccess(userID, eventID uuid.UUID, perm Permission) error {
+	farmID, err := s.breedingRepo.GetFarmIDByEventID(eventID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckProductionRecordAccess(userID, recordID uuid.UUID, perm Permission) error {
+	farmID, err := s.productionRepo.GetFarmIDByRecordID(recordID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckSupplierAccess(userID, supplierID uuid.UUID, perm Permission) error {
+	farmID, err := s.supplierRepo.GetFarmIDBySupplierID(supplierID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckPurchaseOrderAccess(userID, orderID uuid.UUID, perm Permission) error {
+	farmID, err := s.purchaseOrderRepo.GetFarmIDByOrderID(orderID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, perm)
+}
+
+func (s *AccessService) CheckAlertAccess(userID, alertID uuid.UUID, perm Permission) error {
+	alert, err := s.alertRepo.GetAlertByID(alertID)
+	if err != nil {
+		return err
+	} else if alert == nil {
+		return repository.ErrAlertNotFound
+	}
+
+	return s.CheckFarmAccess(userID, alert.FarmID, perm)
+}
+
+// trashPermissions maps each kind of deleted item to the permission needed to
+// restore it, which is the one needed to delete it.
+var trashPermissions = map[string]Permission{
+	models.TrashAnimal:           PermManageAnimals,
+	models.TrashFood:             PermManageFoods,
+	models.TrashMedicine:         PermManageMedicines,
+	models.TrashFeedingRecord:    PermDeleteRecords,
+	models.TrashWateringRecord:   PermDeleteRecords,
+	models.TrashMedicalRecord:    PermDeleteRecords,
+	models.TrashProductionRecord: PermDeleteRecords,
+}
+
+// CheckTrashItemAccess allows restoring a deleted item to the members who may
+// delete it. Deleted farms can only be restored by their owner.
+func (s *AccessService) CheckTrashItemAccess(userID uuid.UUID, itemType string, itemID uuid.UUID) error {
+	if itemType == models.TrashFarm {
+		ownerID, err := s.trashRepo.GetFarmOwnerID(itemID)
+		if err != nil {
+			return err
+		}
+		if ownerID != userID {
+			return ErrForbidden
+		}
+		return nil
+	}
+
+	farmID, err := s.trashRepo.GetFarmIDByItemID(itemType, itemID)
+	if err != nil {
+		return err
+	}
+
+	return s.CheckFarmAccess(userID, farmID, trashPermissions[itemType])
+}
+
+// CheckAuditAccess allows reading the audit history of an entity to the
+// members who may view the audit log of the farm it belonged to last. The
+// history of users, which belong to no farm, is only visible to themselves.
+func (s *AccessService) CheckAuditAccess(userID uuid.UUID, entityType string, entityID uuid.UUID) error {
+	farmID, err := s.auditRepo.GetEntityFarmID(entityType, entityID)
+	if err != nil {
+		return err
+	}
+
+	if farmID == nil {
+		if entityType != models.AuditUser {
+			return ErrForbidden
+		}
+		return s.CheckUserAccess(userID, entityID)
+	}
+
+	return s.CheckFarmAccess(userID, *farmID, PermViewAudit)
+}
+
+// CheckUserAccess allows users to read and modify only their own account.
+func (s *AccessService) CheckUserAccess(userID, targetUserID uuid.UUID) error {
+	if userID != targetUserID {
+		return ErrForbidden
+	}
+	return nil
+}
+
+// GetAccessibleFarmIDs returns the IDs of every farm the user can work with.
+func (s *AccessService) GetAccessibleFarmIDs(userID uuid.UUID) ([]uuid.UUID, error) {
+	farms, err := s.farmRepo.GetFarmsByMemberID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	farmIDs := make([]uuid.UUID, 0, len(farms))
+	for _, farm := range farms {
+		farmIDs = append(farmIDs, farm.ID)
+	}
+	return farmIDs, nil
+}
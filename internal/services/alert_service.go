@@ -0,0 +1,65 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type AlertService struct {
+	repo *repository.AlertRepository
+}
+
+func NewAlertService(repo *repository.AlertRepository) *AlertService {
+	return &AlertService{repo: repo}
+}
+
+func (s *AlertService) CreateAlert(farmID uuid.UUID, alertType, message string) error {
+	alert := &models.Alert{
+		ID:      uuid.New(),
+		FarmID:  farmID,
+		Type:    alertType,
+		Message: message,
+	}
+	return s.repo.CreateAlert(alert)
+}
+
+func (s *AlertService) GetAlerts(filter *models.AlertFilter, page *models.PageQuery) ([]models.Alert, int, error) {
+	return s.repo.GetAlerts(filter, page)
+}
+
+func (s *AlertService) GetAlertByID(alertID uuid.UUID) (*models.Alert, error) {
+	return s.repo.GetAlertByID(alertID)
+}
+
+func (s *AlertService) MarkAlerts(req *models.MarkAlertsReq, actor *models.AuditActor) (int64, error) {
+	return s.repo.SetAlertsReadStatus(req.IDs, req.IsRead, actor)
+}
+
+func (s *AlertService) DismissAlert(alertID uuid.UUID, actor *models.AuditActor) error {
+	return s.repo.DeleteAlert(alertID, actor)
+}
+
+// CheckFoodStock raises a low stock alert when a consumption takes the food
+// from at or above its minimum threshold to below it.
+func (s *AlertService) CheckFoodStock(food *models.Food, remaining float64) error {
+	if food.Quantity < food.MinThreshold || remaining >= food.MinThreshold {
+		return nil
+	}
+	message := fmt.Sprintf("Food %q is running low: %.2f %s left (minimum %.2f)",
+		food.Name, remaining, food.UnitOfMeasure, food.MinThreshold)
+	return s.CreateAlert(food.FarmID, models.AlertTypeLowFoodStock, message)
+}
+
+// CheckMedicineStock raises a low stock alert when a consumption takes the
+// medicine from at or above its minimum threshold to below it.
+func (s *AlertService) CheckMedicineStock(medicine *models.Medicine, remaining float64) error {
+	if medicine.Quantity < medicine.MinThreshold || remaining >= medicine.MinThreshold {
+		return nil
+	}
+	message := fmt.Sprintf("Medicine %q is running low: %.2f %s left (minimum %.2f)",
+		medicine.Name, remaining, medicine.UnitOfMeasure, medicine.MinThreshold)
+	return s.CreateAlert(medicine.FarmID, models.AlertTypeLowMedicineStock, message)
+}
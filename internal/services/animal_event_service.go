@@ -0,0 +1,125 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AnimalEventService struct {
+	eventRepo            *repository.AnimalEventRepository
+	animalRepo           *repository.AnimalRepository
+	medicalRecordService *MedicalRecordService
+}
+
+func NewAnimalEventService(eventRepo *repository.AnimalEventRepository, animalRepo *repository.AnimalRepository,
+	medicalRecordService *MedicalRecordService) *AnimalEventService {
+	return &AnimalEventService{eventRepo: eventRepo, animalRepo: animalRepo, medicalRecordService: medicalRecordService}
+}
+
+var (
+	ErrBuyerRequired      = errors.New("a sale needs the buyer")
+	ErrCauseRequired      = errors.New("a death needs its cause")
+	ErrTargetFarmRequired = errors.New("a transfer needs the farm the animal moves to")
+	ErrSameFarm           = errors.New("animal already belongs to this farm")
+)
+
+// CreateEvent records the sale, death, culling or transfer of an active
+// animal. Sold and culled animals count as meat and must be clear of any
+// withdrawal period on that day.
+func (s *AnimalEventService) CreateEvent(animalID uuid.UUID, req *models.AnimalEventReq, userID uuid.UUID, actor *models.AuditActor) (*models.AnimalEvent, error) {
+	switch {
+	case req.EventType == models.AnimalEventSold && req.Buyer == "":
+		return nil, ErrBuyerRequired
+	case req.EventType == models.AnimalEventDied && req.Cause == "":
+		return nil, ErrCauseRequired
+	case req.EventType == models.AnimalEventTransferred && req.ToFarmID == nil:
+		return nil, ErrTargetFarmRequired
+	}
+
+	animal, err := s.animalRepo.GetAnimalByID(animalID)
+	if err != nil {
+		return nil, err
+	} else if animal == nil {
+		return nil, ErrAnimalNotFound
+	}
+	if animal.Status != models.AnimalActive {
+		return nil, fmt.Errorf("%w: %s is %s", ErrAnimalInactive, animal.Name, animal.Status)
+	}
+
+	event := &models.AnimalEvent{
+		ID:         uuid.New(),
+		AnimalID:   animal.ID,
+		EventType:  req.EventType,
+		OccurredAt: time.Now(),
+		FromFarmID: &animal.FarmID,
+		Cause:      req.Cause,
+		Notes:      req.Notes,
+		RecordedBy: &userID,
+	}
+	if req.OccurredAt != nil {
+		event.OccurredAt = *req.OccurredAt
+	}
+
+	switch req.EventType {
+	case models.AnimalEventSold, models.AnimalEventCulled:
+		if err := s.medicalRecordService.CheckAnimalCleared(animal.ID, models.ProductMeat, event.OccurredAt); err != nil {
+			return nil, err
+		}
+		if req.EventType == models.AnimalEventSold {
+			event.Buyer = req.Buyer
+			event.Price = req.Price
+		}
+	case models.AnimalEventTransferred:
+		if *req.ToFarmID == animal.FarmID {
+			return nil, ErrSameFarm
+		}
+		event.ToFarmID = req.ToFarmID
+	}
+
+	if err := s.eventRepo.CreateEvent(event, actor); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (s *AnimalEventService) GetEventsByAnimalID(animalID uuid.UUID, page *models.PageQuery) ([]models.AnimalEvent, int, error) {
+	return s.eventRepo.GetEventsByAnimalID(animalID, page)
+}
+
+// GetMortality returns the mortality rate of the farm, or of each animal type,
+// over the filtered period.
+func (s *AnimalEventService) GetMortality(filter *models.ReportFilter) ([]models.MortalityReport, error) {
+	rows, err := s.eventRepo.GetMortality(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := rows
+	if filter.GroupBy == models.ReportGroupFarm {
+		total := models.MortalityReport{GroupID: filter.FarmID.String()}
+		for _, row := range rows {
+			total.Animals += row.Animals
+			total.Deaths += row.Deaths
+			total.Culls += row.Culls
+			total.Sales += row.Sales
+			total.TransfersOut += row.TransfersOut
+		}
+		report = []models.MortalityReport{total}
+	}
+	if report == nil {
+		report = []models.MortalityReport{}
+	}
+
+	for i := range report {
+		if report[i].Animals > 0 {
+			rate := float64(report[i].Deaths) / float64(report[i].Animals) * 100
+			report[i].MortalityRate = &rate
+		}
+	}
+	return report, nil
+}
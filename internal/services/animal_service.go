@@ -4,46 +4,140 @@ import (
 	"errors"
 	"farmish/internal/models"
 	"farmish/internal/repository"
+	"farmish/pkg/config"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type AnimalService struct {
-	Repo *repository.AnimalRepository
+	Repo       *repository.AnimalRepository
+	careConfig config.CareConfig
 }
 
-func NewAnimalService(repo *repository.AnimalRepository) *AnimalService {
-	return &AnimalService{Repo: repo}
+func NewAnimalService(repo *repository.AnimalRepository, careConfig config.CareConfig) *AnimalService {
+	return &AnimalService{Repo: repo, careConfig: careConfig}
 }
 
-var ErrNegativeWeight = errors.New("weight must be greater than 0")
+var (
+	ErrNegativeWeight = errors.New("weight must be greater than 0")
+	ErrInvalidSire    = errors.New("sire must be a male animal of the same type on the same farm")
+	ErrInvalidDam     = errors.New("dam must be a female animal of the same type on the same farm")
+	ErrParentCycle    = errors.New("an animal cannot be its own ancestor")
+)
 
-func (s *AnimalService) CreateAnimal(animal *models.AnimalWithoutTime) error {
+func (s *AnimalService) CreateAnimal(animal *models.AnimalWithoutTime, actor *models.AuditActor) error {
 	animal.ID = uuid.New()
 
 	if animal.Weight <= 0 {
 		return ErrNegativeWeight
 	}
 
-	return s.Repo.CreateAnimal(animal)
+	if err := s.checkParentage(animal.ID, animal.FarmID, animal.Type, &animal.AnimalParentage, nil); err != nil {
+		return err
+	}
+
+	return s.Repo.CreateAnimal(animal, actor)
 }
 
 func (s *AnimalService) GetAnimalByID(animalID uuid.UUID) (*models.Animal, error) {
 	return s.Repo.GetAnimalByID(animalID)
 }
 
-func (s *AnimalService) GetAnimalsByFarmID(farmID uuid.UUID) ([]*models.Animal, error) {
-	return s.Repo.GetAnimalsByFarmID(farmID)
+func (s *AnimalService) GetAnimalsByFarmID(filter *models.AnimalFilter, page *models.PageQuery) ([]*models.Animal, int, error) {
+	return s.Repo.GetAnimalsByFarmID(filter, page)
 }
 
-func (s *AnimalService) UpdateAnimal(animal *models.UpdateAnimalReq) error {
+func (s *AnimalService) UpdateAnimal(animal *models.UpdateAnimalReq, actor *models.AuditActor) error {
 	if animal.Weight <= 0 {
 		return ErrNegativeWeight
 	}
 
-	return s.Repo.UpdateAnimal(animal)
+	existing, err := s.Repo.GetAnimalByID(animal.ID)
+	if err != nil {
+		return err
+	} else if existing == nil {
+		return ErrAnimalNotFound
+	}
+
+	if err := s.checkParentage(animal.ID, existing.FarmID, animal.Type, &animal.AnimalParentage, &existing.AnimalParentage); err != nil {
+		return err
+	}
+
+	return s.Repo.UpdateAnimal(animal, actor)
+}
+
+// checkParentage defaults the sex to unknown and makes sure the sire and dam
+// are animals of the farm of the right sex and type that do not descend from
+// the animal itself. Parents already recorded in previous are kept as they
+// are, even if they have been deleted or the animal moved to another farm.
+func (s *AnimalService) checkParentage(animalID, farmID uuid.UUID, animalType string, parentage, previous *models.AnimalParentage) error {
+	if parentage.Sex == "" {
+		parentage.Sex = models.SexUnknown
+	}
+
+	var previousSire, previousDam *uuid.UUID
+	if previous != nil {
+		previousSire, previousDam = previous.SireID, previous.DamID
+	}
+
+	parents := []struct {
+		id, previous *uuid.UUID
+		sex          string
+		invalid      error
+	}{
+		{parentage.SireID, previousSire, models.SexMale, ErrInvalidSire},
+		{parentage.DamID, previousDam, models.SexFemale, ErrInvalidDam},
+	}
+	for _, parent := range parents {
+		if parent.id == nil || (parent.previous != nil && *parent.previous == *parent.id) {
+			continue
+		}
+		if *parent.id == animalID {
+			return ErrParentCycle
+		}
+		if _, err := checkParent(s.Repo, *parent.id, farmID, animalType, parent.sex, parent.invalid); err != nil {
+			return err
+		}
+		descendant, err := s.Repo.IsDescendant(*parent.id, animalID)
+		if err != nil {
+			return err
+		} else if descendant {
+			return ErrParentCycle
+		}
+	}
+	return nil
+}
+
+// checkParent loads the parent and returns invalid unless it is an animal of
+// the farm and type whose sex is the given one or unknown.
+func checkParent(repo *repository.AnimalRepository, parentID, farmID uuid.UUID, animalType, sex string, invalid error) (*models.Animal, error) {
+	parent, err := repo.GetAnimalByID(parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil || parent.FarmID != farmID || !strings.EqualFold(parent.Type, animalType) ||
+		(parent.Sex != sex && parent.Sex != models.SexUnknown) {
+		return nil, invalid
+	}
+	return parent, nil
+}
+
+func (s *AnimalService) DeleteAnimal(animalID uuid.UUID, actor *models.AuditActor) error {
+	return s.Repo.DeleteAnimal(animalID, actor)
 }
 
-func (s *AnimalService) DeleteAnimal(animalID uuid.UUID) error {
-	return s.Repo.DeleteAnimal(animalID)
+// GetOverdueAnimals lists the animals of the farm that were not fed or watered
+// within the given intervals. A zero interval falls back to the configured one.
+func (s *AnimalService) GetOverdueAnimals(farmID uuid.UUID, feedingInterval, wateringInterval time.Duration) ([]models.OverdueAnimal, error) {
+	if feedingInterval <= 0 {
+		feedingInterval = s.careConfig.FeedingInterval
+	}
+	if wateringInterval <= 0 {
+		wateringInterval = s.careConfig.WateringInterval
+	}
+
+	now := time.Now()
+	return s.Repo.GetOverdueAnimals(farmID, now.Add(-feedingInterval), now.Add(-wateringInterval))
 }
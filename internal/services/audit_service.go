@@ -0,0 +1,31 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type AuditService struct {
+	auditRepo *repository.AuditRepository
+}
+
+func NewAuditService(auditRepo *repository.AuditRepository) *AuditService {
+	return &AuditService{auditRepo: auditRepo}
+}
+
+// IsAuditedType reports whether changes to the entity type are audited.
+func (s *AuditService) IsAuditedType(entityType string) bool {
+	return s.auditRepo.IsAuditedType(entityType)
+}
+
+// GetEntityHistory returns a page of the audit entries of the entity.
+func (s *AuditService) GetEntityHistory(entityType string, entityID uuid.UUID, page *models.PageQuery) ([]models.AuditEntry, int, error) {
+	return s.auditRepo.GetEntityHistory(entityType, entityID, page)
+}
+
+// GetFarmTimeline returns a page of the audit entries of the farm.
+func (s *AuditService) GetFarmTimeline(filter *models.AuditFilter, page *models.PageQuery) ([]models.AuditEntry, int, error) {
+	return s.auditRepo.GetFarmTimeline(filter, page)
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BreedingService struct {
+	breedingRepo *repository.BreedingRepository
+	animalRepo   *repository.AnimalRepository
+}
+
+func NewBreedingService(breedingRepo *repository.BreedingRepository, animalRepo *repository.AnimalRepository) *BreedingService {
+	return &BreedingService{breedingRepo: breedingRepo, animalRepo: animalRepo}
+}
+
+var (
+	ErrUnknownGestation  = errors.New("no gestation length is known for this animal type, give the expected due date")
+	ErrDueBeforeBreeding = errors.New("expected due date must be after the breeding date")
+	ErrEmptyBirth        = errors.New("a birth needs at least one live or stillborn offspring")
+)
+
+// CreateEvent records a mating or insemination of the dam. The sire, when
+// given, must be a male of the same type on the dam's farm.
+func (s *BreedingService) CreateEvent(req *models.BreedingEventReq, userID uuid.UUID, actor *models.AuditActor) (*models.BreedingEvent, error) {
+	dam, err := s.animalRepo.GetAnimalByID(req.DamID)
+	if err != nil {
+		return nil, err
+	} else if dam == nil {
+		return nil, ErrAnimalNotFound
+	}
+	if dam.Sex == models.SexMale {
+		return nil, ErrInvalidDam
+	}
+
+	event := &models.BreedingEvent{
+		ID:              uuid.New(),
+		FarmID:          dam.FarmID,
+		DamID:           dam.ID,
+		DamName:         dam.Name,
+		SireDescription: req.SireDescription,
+		Method:          req.Method,
+		BredAt:          req.BredAt,
+		Status:          models.BreedingBred,
+		Notes:           req.Notes,
+		CreatedBy:       &userID,
+	}
+
+	if req.SireID != nil {
+		sire, err := checkParent(s.animalRepo, *req.SireID, dam.FarmID, dam.Type, models.SexMale, ErrInvalidSire)
+		if err != nil {
+			return nil, err
+		}
+		event.SireID = &sire.ID
+		event.SireName = sire.Name
+	}
+
+	if req.ExpectedDueDate != nil {
+		event.ExpectedDueDate = truncateToDay(*req.ExpectedDueDate)
+	} else {
+		days, ok := models.GestationDays[strings.ToLower(dam.Type)]
+		if !ok {
+			return nil, ErrUnknownGestation
+		}
+		event.ExpectedDueDate = truncateToDay(req.BredAt).AddDate(0, 0, days)
+	}
+	if !event.ExpectedDueDate.After(truncateToDay(req.BredAt)) {
+		return nil, ErrDueBeforeBreeding
+	}
+
+	if err := s.breedingRepo.CreateEvent(event, actor); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (s *BreedingService) GetEventByID(id uuid.UUID) (*models.BreedingEvent, error) {
+	return s.breedingRepo.GetEventByID(id)
+}
+
+func (s *BreedingService) DeleteEvent(id uuid.UUID, actor *models.AuditActor) error {
+	return s.breedingRepo.DeleteEvent(id, actor)
+}
+
+func (s *BreedingService) AddPregnancyCheck(eventID uuid.UUID, req *models.PregnancyCheckReq, userID uuid.UUID, actor *models.AuditActor) (*models.PregnancyCheck, error) {
+	check := &models.PregnancyCheck{
+		ID:                uuid.New(),
+		BreedingEventID:   eventID,
+		PregnancyCheckReq: *req,
+	}
+	if err := s.breedingRepo.AddPregnancyCheck(check, userID, actor); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// RecordBirth closes the breeding event with its birth. Live offspring are
+// added to the dam's farm with her type, born on the day of the birth.
+func (s *BreedingService) RecordBirth(eventID uuid.UUID, req *models.BirthReq, userID uuid.UUID, actor *models.AuditActor) (*models.Birth, error) {
+	if len(req.Offspring) == 0 && req.StillbornCount == 0 {
+		return nil, ErrEmptyBirth
+	}
+
+	event, err := s.breedingRepo.GetEventByID(eventID)
+	if err != nil {
+		return nil, err
+	} else if event == nil {
+		return nil, repository.ErrBreedingEventNotFound
+	}
+	dam, err := s.animalRepo.GetAnimalByID(event.DamID)
+	if err != nil {
+		return nil, err
+	} else if dam == nil {
+		return nil, ErrAnimalNotFound
+	}
+
+	birth := &models.Birth{
+		ID:              uuid.New(),
+		BreedingEventID: eventID,
+		BornAt:          req.BornAt,
+		StillbornCount:  req.StillbornCount,
+		Notes:           req.Notes,
+		Offspring:       make([]models.AnimalWithoutTime, 0, len(req.Offspring)),
+	}
+	for _, offspring := range req.Offspring {
+		animal := models.AnimalWithoutTime{ID: uuid.New()}
+		animal.FarmID = dam.FarmID
+		animal.Name = offspring.Name
+		animal.Type = dam.Type
+		animal.Weight = offspring.Weight
+		animal.HealthStatus = "Healthy"
+		animal.DateOfBirth = req.BornAt
+		animal.Sex = offspring.Sex
+		if animal.Sex == "" {
+			animal.Sex = models.SexUnknown
+		}
+		birth.Offspring = append(birth.Offspring, animal)
+	}
+
+	if err := s.breedingRepo.RecordBirth(birth, userID, actor); err != nil {
+		return nil, err
+	}
+	return birth, nil
+}
+
+// GetUpcomingBirths returns the pregnancies of the farm due within the given
+// number of days, including overdue ones.
+func (s *BreedingService) GetUpcomingBirths(farmID uuid.UUID, days int) ([]models.UpcomingBirth, error) {
+	return s.breedingRepo.GetUpcomingBirths(farmID, truncateToDay(time.Now()).AddDate(0, 0, days))
+}
+
+// GetReproductiveHistory returns every breeding event of the female with its
+// outcome, and her birth totals.
+func (s *BreedingService) GetReproductiveHistory(animalID uuid.UUID) (*models.ReproductiveHistory, error) {
+	events, err := s.breedingRepo.GetEventsByDam(animalID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &models.ReproductiveHistory{AnimalID: animalID, BreedingEvents: events}
+	for _, event := range events {
+		if event.Birth == nil {
+			continue
+		}
+		history.Births++
+		history.LiveOffspring += event.Birth.LiveCount
+		history.Stillborn += event.Birth.StillbornCount
+	}
+	return history, nil
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type FarmMemberService struct {
+	repo     *repository.FarmMemberRepository
+	farmRepo *repository.FarmRepository
+	userRepo *repository.UserRepository
+}
+
+func NewFarmMemberService(repo *repository.FarmMemberRepository, farmRepo *repository.FarmRepository,
+	userRepo *repository.UserRepository) *FarmMemberService {
+	return &FarmMemberService{repo: repo, farmRepo: farmRepo, userRepo: userRepo}
+}
+
+var (
+	ErrAlreadyMember        = errors.New("user is already a member of this farm")
+	ErrInvitationExists     = errors.New("a pending invitation for this email already exists")
+	ErrInvitationNotPending = errors.New("invitation is no longer pending")
+	ErrOwnerRoleImmutable   = errors.New("the farm owner's role cannot be changed or removed")
+)
+
+func (s *FarmMemberService) GetMembers(farmID uuid.UUID, page *models.PageQuery) ([]models.FarmMember, int, error) {
+	return s.repo.GetMembersByFarmID(farmID, page)
+}
+
+func (s *FarmMemberService) InviteMember(farmID, invitedBy uuid.UUID, req *models.InviteMemberReq, actor *models.AuditActor) (*models.FarmInvitation, error) {
+	email := strings.TrimSpace(req.Email)
+
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		role, err := s.repo.GetMemberRole(farmID, user.ID)
+		if err != nil {
+			return nil, err
+		} else if role != "" {
+			return nil, ErrAlreadyMember
+		}
+	}
+
+	pending, err := s.repo.GetPendingInvitation(farmID, email)
+	if err != nil {
+		return nil, err
+	} else if pending != nil {
+		return nil, ErrInvitationExists
+	}
+
+	invitation := &models.FarmInvitation{
+		ID:        uuid.New(),
+		FarmID:    farmID,
+		Email:     email,
+		Role:      req.Role,
+		InvitedBy: invitedBy,
+		Status:    models.InvitationPending,
+	}
+	if err := s.repo.CreateInvitation(invitation, actor); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+func (s *FarmMemberService) GetFarmInvitations(farmID uuid.UUID, page *models.PageQuery) ([]models.FarmInvitation, int, error) {
+	return s.repo.GetInvitationsByFarmID(farmID, page)
+}
+
+func (s *FarmMemberService) GetInvitationByID(invitationID uuid.UUID) (*models.FarmInvitation, error) {
+	return s.repo.GetInvitationByID(invitationID)
+}
+
+func (s *FarmMemberService) RevokeInvitation(invitationID uuid.UUID, actor *models.AuditActor) error {
+	err := s.repo.SetInvitationStatus(invitationID, models.InvitationRevoked, actor)
+	if errors.Is(err, repository.ErrInvitationNotFound) {
+		return ErrInvitationNotPending
+	}
+	return err
+}
+
+// GetMyInvitations returns the pending invitations addressed to the user's email.
+func (s *FarmMemberService) GetMyInvitations(userID uuid.UUID, page *models.PageQuery) ([]models.FarmInvitation, int, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, 0, err
+	} else if user == nil {
+		return nil, 0, ErrForbidden
+	}
+
+	return s.repo.GetPendingInvitationsByEmail(user.Email, page)
+}
+
+// RespondToInvitation accepts or declines an invitation on behalf of the user it
+// was sent to.
+func (s *FarmMemberService) RespondToInvitation(invitationID, userID uuid.UUID, accept bool, actor *models.AuditActor) error {
+	invitation, err := s.repo.GetInvitationByID(invitationID)
+	if err != nil {
+		return err
+	} else if invitation == nil {
+		return repository.ErrInvitationNotFound
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil || !strings.EqualFold(user.Email, invitation.Email) {
+		return ErrForbidden
+	}
+
+	if invitation.Status != models.InvitationPending {
+		return ErrInvitationNotPending
+	}
+
+	if accept {
+		err = s.repo.AcceptInvitation(invitation, userID, actor)
+	} else {
+		err = s.repo.SetInvitationStatus(invitationID, models.InvitationDeclined, actor)
+	}
+	if errors.Is(err, repository.ErrInvitationNotFound) {
+		return ErrInvitationNotPending
+	}
+	return err
+}
+
+func (s *FarmMemberService) UpdateMemberRole(farmID, userID uuid.UUID, role string, actor *models.AuditActor) (*models.FarmMember, error) {
+	if err := s.checkNotOwner(farmID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateMemberRole(farmID, userID, role, actor); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMember(farmID, userID)
+}
+
+func (s *FarmMemberService) RemoveMember(farmID, userID uuid.UUID, actor *models.AuditActor) error {
+	if err := s.checkNotOwner(farmID, userID); err != nil {
+		return err
+	}
+
+	return s.repo.RemoveMember(farmID, userID, actor)
+}
+
+func (s *FarmMemberService) checkNotOwner(farmID, userID uuid.UUID) error {
+	farm, err := s.farmRepo.GetFarmByID(farmID)
+	if err != nil {
+		return err
+	} else if farm == nil {
+		return ErrFarmNotFound
+	}
+
+	if farm.OwnerID == userID {
+		return ErrOwnerRoleImmutable
+	}
+	return nil
+}
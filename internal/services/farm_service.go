@@ -1,6 +1,7 @@
 package services
 
 import (
+	"errors"
 	"farmish/internal/models"
 	"farmish/internal/repository"
 	"time"
@@ -9,31 +10,54 @@ import (
 )
 
 type FarmService struct {
-	repo *repository.FarmRepository
+	repo       *repository.FarmRepository
+	memberRepo *repository.FarmMemberRepository
 }
 
-func NewFarmService(repo *repository.FarmRepository) *FarmService {
-	return &FarmService{repo: repo}
+func NewFarmService(repo *repository.FarmRepository, memberRepo *repository.FarmMemberRepository) *FarmService {
+	return &FarmService{repo: repo, memberRepo: memberRepo}
 }
 
-func (s *FarmService) CreateFarm(farm *models.Farm) error {
+var ErrNewOwnerNotMember = errors.New("new owner must already be a member of the farm")
+
+func (s *FarmService) CreateFarm(farm *models.Farm, actor *models.AuditActor) error {
 	farm.ID = uuid.New()
 	farm.CreatedAt = time.Now()
-	return s.repo.CreateFarm(farm)
+	return s.repo.CreateFarm(farm, actor)
 }
 
 func (s *FarmService) GetFarmByID(farmID uuid.UUID) (*models.Farm, error) {
 	return s.repo.GetFarmByID(farmID)
 }
 
-func (s *FarmService) GetAllFarms() ([]models.Farm, error) {
-	return s.repo.GetAllFarms()
+func (s *FarmService) GetAllFarms(userID uuid.UUID, page *models.PageQuery) ([]models.Farm, int, error) {
+	return s.repo.GetAllFarms(userID, page)
+}
+
+func (s *FarmService) GetFarmsByMemberID(userID uuid.UUID) ([]models.Farm, error) {
+	return s.repo.GetFarmsByMemberID(userID)
 }
 
-func (s *FarmService) UpdateFarm(farm *models.UpdateFarmRequest) error {
-	return s.repo.UpdateFarm(farm)
+func (s *FarmService) UpdateFarm(farm *models.UpdateFarmRequest, actor *models.AuditActor) error {
+	existing, err := s.repo.GetFarmByID(farm.ID)
+	if err != nil {
+		return err
+	} else if existing == nil {
+		return ErrFarmNotFound
+	}
+
+	if farm.OwnerID != existing.OwnerID {
+		role, err := s.memberRepo.GetMemberRole(farm.ID, farm.OwnerID)
+		if err != nil {
+			return err
+		} else if role == "" {
+			return ErrNewOwnerNotMember
+		}
+	}
+
+	return s.repo.UpdateFarm(farm, existing.OwnerID, actor)
 }
 
-func (s *FarmService) DeleteFarm(farmID uuid.UUID) error {
-	return s.repo.DeleteFarm(farmID)
+func (s *FarmService) DeleteFarm(farmID uuid.UUID, actor *models.AuditActor) error {
+	return s.repo.DeleteFarm(farmID, actor)
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"farmish/internal/models"
 	"farmish/internal/repository"
+	"log"
 
 	"github.com/google/uuid"
 )
@@ -12,27 +13,29 @@ type FeedingRecordService struct {
 	feedingRecordRepo *repository.FeedingRecordRepository
 	animalRepo        *repository.AnimalRepository
 	foodRepo          *repository.FoodRepository
+	alertService      *AlertService
 }
 
 func NewFeedingRecordService(
 	feedingRecordRepo *repository.FeedingRecordRepository,
 	animalRepo *repository.AnimalRepository,
 	foodRepo *repository.FoodRepository,
+	alertService *AlertService,
 ) *FeedingRecordService {
 	return &FeedingRecordService{
 		feedingRecordRepo: feedingRecordRepo,
 		animalRepo:        animalRepo,
 		foodRepo:          foodRepo,
+		alertService:      alertService,
 	}
 }
 
 var (
-	ErrAnimalNotFound       = errors.New("animal not found")
-	ErrFoodNotFound         = errors.New("food not found")
-	ErrInsufficientQuantity = errors.New("insufficient food quantity")
+	ErrAnimalNotFound = errors.New("animal not found")
+	ErrFoodNotFound   = errors.New("food not found")
 )
 
-func (s *FeedingRecordService) CreateFeedingRecord(record *models.FeedingRecordWithoutTime) error {
+func (s *FeedingRecordService) CreateFeedingRecord(record *models.FeedingRecordWithoutTime, userID uuid.UUID, actor *models.AuditActor) error {
 	animal, err := s.animalRepo.GetAnimalByID(record.AnimalID)
 	if err != nil {
 		return err
@@ -45,31 +48,65 @@ func (s *FeedingRecordService) CreateFeedingRecord(record *models.FeedingRecordW
 		return err
 	} else if food == nil {
 		return ErrFoodNotFound
+	} else if food.FarmID != animal.FarmID {
+		return ErrFoodNotInFarm
 	}
 
-	if food.Quantity < record.Quantity {
-		return ErrInsufficientQuantity
-	}
+	record.ID = uuid.New()
+	record.RecordedBy = &userID
 
-	newQuantity := food.Quantity - record.Quantity
+	movement, err := s.feedingRecordRepo.CreateFeedingRecord(record, actor)
+	if err != nil {
+		if errors.Is(err, repository.ErrStockItemNotFound) {
+			return ErrFoodNotFound
+		}
+		return err
+	}
 
-	record.ID = uuid.New()
+	s.checkFoodStock(movement)
 
-	return s.feedingRecordRepo.CreateFeedingRecord(record, newQuantity)
+	return nil
 }
 
 func (s *FeedingRecordService) GetFeedingRecordByID(id uuid.UUID) (*models.FeedingRecordDetailed, error) {
 	return s.feedingRecordRepo.GetFeedingRecordByID(id)
 }
 
-func (s *FeedingRecordService) GetFeedingRecordsByAnimalID(animalID uuid.UUID) ([]models.FeedingRecordDetailed, error) {
-	return s.feedingRecordRepo.GetFeedingRecordsByAnimalID(animalID)
+func (s *FeedingRecordService) GetFeedingRecords(filter *models.RecordFilter, page *models.PageQuery) ([]models.FeedingRecordDetailed, int, error) {
+	return s.feedingRecordRepo.GetFeedingRecords(filter, page)
 }
 
-func (s *FeedingRecordService) UpdateFeedingRecord(record *models.FeedingRecordWithoutTime) error {
-	return s.feedingRecordRepo.UpdateFeedingRecord(record)
+func (s *FeedingRecordService) UpdateFeedingRecord(record *models.FeedingRecordWithoutTime, actor *models.AuditActor) error {
+	movement, err := s.feedingRecordRepo.UpdateFeedingRecord(record, actor)
+	if err != nil {
+		return err
+	}
+
+	if movement != nil {
+		s.checkFoodStock(movement)
+	}
+
+	return nil
 }
 
-func (s *FeedingRecordService) DeleteFeedingRecord(id uuid.UUID) error {
-	return s.feedingRecordRepo.DeleteFeedingRecord(id)
+func (s *FeedingRecordService) DeleteFeedingRecord(id uuid.UUID, actor *models.AuditActor) error {
+	return s.feedingRecordRepo.DeleteFeedingRecord(id, actor)
+}
+
+// checkFoodStock raises a low stock alert when the movement took the food
+// below its minimum threshold.
+func (s *FeedingRecordService) checkFoodStock(movement *models.StockMovement) {
+	food, err := s.foodRepo.GetFoodByID(movement.ItemID)
+	if err != nil {
+		log.Printf("failed to load food %s for low stock check: %v", movement.ItemID, err)
+		return
+	} else if food == nil {
+		// A record of a food in the trash was corrected.
+		return
+	}
+
+	food.Quantity = movement.BalanceAfter - movement.Quantity
+	if err := s.alertService.CheckFoodStock(food, movement.BalanceAfter); err != nil {
+		log.Printf("failed to create low stock alert for food %s: %v", food.ID, err)
+	}
 }
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FeedingScheduleService struct {
+	scheduleRepo         *repository.FeedingScheduleRepository
+	animalRepo           *repository.AnimalRepository
+	foodRepo             *repository.FoodRepository
+	feedingRecordService *FeedingRecordService
+}
+
+func NewFeedingScheduleService(
+	scheduleRepo *repository.FeedingScheduleRepository,
+	animalRepo *repository.AnimalRepository,
+	foodRepo *repository.FoodRepository,
+	feedingRecordService *FeedingRecordService,
+) *FeedingScheduleService {
+	return &FeedingScheduleService{
+		scheduleRepo:         scheduleRepo,
+		animalRepo:           animalRepo,
+		foodRepo:             foodRepo,
+		feedingRecordService: feedingRecordService,
+	}
+}
+
+var (
+	ErrInvalidScheduleTarget = errors.New("exactly one of animal_id and animal_type is required")
+	ErrInvalidTimeOfDay      = errors.New("times_of_day must use the HH:MM format")
+	ErrAnimalNotInFarm       = errors.New("animal does not belong to this farm")
+	ErrFoodNotInFarm         = errors.New("food does not belong to this farm")
+)
+
+func (s *FeedingScheduleService) CreateSchedule(req *models.FeedingScheduleReq, actor *models.AuditActor) (*models.FeedingSchedule, error) {
+	if (req.AnimalID == nil) == (req.AnimalType == "") {
+		return nil, ErrInvalidScheduleTarget
+	}
+
+	if req.AnimalID != nil {
+		animal, err := s.animalRepo.GetAnimalByID(*req.AnimalID)
+		if err != nil {
+			return nil, err
+		} else if animal == nil {
+			return nil, ErrAnimalNotFound
+		} else if animal.FarmID != req.FarmID {
+			return nil, ErrAnimalNotInFarm
+		}
+	}
+
+	if err := s.validateDetails(req.FarmID, &req.ScheduleDetails); err != nil {
+		return nil, err
+	}
+
+	schedule := &models.FeedingSchedule{
+		ID:                 uuid.New(),
+		FeedingScheduleReq: *req,
+		Active:             true,
+	}
+	if err := s.scheduleRepo.CreateSchedule(schedule, actor); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// validateDetails checks that the food belongs to the farm and normalizes the
+// times of day to HH:MM.
+func (s *FeedingScheduleService) validateDetails(farmID uuid.UUID, details *models.ScheduleDetails) error {
+	food, err := s.foodRepo.GetFoodByID(details.FoodID)
+	if err != nil {
+		return err
+	} else if food == nil {
+		return ErrFoodNotFound
+	} else if food.FarmID != farmID {
+		return ErrFoodNotInFarm
+	}
+
+	for i, value := range details.TimesOfDay {
+		t, err := time.Parse("15:04", value)
+		if err != nil {
+			return ErrInvalidTimeOfDay
+		}
+		details.TimesOfDay[i] = t.Format("15:04")
+	}
+	if details.DaysOfWeek == nil {
+		details.DaysOfWeek = []int64{}
+	}
+
+	return nil
+}
+
+func (s *FeedingScheduleService) GetSchedulesByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.FeedingSchedule, int, error) {
+	return s.scheduleRepo.GetSchedulesByFarmID(farmID, page)
+}
+
+func (s *FeedingScheduleService) GetScheduleByID(id uuid.UUID) (*models.FeedingSchedule, error) {
+	return s.scheduleRepo.GetScheduleByID(id)
+}
+
+func (s *FeedingScheduleService) UpdateSchedule(id uuid.UUID, req *models.UpdateFeedingScheduleReq, actor *models.AuditActor) error {
+	schedule, err := s.scheduleRepo.GetScheduleByID(id)
+	if err != nil {
+		return err
+	} else if schedule == nil {
+		return repository.ErrFeedingScheduleNotFound
+	}
+
+	if err := s.validateDetails(schedule.FarmID, &req.ScheduleDetails); err != nil {
+		return err
+	}
+
+	return s.scheduleRepo.UpdateSchedule(id, req, actor)
+}
+
+func (s *FeedingScheduleService) DeleteSchedule(id uuid.UUID, actor *models.AuditActor) error {
+	return s.scheduleRepo.DeleteSchedule(id, actor)
+}
+
+// GenerateTasks materializes the feeding tasks due on the given day. Running
+// it again for the same day only adds tasks for new schedules or animals.
+func (s *FeedingScheduleService) GenerateTasks(day time.Time, actor *models.AuditActor) (int64, error) {
+	tasks, err := s.scheduleRepo.GetDueTasks(day)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range tasks {
+		tasks[i].ID = uuid.New()
+	}
+
+	return s.scheduleRepo.CreateTasks(tasks, actor)
+}
+
+func (s *FeedingScheduleService) GetTasks(filter *models.FeedingTaskFilter, page *models.PageQuery) ([]models.FeedingTask, int, error) {
+	return s.scheduleRepo.GetTasks(filter, page)
+}
+
+// CompleteTask records the feeding of a pending task and returns the ID of the
+// created feeding record.
+func (s *FeedingScheduleService) CompleteTask(taskID, userID uuid.UUID, req *models.CompleteFeedingTaskReq, actor *models.AuditActor) (uuid.UUID, error) {
+	recordID := uuid.New()
+
+	movement, err := s.scheduleRepo.CompleteTask(taskID, recordID, userID, req, actor)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	s.feedingRecordService.checkFoodStock(movement)
+
+	return recordID, nil
+}
+
+func (s *FeedingScheduleService) SkipTask(taskID, userID uuid.UUID, actor *models.AuditActor) error {
+	return s.scheduleRepo.SkipTask(taskID, userID, actor)
+}
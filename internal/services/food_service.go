@@ -15,23 +15,23 @@ func NewFoodService(repo *repository.FoodRepository) *FoodService {
 	return &FoodService{FoodRepo: repo}
 }
 
-func (s *FoodService) AddFoodToWarehouse(food *models.FoodWithoutTime) error {
+func (s *FoodService) AddFoodToWarehouse(food *models.FoodWithoutTime, userID uuid.UUID, actor *models.AuditActor) error {
 	food.ID = uuid.New()
-	return s.FoodRepo.CreateFood(food)
+	return s.FoodRepo.CreateFood(food, userID, actor)
 }
 
-func (s *FoodService) GetFoodsByFarm(farmID uuid.UUID) ([]models.Food, error) {
-	return s.FoodRepo.GetAllFoods(farmID)
+func (s *FoodService) GetFoodsByFarm(filter *models.StockFilter, page *models.PageQuery) ([]models.Food, int, error) {
+	return s.FoodRepo.GetAllFoods(filter, page)
 }
 
 func (s *FoodService) GetFoodByID(foodID uuid.UUID) (*models.Food, error) {
 	return s.FoodRepo.GetFoodByID(foodID)
 }
 
-func (s *FoodService) UpdateFood(food *models.UpdateFoodReq) error {
-	return s.FoodRepo.UpdateFood(food)
+func (s *FoodService) UpdateFood(food *models.UpdateFoodReq, userID uuid.UUID, actor *models.AuditActor) error {
+	return s.FoodRepo.UpdateFood(food, userID, actor)
 }
 
-func (s *FoodService) RemoveWarehouseFood(foodID uuid.UUID) error {
-	return s.FoodRepo.DeleteFood(foodID)
+func (s *FoodService) RemoveWarehouseFood(foodID uuid.UUID, actor *models.AuditActor) error {
+	return s.FoodRepo.DeleteFood(foodID, actor)
 }
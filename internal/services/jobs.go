@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"farmish/internal/models"
+	"log"
+	"time"
+)
+
+// Job is work that runs periodically in the background. The changes it makes
+// are audited under the actor Run is given, which names the job.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(now time.Time, actor *models.AuditActor) error
+}
+
+// RunJobs starts every job in its own goroutine. Each job runs once right away
+// and then on its interval until ctx is cancelled. Failures are logged and the
+// job is retried on the next tick.
+func RunJobs(ctx context.Context, jobs ...Job) {
+	for _, job := range jobs {
+		go runJob(ctx, job)
+	}
+}
+
+func runJob(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	actor := &models.AuditActor{RequestID: "job: " + job.Name}
+	for {
+		if err := job.Run(time.Now(), actor); err != nil {
+			log.Printf("job %s failed: %v", job.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
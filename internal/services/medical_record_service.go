@@ -4,6 +4,9 @@ import (
 	"errors"
 	"farmish/internal/models"
 	"farmish/internal/repository"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,23 +15,27 @@ type MedicalRecordService struct {
 	medicalRecordRepo *repository.MedicalRecordRepository
 	animalRepo        *repository.AnimalRepository
 	medicineRepo      *repository.MedicineRepository
+	alertService      *AlertService
 }
 
 func NewMedicalRecordService(medicalRecordRepo *repository.MedicalRecordRepository,
 	animalRepo *repository.AnimalRepository,
-	medicineRepo *repository.MedicineRepository) *MedicalRecordService {
+	medicineRepo *repository.MedicineRepository,
+	alertService *AlertService) *MedicalRecordService {
 	return &MedicalRecordService{
 		medicalRecordRepo: medicalRecordRepo,
 		animalRepo:        animalRepo,
 		medicineRepo:      medicineRepo,
+		alertService:      alertService,
 	}
 }
 
 var (
 	ErrMedicalRecordNotFound = errors.New("medical record not found")
+	ErrAnimalUnderWithdrawal = errors.New("animal is under a drug withdrawal period")
 )
 
-func (s *MedicalRecordService) CreateMedicalRecord(record *models.MedicalRecordWithoutTime) error {
+func (s *MedicalRecordService) CreateMedicalRecord(record *models.MedicalRecordWithoutTime, userID uuid.UUID, actor *models.AuditActor) error {
 	animal, err := s.animalRepo.GetAnimalByID(record.AnimalID)
 	if err != nil {
 		return err
@@ -41,31 +48,98 @@ func (s *MedicalRecordService) CreateMedicalRecord(record *models.MedicalRecordW
 		return err
 	} else if medicine == nil {
 		return ErrMedicineNotExist
+	} else if medicine.FarmID != animal.FarmID {
+		return ErrMedicineNotInFarm
 	}
 
-	if medicine.Quantity < record.Quantity {
-		return ErrInsufficientQuantity
+	record.ID = uuid.New()
+	record.RecordedBy = &userID
+
+	movement, err := s.medicalRecordRepo.CreateMedicalRecord(record, actor)
+	if err != nil {
+		if errors.Is(err, repository.ErrStockItemNotFound) {
+			return ErrMedicineNotExist
+		}
+		return err
 	}
 
-	newQuantity := medicine.Quantity - record.Quantity
+	s.checkMedicineStock(movement)
 
-	record.ID = uuid.New()
-
-	return s.medicalRecordRepo.CreateMedicalRecord(record, newQuantity)
+	return nil
 }
 
 func (s *MedicalRecordService) GetMedicalRecordByID(recordID uuid.UUID) (*models.MedicalRecordDetailed, error) {
 	return s.medicalRecordRepo.GetMedicalRecordByID(recordID)
 }
 
-func (s *MedicalRecordService) GetMedicalRecordsByAnimalID(animalID uuid.UUID) ([]*models.MedicalRecordDetailed, error) {
-	return s.medicalRecordRepo.GetMedicalRecordsByAnimalID(animalID)
+func (s *MedicalRecordService) GetMedicalRecords(filter *models.RecordFilter, page *models.PageQuery) ([]*models.MedicalRecordDetailed, int, error) {
+	return s.medicalRecordRepo.GetMedicalRecords(filter, page)
 }
 
-func (s *MedicalRecordService) UpdateMedicalRecord(record *models.MedicalRecordWithoutTime) error {
-	return s.medicalRecordRepo.UpdateMedicalRecord(record)
+func (s *MedicalRecordService) UpdateMedicalRecord(record *models.MedicalRecordWithoutTime, actor *models.AuditActor) error {
+	movement, err := s.medicalRecordRepo.UpdateMedicalRecord(record, actor)
+	if err != nil {
+		return err
+	}
+
+	if movement != nil {
+		s.checkMedicineStock(movement)
+	}
+
+	return nil
+}
+
+func (s *MedicalRecordService) DeleteMedicalRecord(recordID uuid.UUID, actor *models.AuditActor) error {
+	return s.medicalRecordRepo.DeleteMedicalRecord(recordID, actor)
+}
+
+// GetWithdrawals returns the animals of the farm whose products are currently
+// held back by a withdrawal period.
+func (s *MedicalRecordService) GetWithdrawals(farmID uuid.UUID) ([]models.AnimalWithdrawal, error) {
+	return s.medicalRecordRepo.GetWithdrawals(farmID, time.Now())
 }
 
-func (s *MedicalRecordService) DeleteMedicalRecord(recordID uuid.UUID) error {
-	return s.medicalRecordRepo.DeleteMedicalRecord(recordID)
+// CheckAnimalCleared returns ErrAnimalUnderWithdrawal when the animal's meat,
+// milk or eggs may not be used on the given day. Production and sale records
+// must pass this check; selling or slaughtering an animal counts as meat.
+func (s *MedicalRecordService) CheckAnimalCleared(animalID uuid.UUID, product string, day time.Time) error {
+	until, err := s.medicalRecordRepo.GetWithdrawalEnd(animalID, product, day)
+	if err != nil {
+		return err
+	}
+	if until != nil {
+		return fmt.Errorf("%w: %s may not be used before %s", ErrAnimalUnderWithdrawal, product, until.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// CheckGroupCleared returns ErrAnimalUnderWithdrawal when the product of any
+// active animal of the type on the farm may not be used on the given day.
+func (s *MedicalRecordService) CheckGroupCleared(farmID uuid.UUID, animalType, product string, day time.Time) error {
+	count, until, err := s.medicalRecordRepo.GetGroupWithdrawalEnd(farmID, animalType, product, day)
+	if err != nil {
+		return err
+	}
+	if until != nil {
+		return fmt.Errorf("%w: %s of %d %s may not be used before %s", ErrAnimalUnderWithdrawal, product, count, animalType, until.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// checkMedicineStock raises a low stock alert when the movement took the
+// medicine below its minimum threshold.
+func (s *MedicalRecordService) checkMedicineStock(movement *models.StockMovement) {
+	medicine, err := s.medicineRepo.GetMedicineByID(movement.ItemID)
+	if err != nil {
+		log.Printf("failed to load medicine %s for low stock check: %v", movement.ItemID, err)
+		return
+	} else if medicine == nil {
+		// A record of a medicine in the trash was corrected.
+		return
+	}
+
+	medicine.Quantity = movement.BalanceAfter - movement.Quantity
+	if err := s.alertService.CheckMedicineStock(medicine, movement.BalanceAfter); err != nil {
+		log.Printf("failed to create low stock alert for medicine %s: %v", medicine.ID, err)
+	}
 }
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"farmish/pkg/config"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MedicineBatchService struct {
+	batchRepo            *repository.MedicineBatchRepository
+	medicalRecordService *MedicalRecordService
+	alertService         *AlertService
+	stockConfig          config.StockConfig
+}
+
+func NewMedicineBatchService(
+	batchRepo *repository.MedicineBatchRepository,
+	medicalRecordService *MedicalRecordService,
+	alertService *AlertService,
+	stockConfig config.StockConfig,
+) *MedicineBatchService {
+	return &MedicineBatchService{
+		batchRepo:            batchRepo,
+		medicalRecordService: medicalRecordService,
+		alertService:         alertService,
+		stockConfig:          stockConfig,
+	}
+}
+
+func (s *MedicineBatchService) CreateBatch(medicineID uuid.UUID, req *models.MedicineBatchReq, userID uuid.UUID, actor *models.AuditActor) (*models.MedicineBatch, error) {
+	batch := &models.MedicineBatch{
+		ID:              uuid.New(),
+		MedicineID:      medicineID,
+		LotNumber:       req.LotNumber,
+		ExpiryDate:      req.ExpiryDate,
+		InitialQuantity: req.Quantity,
+	}
+	if err := s.batchRepo.CreateBatch(batch, req.UnitCost, userID, actor); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+func (s *MedicineBatchService) GetBatches(medicineID uuid.UUID, includeEmpty bool, page *models.PageQuery) ([]models.MedicineBatch, int, error) {
+	return s.batchRepo.GetBatches(medicineID, includeEmpty, page)
+}
+
+// GetExpiringBatches returns the batches of the farm with stock left that
+// expire within the given number of days or have already expired. A negative
+// days only returns expired batches.
+func (s *MedicineBatchService) GetExpiringBatches(farmID uuid.UUID, days int, page *models.PageQuery) ([]models.ExpiringBatch, int, error) {
+	return s.batchRepo.GetExpiringBatches(farmID, truncateToDay(time.Now()).AddDate(0, 0, days), page)
+}
+
+// DiscardBatch writes off what is left of the batch as waste.
+func (s *MedicineBatchService) DiscardBatch(id uuid.UUID, userID uuid.UUID, actor *models.AuditActor) error {
+	movement, err := s.batchRepo.DiscardBatch(id, userID, actor)
+	if err != nil {
+		return err
+	}
+
+	s.medicalRecordService.checkMedicineStock(movement)
+
+	return nil
+}
+
+// SendExpiryAlerts raises an alert for every batch with stock left that
+// entered the expiry warning period, and another one once it has expired.
+func (s *MedicineBatchService) SendExpiryAlerts(now time.Time) error {
+	batches, err := s.batchRepo.GetBatchesToAlert(truncateToDay(now).Add(s.stockConfig.ExpiryWarning))
+	if err != nil {
+		return err
+	}
+
+	var alerted []uuid.UUID
+	for _, batch := range batches {
+		alertType := models.AlertTypeBatchExpiring
+		message := fmt.Sprintf("Batch %s of %q expires on %s: %.2f %s left",
+			batch.LotNumber, batch.MedicineName, batch.ExpiryDate.Format("2006-01-02"), batch.Quantity, batch.UnitOfMeasure)
+		if batch.Expired {
+			alertType = models.AlertTypeBatchExpired
+			message = fmt.Sprintf("Batch %s of %q expired on %s: %.2f %s should be discarded",
+				batch.LotNumber, batch.MedicineName, batch.ExpiryDate.Format("2006-01-02"), batch.Quantity, batch.UnitOfMeasure)
+		}
+		if err := s.alertService.CreateAlert(batch.FarmID, alertType, message); err != nil {
+			return err
+		}
+		alerted = append(alerted, batch.ID)
+	}
+
+	if len(alerted) == 0 {
+		return nil
+	}
+	return s.batchRepo.MarkAlerted(alerted)
+}
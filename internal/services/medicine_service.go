@@ -22,24 +22,24 @@ var (
 	ErrMedicineNotExist          = errors.New("medicine with this ID not found")
 )
 
-func (s *MedicineService) CreateMedicine(medicine *models.MedicineWithoutTime) error {
+func (s *MedicineService) CreateMedicine(medicine *models.MedicineWithoutTime, userID uuid.UUID, actor *models.AuditActor) error {
 	medicine.ID = uuid.New()
 	if medicine.Quantity < medicine.MinThreshold {
 		return ErrQuantityLessThanThreshold
 	}
 
-	return s.repo.CreateMedicine(medicine)
+	return s.repo.CreateMedicine(medicine, userID, actor)
 }
 
-func (s *MedicineService) GetAllMedicines(farmID uuid.UUID) ([]models.Medicine, error) {
-	return s.repo.GetAllMedicines(farmID)
+func (s *MedicineService) GetAllMedicines(filter *models.StockFilter, page *models.PageQuery) ([]models.Medicine, int, error) {
+	return s.repo.GetAllMedicines(filter, page)
 }
 
 func (s *MedicineService) GetMedicineByID(id uuid.UUID) (*models.Medicine, error) {
 	return s.repo.GetMedicineByID(id)
 }
 
-func (s *MedicineService) UpdateMedicine(medicine *models.MedicineWithoutTime) error {
+func (s *MedicineService) UpdateMedicine(medicine *models.MedicineWithoutTime, userID uuid.UUID, actor *models.AuditActor) error {
 	existing, err := s.repo.GetMedicineByID(medicine.ID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch existing medicine: %v", err)
@@ -52,10 +52,10 @@ func (s *MedicineService) UpdateMedicine(medicine *models.MedicineWithoutTime) e
 		return ErrQuantityLessThanThreshold
 	}
 
-	return s.repo.UpdateMedicine(medicine)
+	return s.repo.UpdateMedicine(medicine, userID, actor)
 }
 
-func (s *MedicineService) DeleteMedicine(id uuid.UUID) error {
+func (s *MedicineService) DeleteMedicine(id uuid.UUID, actor *models.AuditActor) error {
 	existing, err := s.repo.GetMedicineByID(id)
 	if err != nil {
 		return fmt.Errorf("failed to fetch existing medicine: %v", err)
@@ -64,5 +64,5 @@ func (s *MedicineService) DeleteMedicine(id uuid.UUID) error {
 		return ErrMedicineNotExist
 	}
 
-	return s.repo.DeleteMedicine(id)
+	return s.repo.DeleteMedicine(id, actor)
 }
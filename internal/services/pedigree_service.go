@@ -0,0 +1,187 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// MaxPedigreeGenerations bounds how far pedigree queries walk the lineage.
+const MaxPedigreeGenerations = 10
+
+type PedigreeService struct {
+	animalRepo *repository.AnimalRepository
+}
+
+func NewPedigreeService(animalRepo *repository.AnimalRepository) *PedigreeService {
+	return &PedigreeService{animalRepo: animalRepo}
+}
+
+// GetPedigree returns the ancestry tree of the animal up to the given number
+// of generations, with the animal's inbreeding coefficient over that tree.
+func (s *PedigreeService) GetPedigree(animalID uuid.UUID, generations int) (*models.Pedigree, error) {
+	ancestry, err := s.animalRepo.GetAncestry([]uuid.UUID{animalID}, generations)
+	if err != nil {
+		return nil, err
+	}
+	animal, ok := ancestry[animalID]
+	if !ok {
+		return nil, ErrAnimalNotFound
+	}
+
+	k := newKinship(ancestry)
+	return &models.Pedigree{
+		Generations:           generations,
+		InbreedingCoefficient: k.coefficient(animal.SireID, animal.DamID),
+		Tree:                  *pedigreeNode(ancestry, animalID, generations),
+	}, nil
+}
+
+func pedigreeNode(ancestry map[uuid.UUID]models.PedigreeAnimal, id uuid.UUID, generations int) *models.PedigreeNode {
+	animal, ok := ancestry[id]
+	if !ok {
+		return nil
+	}
+
+	node := &models.PedigreeNode{PedigreeAnimal: animal}
+	if generations > 0 {
+		if animal.SireID != nil {
+			node.Sire = pedigreeNode(ancestry, *animal.SireID, generations-1)
+		}
+		if animal.DamID != nil {
+			node.Dam = pedigreeNode(ancestry, *animal.DamID, generations-1)
+		}
+	}
+	return node
+}
+
+func (s *PedigreeService) GetDescendants(animalID uuid.UUID, generations int) ([]models.Descendant, error) {
+	return s.animalRepo.GetDescendants(animalID, generations)
+}
+
+// GetInbreeding computes the inbreeding coefficient an offspring of the sire
+// and dam would have, over the given number of generations of their ancestry.
+func (s *PedigreeService) GetInbreeding(sireID, damID uuid.UUID, generations int) (*models.InbreedingResult, error) {
+	dam, err := s.animalRepo.GetAnimalByID(damID)
+	if err != nil {
+		return nil, err
+	} else if dam == nil || dam.Sex == models.SexMale {
+		return nil, ErrInvalidDam
+	}
+	if _, err := checkParent(s.animalRepo, sireID, dam.FarmID, dam.Type, models.SexMale, ErrInvalidSire); err != nil {
+		return nil, err
+	}
+
+	ancestry, err := s.animalRepo.GetAncestry([]uuid.UUID{sireID, damID}, generations)
+	if err != nil {
+		return nil, err
+	}
+
+	k := newKinship(ancestry)
+	result := &models.InbreedingResult{
+		SireID:          sireID,
+		DamID:           damID,
+		Generations:     generations,
+		Coefficient:     k.coefficient(&sireID, &damID),
+		CommonAncestors: []models.PedigreeAnimal{},
+	}
+
+	damLine := k.lineage(damID)
+	for id := range k.lineage(sireID) {
+		if damLine[id] {
+			result.CommonAncestors = append(result.CommonAncestors, ancestry[id])
+		}
+	}
+	return result, nil
+}
+
+// kinship computes coefficients of kinship over a known ancestry. Animals
+// whose parents are not in the ancestry are treated as unrelated founders.
+// Parentage is checked when it is set, but concurrent updates can still make
+// an animal its own ancestor; the walks track what is on their current path
+// and stop at a repeat, so such a loop counts as unrelated instead of
+// recursing forever.
+type kinship struct {
+	ancestry  map[uuid.UUID]models.PedigreeAnimal
+	memo      map[[2]uuid.UUID]float64
+	ancestors map[uuid.UUID]map[uuid.UUID]bool
+	pairPath  map[[2]uuid.UUID]bool
+	linePath  map[uuid.UUID]bool
+}
+
+func newKinship(ancestry map[uuid.UUID]models.PedigreeAnimal) *kinship {
+	return &kinship{
+		ancestry:  ancestry,
+		memo:      make(map[[2]uuid.UUID]float64),
+		ancestors: make(map[uuid.UUID]map[uuid.UUID]bool),
+		pairPath:  make(map[[2]uuid.UUID]bool),
+		linePath:  make(map[uuid.UUID]bool),
+	}
+}
+
+func (k *kinship) parents(id uuid.UUID) (sire, dam *uuid.UUID) {
+	animal := k.ancestry[id]
+	return animal.SireID, animal.DamID
+}
+
+// lineage returns the animal and all its known ancestors.
+func (k *kinship) lineage(id uuid.UUID) map[uuid.UUID]bool {
+	if line, ok := k.ancestors[id]; ok {
+		return line
+	}
+	if k.linePath[id] {
+		return map[uuid.UUID]bool{id: true}
+	}
+	k.linePath[id] = true
+	defer delete(k.linePath, id)
+
+	line := map[uuid.UUID]bool{id: true}
+	sire, dam := k.parents(id)
+	for _, parent := range []*uuid.UUID{sire, dam} {
+		if parent == nil {
+			continue
+		}
+		for ancestor := range k.lineage(*parent) {
+			line[ancestor] = true
+		}
+	}
+	k.ancestors[id] = line
+	return line
+}
+
+// coefficient returns the probability that an allele taken at random from a
+// and one from b are identical by descent. It equals the inbreeding
+// coefficient of their offspring.
+func (k *kinship) coefficient(a, b *uuid.UUID) float64 {
+	if a == nil || b == nil {
+		return 0
+	}
+
+	key := [2]uuid.UUID{*a, *b}
+	if value, ok := k.memo[key]; ok {
+		return value
+	}
+	if k.pairPath[key] {
+		return 0
+	}
+	k.pairPath[key] = true
+	defer delete(k.pairPath, key)
+
+	var value float64
+	if *a == *b {
+		sire, dam := k.parents(*a)
+		value = (1 + k.coefficient(sire, dam)) / 2
+	} else {
+		// Expand the animal that is not an ancestor of the other.
+		if k.lineage(*b)[*a] {
+			a, b = b, a
+		}
+		sire, dam := k.parents(*a)
+		value = (k.coefficient(sire, b) + k.coefficient(dam, b)) / 2
+	}
+
+	k.memo[key] = value
+	k.memo[[2]uuid.UUID{*b, *a}] = value
+	return value
+}
@@ -0,0 +1,57 @@
+package services
+
+import "farmish/internal/models"
+
+// Permission names an action a farm member may be allowed to perform.
+type Permission string
+
+const (
+	PermViewFarm             Permission = "view_farm"
+	PermManageFarm           Permission = "manage_farm"
+	PermManageMembers        Permission = "manage_members"
+	PermManageAnimals        Permission = "manage_animals"
+	PermEditAnimals          Permission = "edit_animals"
+	PermManageFoods          Permission = "manage_foods"
+	PermManageMedicines      Permission = "manage_medicines"
+	PermManageSchedules      Permission = "manage_schedules"
+	PermManageTreatmentPlans Permission = "manage_treatment_plans"
+	PermManagePurchases      Permission = "manage_purchases"
+	PermManageBreeding       Permission = "manage_breeding"
+	PermRecordFeeding        Permission = "record_feeding"
+	PermRecordWatering       Permission = "record_watering"
+	PermRecordTreatment      Permission = "record_treatment"
+	PermRecordProduction     Permission = "record_production"
+	PermDeleteRecords        Permission = "delete_records"
+	PermManageAlerts         Permission = "manage_alerts"
+	PermViewAudit            Permission = "view_audit"
+)
+
+var rolePermissions = map[string][]Permission{
+	models.RoleOwner: {
+		PermViewFarm, PermManageFarm, PermManageMembers, PermManageAnimals, PermEditAnimals, PermManageFoods,
+		PermManageMedicines, PermManageSchedules, PermManageTreatmentPlans, PermManagePurchases, PermManageBreeding,
+		PermRecordFeeding, PermRecordWatering, PermRecordTreatment, PermRecordProduction, PermDeleteRecords, PermManageAlerts,
+		PermViewAudit,
+	},
+	models.RoleManager: {
+		PermViewFarm, PermManageAnimals, PermEditAnimals, PermManageFoods, PermManageMedicines, PermManageSchedules,
+		PermManageTreatmentPlans, PermManagePurchases, PermManageBreeding, PermRecordFeeding, PermRecordWatering, PermRecordTreatment,
+		PermRecordProduction, PermDeleteRecords, PermManageAlerts, PermViewAudit,
+	},
+	models.RoleWorker: {
+		PermViewFarm, PermEditAnimals, PermRecordFeeding, PermRecordWatering, PermRecordProduction,
+	},
+	models.RoleVeterinarian: {
+		PermViewFarm, PermEditAnimals, PermManageMedicines, PermManageTreatmentPlans, PermManageBreeding, PermRecordTreatment,
+	},
+}
+
+// RoleHasPermission reports whether members with the given role may perform the action.
+func RoleHasPermission(role string, perm Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
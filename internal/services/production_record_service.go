@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProductionRecordService struct {
+	productionRepo       *repository.ProductionRecordRepository
+	animalRepo           *repository.AnimalRepository
+	medicalRecordService *MedicalRecordService
+}
+
+func NewProductionRecordService(productionRepo *repository.ProductionRecordRepository,
+	animalRepo *repository.AnimalRepository, medicalRecordService *MedicalRecordService) *ProductionRecordService {
+	return &ProductionRecordService{
+		productionRepo:       productionRepo,
+		animalRepo:           animalRepo,
+		medicalRecordService: medicalRecordService,
+	}
+}
+
+var (
+	ErrProductionSource = errors.New("give either animal_id, or farm_id and animal_type for a group")
+	ErrAnimalInactive   = errors.New("animal is not active")
+	ErrNoActiveAnimals  = errors.New("farm has no active animals of this type")
+)
+
+// CreateRecord records the output of an animal or of the active animals of a
+// type on a farm. Milk and eggs are rejected while a withdrawal period runs
+// for the animal, or for any animal of the group.
+func (s *ProductionRecordService) CreateRecord(req *models.ProductionRecordReq, userID uuid.UUID, actor *models.AuditActor) (*models.ProductionRecord, error) {
+	record := &models.ProductionRecord{
+		ID:         uuid.New(),
+		Product:    req.Product,
+		Quantity:   req.Quantity,
+		Unit:       req.Unit,
+		ProducedAt: time.Now(),
+		Notes:      req.Notes,
+		RecordedBy: &userID,
+	}
+	if req.ProducedAt != nil {
+		record.ProducedAt = *req.ProducedAt
+	}
+
+	switch {
+	case req.AnimalID != nil && req.FarmID == nil && req.AnimalType == "":
+		animal, err := s.animalRepo.GetAnimalByID(*req.AnimalID)
+		if err != nil {
+			return nil, err
+		} else if animal == nil {
+			return nil, ErrAnimalNotFound
+		}
+		if animal.Status != models.AnimalActive {
+			return nil, fmt.Errorf("%w: %s is %s", ErrAnimalInactive, animal.Name, animal.Status)
+		}
+		if hasWithdrawal(req.Product) {
+			if err := s.medicalRecordService.CheckAnimalCleared(animal.ID, req.Product, record.ProducedAt); err != nil {
+				return nil, err
+			}
+		}
+		record.FarmID = animal.FarmID
+		record.AnimalID = &animal.ID
+		record.AnimalName = animal.Name
+		record.AnimalType = animal.Type
+	case req.AnimalID == nil && req.FarmID != nil && req.AnimalType != "":
+		counts, err := s.animalRepo.CountActiveByType(*req.FarmID)
+		if err != nil {
+			return nil, err
+		}
+		if counts[req.AnimalType] == 0 {
+			return nil, ErrNoActiveAnimals
+		}
+		if hasWithdrawal(req.Product) {
+			if err := s.medicalRecordService.CheckGroupCleared(*req.FarmID, req.AnimalType, req.Product, record.ProducedAt); err != nil {
+				return nil, err
+			}
+		}
+		record.FarmID = *req.FarmID
+		record.AnimalType = req.AnimalType
+	default:
+		return nil, ErrProductionSource
+	}
+
+	if err := s.productionRepo.CreateRecord(record, actor); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// hasWithdrawal reports whether treatments can hold the product back. Wool is
+// never affected.
+func hasWithdrawal(product string) bool {
+	return product == models.ProductMilk || product == models.ProductEggs
+}
+
+func (s *ProductionRecordService) GetRecordByID(id uuid.UUID) (*models.ProductionRecord, error) {
+	return s.productionRepo.GetRecordByID(id)
+}
+
+func (s *ProductionRecordService) GetRecords(filter *models.ReportFilter, page *models.PageQuery) ([]models.ProductionRecord, int, error) {
+	return s.productionRepo.GetRecords(filter, page)
+}
+
+func (s *ProductionRecordService) DeleteRecord(id uuid.UUID, actor *models.AuditActor) error {
+	return s.productionRepo.DeleteRecord(id, actor)
+}
+
+// GetYields sums the production per period for the farm, per animal or per
+// animal type. Type yields are also divided among the type's active animals.
+func (s *ProductionRecordService) GetYields(filter *models.ReportFilter) ([]models.ProductionYield, error) {
+	yields, err := s.productionRepo.GetYields(filter)
+	if err != nil {
+		return nil, err
+	}
+	if yields == nil {
+		return []models.ProductionYield{}, nil
+	}
+
+	if filter.GroupBy == models.ReportGroupType {
+		counts, err := s.animalRepo.CountActiveByType(filter.FarmID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range yields {
+			yields[i].Animals = counts[yields[i].GroupID]
+			if yields[i].Animals > 0 {
+				perHead := yields[i].Quantity / float64(yields[i].Animals)
+				yields[i].PerHead = &perHead
+			}
+		}
+	}
+
+	return yields, nil
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type PurchaseOrderService struct {
+	orderRepo    *repository.PurchaseOrderRepository
+	supplierRepo *repository.SupplierRepository
+	foodRepo     *repository.FoodRepository
+	medicineRepo *repository.MedicineRepository
+}
+
+func NewPurchaseOrderService(
+	orderRepo *repository.PurchaseOrderRepository,
+	supplierRepo *repository.SupplierRepository,
+	foodRepo *repository.FoodRepository,
+	medicineRepo *repository.MedicineRepository,
+) *PurchaseOrderService {
+	return &PurchaseOrderService{
+		orderRepo:    orderRepo,
+		supplierRepo: supplierRepo,
+		foodRepo:     foodRepo,
+		medicineRepo: medicineRepo,
+	}
+}
+
+var (
+	ErrInvalidOrderItem   = errors.New("each item needs exactly one of food_id and medicine_id")
+	ErrSupplierNotInFarm  = errors.New("supplier does not belong to this farm")
+	ErrOrderItemDuplicate = errors.New("each food or medicine may appear only once per order")
+)
+
+func (s *PurchaseOrderService) CreateOrder(req *models.PurchaseOrderReq, userID uuid.UUID, actor *models.AuditActor) (*models.PurchaseOrder, error) {
+	if err := s.validateDetails(req.FarmID, &req.PurchaseOrderDetails); err != nil {
+		return nil, err
+	}
+
+	order := &models.PurchaseOrder{
+		ID:               uuid.New(),
+		FarmID:           req.FarmID,
+		SupplierID:       req.SupplierID,
+		Status:           models.PurchaseOrderDraft,
+		ExpectedDelivery: req.ExpectedDelivery,
+		Notes:            req.Notes,
+		CreatedBy:        &userID,
+	}
+	if err := s.orderRepo.CreateOrder(order, req.Items, actor); err != nil {
+		return nil, err
+	}
+
+	return s.orderRepo.GetOrderByID(order.ID)
+}
+
+// validateDetails checks that the supplier and every ordered food or
+// medicine belong to the farm.
+func (s *PurchaseOrderService) validateDetails(farmID uuid.UUID, details *models.PurchaseOrderDetails) error {
+	supplier, err := s.supplierRepo.GetSupplierByID(details.SupplierID)
+	if err != nil {
+		return err
+	} else if supplier == nil {
+		return repository.ErrSupplierNotFound
+	} else if supplier.FarmID != farmID {
+		return ErrSupplierNotInFarm
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, item := range details.Items {
+		if (item.FoodID == nil) == (item.MedicineID == nil) {
+			return ErrInvalidOrderItem
+		}
+
+		if item.FoodID != nil {
+			food, err := s.foodRepo.GetFoodByID(*item.FoodID)
+			if err != nil {
+				return err
+			} else if food == nil {
+				return ErrFoodNotFound
+			} else if food.FarmID != farmID {
+				return ErrFoodNotInFarm
+			}
+			if seen[*item.FoodID] {
+				return ErrOrderItemDuplicate
+			}
+			seen[*item.FoodID] = true
+		} else {
+			medicine, err := s.medicineRepo.GetMedicineByID(*item.MedicineID)
+			if err != nil {
+				return err
+			} else if medicine == nil {
+				return ErrMedicineNotExist
+			} else if medicine.FarmID != farmID {
+				return ErrMedicineNotInFarm
+			}
+			if seen[*item.MedicineID] {
+				return ErrOrderItemDuplicate
+			}
+			seen[*item.MedicineID] = true
+		}
+	}
+
+	return nil
+}
+
+func (s *PurchaseOrderService) GetOrders(filter *models.PurchaseOrderFilter, page *models.PageQuery) ([]models.PurchaseOrder, int, error) {
+	return s.orderRepo.GetOrders(filter, page)
+}
+
+func (s *PurchaseOrderService) GetOrderByID(id uuid.UUID) (*models.PurchaseOrder, error) {
+	return s.orderRepo.GetOrderByID(id)
+}
+
+// UpdateOrder replaces the details of a draft order.
+func (s *PurchaseOrderService) UpdateOrder(id uuid.UUID, details *models.PurchaseOrderDetails, actor *models.AuditActor) error {
+	farmID, err := s.orderRepo.GetFarmIDByOrderID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateDetails(farmID, details); err != nil {
+		return err
+	}
+
+	return s.orderRepo.UpdateOrder(id, details, actor)
+}
+
+func (s *PurchaseOrderService) DeleteOrder(id uuid.UUID, actor *models.AuditActor) error {
+	return s.orderRepo.DeleteOrder(id, actor)
+}
+
+// SubmitOrder marks a draft order as sent to the supplier.
+func (s *PurchaseOrderService) SubmitOrder(id uuid.UUID, actor *models.AuditActor) error {
+	return s.orderRepo.SetStatus(id, models.PurchaseOrderOrdered, actor, models.PurchaseOrderDraft)
+}
+
+// CancelOrder cancels an order that was not fully received. Quantities already
+// received stay in stock.
+func (s *PurchaseOrderService) CancelOrder(id uuid.UUID, actor *models.AuditActor) error {
+	return s.orderRepo.SetStatus(id, models.PurchaseOrderCancelled, actor,
+		models.PurchaseOrderDraft, models.PurchaseOrderOrdered, models.PurchaseOrderPartiallyReceived)
+}
+
+// ReceiveOrder adds the delivered quantities to stock and returns the updated
+// order.
+func (s *PurchaseOrderService) ReceiveOrder(id uuid.UUID, req *models.ReceivePurchaseOrderReq, userID uuid.UUID, actor *models.AuditActor) (*models.PurchaseOrder, error) {
+	if err := s.orderRepo.ReceiveOrder(id, req.Items, userID, actor); err != nil {
+		return nil, err
+	}
+
+	return s.orderRepo.GetOrderByID(id)
+}
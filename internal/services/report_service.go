@@ -0,0 +1,69 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+)
+
+type ReportService struct {
+	reportRepo *repository.ReportRepository
+}
+
+func NewReportService(reportRepo *repository.ReportRepository) *ReportService {
+	return &ReportService{reportRepo: reportRepo}
+}
+
+func (s *ReportService) GetFeedingConsumption(filter *models.ReportFilter) ([]models.FeedingReportRow, error) {
+	report, err := s.reportRepo.GetFeedingConsumption(filter)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		report = []models.FeedingReportRow{}
+	}
+	return report, nil
+}
+
+// GetFeedConversion computes the feed conversion ratio, feed consumed per unit
+// of weight gained, per animal, per type or for the whole farm.
+func (s *ReportService) GetFeedConversion(filter *models.ReportFilter) ([]models.FeedConversion, error) {
+	gains, err := s.reportRepo.GetFeedGains(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []models.FeedConversion{}
+	index := make(map[string]int)
+	for _, gain := range gains {
+		groupID, groupName := filter.FarmID.String(), ""
+		switch filter.GroupBy {
+		case models.ReportGroupAnimal:
+			groupID, groupName = gain.AnimalID.String(), gain.AnimalName
+		case models.ReportGroupType:
+			groupID, groupName = gain.AnimalType, gain.AnimalType
+		}
+
+		i, ok := index[groupID]
+		if !ok {
+			i = len(result)
+			index[groupID] = i
+			result = append(result, models.FeedConversion{GroupID: groupID, GroupName: groupName})
+		}
+
+		if gain.Measurements < 2 {
+			continue
+		}
+		result[i].Animals++
+		result[i].FeedConsumed += gain.FeedConsumed
+		result[i].WeightGained += gain.LastWeight - gain.FirstWeight
+	}
+
+	for i := range result {
+		if result[i].WeightGained > 0 {
+			ratio := result[i].FeedConsumed / result[i].WeightGained
+			result[i].FeedConversionRatio = &ratio
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type StockService struct {
+	movementRepo *repository.StockMovementRepository
+	foodRepo     *repository.FoodRepository
+	medicineRepo *repository.MedicineRepository
+	alertService *AlertService
+}
+
+func NewStockService(movementRepo *repository.StockMovementRepository,
+	foodRepo *repository.FoodRepository,
+	medicineRepo *repository.MedicineRepository,
+	alertService *AlertService) *StockService {
+	return &StockService{
+		movementRepo: movementRepo,
+		foodRepo:     foodRepo,
+		medicineRepo: medicineRepo,
+		alertService: alertService,
+	}
+}
+
+// signedQuantity returns the ledger quantity for a manual movement: purchases
+// always add and waste always removes stock.
+func signedQuantity(req *models.StockMovementReq) float64 {
+	switch req.MovementType {
+	case models.MovementPurchase:
+		return math.Abs(req.Quantity)
+	case models.MovementWaste:
+		return -math.Abs(req.Quantity)
+	default:
+		return req.Quantity
+	}
+}
+
+func (s *StockService) RecordFoodMovement(foodID uuid.UUID, req *models.StockMovementReq, userID uuid.UUID, actor *models.AuditActor) (*models.StockMovement, error) {
+	food, err := s.foodRepo.GetFoodByID(foodID)
+	if err != nil {
+		return nil, err
+	} else if food == nil {
+		return nil, ErrFoodNotFound
+	}
+
+	movement := &models.StockMovement{
+		ItemType:     models.StockItemFood,
+		ItemID:       foodID,
+		MovementType: req.MovementType,
+		Quantity:     signedQuantity(req),
+		UnitCost:     req.UnitCost,
+		Notes:        req.Notes,
+		CreatedBy:    &userID,
+	}
+
+	if err := s.movementRepo.RecordMovement(movement, actor); err != nil {
+		return nil, err
+	}
+
+	if err := s.alertService.CheckFoodStock(food, movement.BalanceAfter); err != nil {
+		log.Printf("failed to create low stock alert for food %s: %v", food.ID, err)
+	}
+
+	return movement, nil
+}
+
+func (s *StockService) RecordMedicineMovement(medicineID uuid.UUID, req *models.StockMovementReq, userID uuid.UUID, actor *models.AuditActor) (*models.StockMovement, error) {
+	medicine, err := s.medicineRepo.GetMedicineByID(medicineID)
+	if err != nil {
+		return nil, err
+	} else if medicine == nil {
+		return nil, ErrMedicineNotExist
+	}
+
+	movement := &models.StockMovement{
+		ItemType:     models.StockItemMedicine,
+		ItemID:       medicineID,
+		MovementType: req.MovementType,
+		Quantity:     signedQuantity(req),
+		UnitCost:     req.UnitCost,
+		Notes:        req.Notes,
+		CreatedBy:    &userID,
+	}
+
+	if err := s.movementRepo.RecordMovement(movement, actor); err != nil {
+		return nil, err
+	}
+
+	if err := s.alertService.CheckMedicineStock(medicine, movement.BalanceAfter); err != nil {
+		log.Printf("failed to create low stock alert for medicine %s: %v", medicine.ID, err)
+	}
+
+	return movement, nil
+}
+
+func (s *StockService) GetMovements(itemType string, itemID uuid.UUID, page *models.PageQuery) ([]models.StockMovement, int, error) {
+	return s.movementRepo.GetMovements(itemType, itemID, page)
+}
+
+func (s *StockService) Reconcile(itemType string, itemID uuid.UUID) (*models.StockReconciliation, error) {
+	return s.movementRepo.GetReconciliation(itemType, itemID)
+}
+
+func (s *StockService) ReconcileFarm(farmID uuid.UUID) ([]models.StockReconciliation, error) {
+	return s.movementRepo.GetFarmReconciliation(farmID)
+}
+
+// Forecast estimates, for every food and medicine of the farm, how many days
+// are left until it falls below its threshold and until it runs out. Historical
+// use is averaged over the last windowDays.
+func (s *StockService) Forecast(farmID uuid.UUID, windowDays int) ([]models.StockForecast, error) {
+	usages, err := s.movementRepo.GetStockUsage(farmID, time.Now().AddDate(0, 0, -windowDays), windowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	forecasts := make([]models.StockForecast, 0, len(usages))
+	for _, usage := range usages {
+		forecast := models.StockForecast{
+			StockUsage:        usage,
+			EstimatedDailyUse: math.Max(usage.HistoricalDailyUse, usage.PlannedDailyUse),
+		}
+		if forecast.EstimatedDailyUse > 0 {
+			untilThreshold := math.Max(usage.Quantity-usage.MinThreshold, 0) / forecast.EstimatedDailyUse
+			untilEmpty := usage.Quantity / forecast.EstimatedDailyUse
+			forecast.DaysUntilThreshold = &untilThreshold
+			forecast.DaysUntilEmpty = &untilEmpty
+		}
+		forecasts = append(forecasts, forecast)
+	}
+
+	return forecasts, nil
+}
+
+// ReorderList returns the items expected to fall below their threshold within
+// leadDays, soonest first. The suggested quantity covers the estimated use of
+// coverDays after the lead time while staying above the threshold.
+func (s *StockService) ReorderList(farmID uuid.UUID, windowDays, leadDays, coverDays int) ([]models.ReorderSuggestion, error) {
+	forecasts, err := s.Forecast(farmID, windowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := []models.ReorderSuggestion{}
+	for _, forecast := range forecasts {
+		if forecast.DaysUntilThreshold == nil || *forecast.DaysUntilThreshold > float64(leadDays) {
+			continue
+		}
+		needed := forecast.EstimatedDailyUse*float64(leadDays+coverDays) + forecast.MinThreshold - forecast.Quantity
+		suggestions = append(suggestions, models.ReorderSuggestion{
+			StockForecast:     forecast,
+			SuggestedQuantity: math.Ceil(math.Max(needed, 0)),
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return *suggestions[i].DaysUntilThreshold < *suggestions[j].DaysUntilThreshold
+	})
+	return suggestions, nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type SupplierService struct {
+	supplierRepo *repository.SupplierRepository
+}
+
+func NewSupplierService(supplierRepo *repository.SupplierRepository) *SupplierService {
+	return &SupplierService{supplierRepo: supplierRepo}
+}
+
+func (s *SupplierService) CreateSupplier(req *models.SupplierReq, actor *models.AuditActor) (*models.Supplier, error) {
+	supplier := &models.Supplier{
+		ID:          uuid.New(),
+		SupplierReq: *req,
+	}
+	if err := s.supplierRepo.CreateSupplier(supplier, actor); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+func (s *SupplierService) GetSuppliersByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.Supplier, int, error) {
+	return s.supplierRepo.GetSuppliersByFarmID(farmID, page)
+}
+
+func (s *SupplierService) GetSupplierByID(id uuid.UUID) (*models.Supplier, error) {
+	return s.supplierRepo.GetSupplierByID(id)
+}
+
+func (s *SupplierService) UpdateSupplier(id uuid.UUID, details *models.SupplierDetails, actor *models.AuditActor) error {
+	return s.supplierRepo.UpdateSupplier(id, details, actor)
+}
+
+func (s *SupplierService) DeleteSupplier(id uuid.UUID, actor *models.AuditActor) error {
+	return s.supplierRepo.DeleteSupplier(id, actor)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TrashService struct {
+	trashRepo    *repository.TrashRepository
+	foodRepo     *repository.FoodRepository
+	medicineRepo *repository.MedicineRepository
+	alertService *AlertService
+	retention    time.Duration
+}
+
+func NewTrashService(trashRepo *repository.TrashRepository, foodRepo *repository.FoodRepository,
+	medicineRepo *repository.MedicineRepository, alertService *AlertService, retention time.Duration) *TrashService {
+	return &TrashService{
+		trashRepo:    trashRepo,
+		foodRepo:     foodRepo,
+		medicineRepo: medicineRepo,
+		alertService: alertService,
+		retention:    retention,
+	}
+}
+
+// GetItems returns the deleted items of the farm.
+func (s *TrashService) GetItems(farmID uuid.UUID, page *models.PageQuery) ([]models.TrashItem, int, error) {
+	items, total, err := s.trashRepo.GetItems(farmID, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.withPurgeTime(items), total, nil
+}
+
+// GetFarms returns the deleted farms owned by the user.
+func (s *TrashService) GetFarms(ownerID uuid.UUID, page *models.PageQuery) ([]models.TrashItem, int, error) {
+	farms, total, err := s.trashRepo.GetFarms(ownerID, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.withPurgeTime(farms), total, nil
+}
+
+func (s *TrashService) withPurgeTime(items []models.TrashItem) []models.TrashItem {
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(s.retention)
+	}
+	return items
+}
+
+// RestoreItem takes the item out of the trash. Restored feeding and medical
+// records consume their stock again, which may raise a low stock alert.
+func (s *TrashService) RestoreItem(itemType string, id uuid.UUID, actor *models.AuditActor) error {
+	movement, err := s.trashRepo.RestoreItem(itemType, id, actor)
+	if err != nil {
+		return err
+	}
+
+	if movement != nil {
+		s.checkStock(movement)
+	}
+	return nil
+}
+
+// checkStock raises a low stock alert when the movement took the food or
+// medicine below its minimum threshold.
+func (s *TrashService) checkStock(movement *models.StockMovement) {
+	previous := movement.BalanceAfter - movement.Quantity
+
+	if movement.ItemType == models.StockItemFood {
+		food, err := s.foodRepo.GetFoodByID(movement.ItemID)
+		if err != nil || food == nil {
+			log.Printf("failed to load food %s for low stock check: %v", movement.ItemID, err)
+			return
+		}
+		food.Quantity = previous
+		if err := s.alertService.CheckFoodStock(food, movement.BalanceAfter); err != nil {
+			log.Printf("failed to create low stock alert for food %s: %v", food.ID, err)
+		}
+		return
+	}
+
+	medicine, err := s.medicineRepo.GetMedicineByID(movement.ItemID)
+	if err != nil || medicine == nil {
+		log.Printf("failed to load medicine %s for low stock check: %v", movement.ItemID, err)
+		return
+	}
+	medicine.Quantity = previous
+	if err := s.alertService.CheckMedicineStock(medicine, movement.BalanceAfter); err != nil {
+		log.Printf("failed to create low stock alert for medicine %s: %v", medicine.ID, err)
+	}
+}
+
+// Purge permanently deletes the items that have been in the trash for longer
+// than the retention period.
+func (s *TrashService) Purge(now time.Time, actor *models.AuditActor) error {
+	purged, err := s.trashRepo.Purge(now.Add(-s.retention), actor)
+	if err != nil {
+		return err
+	}
+	if purged > 0 {
+		log.Printf("purged %d items from the trash", purged)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"errors"
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TreatmentPlanService struct {
+	planRepo             *repository.TreatmentPlanRepository
+	animalRepo           *repository.AnimalRepository
+	medicineRepo         *repository.MedicineRepository
+	medicalRecordService *MedicalRecordService
+	alertService         *AlertService
+}
+
+func NewTreatmentPlanService(
+	planRepo *repository.TreatmentPlanRepository,
+	animalRepo *repository.AnimalRepository,
+	medicineRepo *repository.MedicineRepository,
+	medicalRecordService *MedicalRecordService,
+	alertService *AlertService,
+) *TreatmentPlanService {
+	return &TreatmentPlanService{
+		planRepo:             planRepo,
+		animalRepo:           animalRepo,
+		medicineRepo:         medicineRepo,
+		medicalRecordService: medicalRecordService,
+		alertService:         alertService,
+	}
+}
+
+var (
+	ErrMedicineNotInFarm = errors.New("medicine does not belong to this farm")
+)
+
+func (s *TreatmentPlanService) CreatePlan(req *models.TreatmentPlanReq, actor *models.AuditActor) (*models.TreatmentPlan, error) {
+	medicine, err := s.medicineRepo.GetMedicineByID(req.MedicineID)
+	if err != nil {
+		return nil, err
+	} else if medicine == nil {
+		return nil, ErrMedicineNotExist
+	} else if medicine.FarmID != req.FarmID {
+		return nil, ErrMedicineNotInFarm
+	}
+
+	plan := &models.TreatmentPlan{
+		ID:               uuid.New(),
+		TreatmentPlanReq: *req,
+		Active:           true,
+	}
+	if err := s.planRepo.CreatePlan(plan, actor); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GenerateEvents(actor); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (s *TreatmentPlanService) GetPlansByFarmID(farmID uuid.UUID, page *models.PageQuery) ([]models.TreatmentPlan, int, error) {
+	return s.planRepo.GetPlansByFarmID(farmID, page)
+}
+
+func (s *TreatmentPlanService) GetPlanByID(id uuid.UUID) (*models.TreatmentPlan, error) {
+	return s.planRepo.GetPlanByID(id)
+}
+
+func (s *TreatmentPlanService) UpdatePlan(id uuid.UUID, req *models.UpdateTreatmentPlanReq, actor *models.AuditActor) error {
+	if err := s.planRepo.UpdatePlan(id, req, actor); err != nil {
+		return err
+	}
+
+	_, err := s.GenerateEvents(actor)
+	return err
+}
+
+func (s *TreatmentPlanService) DeletePlan(id uuid.UUID, actor *models.AuditActor) error {
+	return s.planRepo.DeletePlan(id, actor)
+}
+
+// GenerateEvents schedules the next dose of every animal covered by an active
+// plan that has no pending event. It returns the number of new events.
+func (s *TreatmentPlanService) GenerateEvents(actor *models.AuditActor) (int64, error) {
+	enrollments, err := s.planRepo.GetEnrollments()
+	if err != nil {
+		return 0, err
+	}
+
+	var events []models.TreatmentEvent
+	for _, enrollment := range enrollments {
+		dose, dueDate, ok := nextDose(&enrollment)
+		if !ok {
+			continue
+		}
+		events = append(events, models.TreatmentEvent{
+			ID:         uuid.New(),
+			PlanID:     enrollment.PlanID,
+			AnimalID:   enrollment.AnimalID,
+			DoseNumber: dose,
+			DueDate:    dueDate,
+		})
+	}
+
+	return s.planRepo.CreateEvents(events, actor)
+}
+
+// nextDose returns the dose that follows the last event of an enrollment and
+// the day it is due. ok is false while an event is still pending and once a
+// plan without boosters is complete.
+func nextDose(enrollment *models.TreatmentEnrollment) (dose int, dueDate time.Time, ok bool) {
+	if enrollment.LastDoseNumber == 0 {
+		dueDate = enrollment.PlanCreatedAt
+		if enrollment.AnimalCreatedAt.After(dueDate) {
+			dueDate = enrollment.AnimalCreatedAt
+		}
+		if enrollment.FirstDoseAgeDays != nil && enrollment.DateOfBirth != nil {
+			ageDate := enrollment.DateOfBirth.AddDate(0, 0, *enrollment.FirstDoseAgeDays)
+			if ageDate.After(dueDate) {
+				dueDate = ageDate
+			}
+		}
+		return 1, truncateToDay(dueDate), true
+	}
+
+	if enrollment.LastStatus == models.TreatmentEventPending || enrollment.LastDoseDate == nil {
+		return 0, time.Time{}, false
+	}
+
+	dose = enrollment.LastDoseNumber + 1
+	interval := enrollment.DoseIntervalDays
+	if dose > enrollment.DoseCount {
+		if enrollment.BoosterIntervalDays == 0 {
+			return 0, time.Time{}, false
+		}
+		interval = enrollment.BoosterIntervalDays
+	}
+
+	return dose, truncateToDay(enrollment.LastDoseDate.AddDate(0, 0, interval)), true
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func (s *TreatmentPlanService) GetUpcomingEvents(filter *models.TreatmentEventFilter, page *models.PageQuery) ([]models.TreatmentEvent, int, error) {
+	return s.planRepo.GetUpcomingEvents(filter, page)
+}
+
+// CompleteEvent records the treatment of a pending event and schedules the
+// next dose. It returns the ID of the created medical record.
+func (s *TreatmentPlanService) CompleteEvent(eventID, userID uuid.UUID, req *models.CompleteTreatmentEventReq, actor *models.AuditActor) (uuid.UUID, error) {
+	recordID := uuid.New()
+
+	movement, err := s.planRepo.CompleteEvent(eventID, recordID, userID, req, actor)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	s.medicalRecordService.checkMedicineStock(movement)
+
+	if _, err := s.GenerateEvents(actor); err != nil {
+		return uuid.Nil, err
+	}
+
+	return recordID, nil
+}
+
+func (s *TreatmentPlanService) SkipEvent(eventID uuid.UUID, actor *models.AuditActor) error {
+	if err := s.planRepo.SkipEvent(eventID, actor); err != nil {
+		return err
+	}
+
+	_, err := s.GenerateEvents(actor)
+	return err
+}
+
+// SendReminders raises a treatment_due alert for every pending event due on or
+// before the given day. Each event is reminded of once.
+func (s *TreatmentPlanService) SendReminders(day time.Time) error {
+	events, err := s.planRepo.GetEventsToRemind(day)
+	if err != nil {
+		return err
+	}
+
+	var reminded []uuid.UUID
+	for _, event := range events {
+		animal := event.AnimalName
+		if animal == "" {
+			animal = event.AnimalID.String()
+		}
+		message := fmt.Sprintf("%s dose %d for %s is due on %s: %.2f of %s",
+			event.PlanName, event.DoseNumber, animal, event.DueDate.Format("2006-01-02"), event.Quantity, event.MedicineName)
+		if err := s.alertService.CreateAlert(event.FarmID, models.AlertTypeTreatmentDue, message); err != nil {
+			return err
+		}
+		reminded = append(reminded, event.ID)
+	}
+
+	if len(reminded) == 0 {
+		return nil
+	}
+	return s.planRepo.MarkReminded(reminded)
+}
@@ -4,44 +4,96 @@ import (
 	"errors"
 	"farmish/internal/models"
 	"farmish/internal/repository"
+	"farmish/pkg/config"
 	"farmish/pkg/utils"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type UserService struct {
-	UserRepo *repository.UserRepository
+	UserRepo    *repository.UserRepository
+	SessionRepo *repository.SessionRepository
+	jwtConfig   config.JWTConfig
 }
 
-func NewUserService(userRepo *repository.UserRepository) *UserService {
+func NewUserService(userRepo *repository.UserRepository, sessionRepo *repository.SessionRepository,
+	jwtConfig config.JWTConfig) *UserService {
 	return &UserService{
-		UserRepo: userRepo,
+		UserRepo:    userRepo,
+		SessionRepo: sessionRepo,
+		jwtConfig:   jwtConfig,
 	}
 }
 
 var (
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected, session has been revoked")
 )
 
-func (s *UserService) SignUp(user *models.User) (string, error) {
+func (s *UserService) SignUp(user *models.User, actor *models.AuditActor) (models.LoginResponse, error) {
 	hashedPassword, err := utils.HashPassword(user.Password)
 	if err != nil {
-		return "", fmt.Errorf("failed to hash password: %v", err)
+		return models.LoginResponse{}, fmt.Errorf("failed to hash password: %v", err)
 	}
 
 	user.Password = hashedPassword
 	user.ID = uuid.New()
-	err = s.UserRepo.CreateUser(user)
+	// A new user signs up on their own behalf.
+	actor = &models.AuditActor{UserID: user.ID, RequestID: actor.RequestID}
+	err = s.UserRepo.CreateUser(user, actor)
 	if err != nil {
-		return "", err
+		return models.LoginResponse{}, err
 	}
 
-	token, err := utils.CreateToken(user.Email, user.ID)
+	return s.startSession(user)
+}
+
+// startSession opens a new server-side session and issues its first token pair.
+func (s *UserService) startSession(user *models.User) (models.LoginResponse, error) {
+	session := &models.Session{ID: uuid.New(), UserID: user.ID}
+
+	refreshToken, token, err := s.newRefreshToken(session.ID)
 	if err != nil {
-		return "", err
+		return models.LoginResponse{}, err
+	}
+
+	if err := s.SessionRepo.CreateSession(session, token); err != nil {
+		return models.LoginResponse{}, err
 	}
-	return token, nil
+
+	return s.issueTokenPair(user, session.ID, refreshToken)
+}
+
+func (s *UserService) newRefreshToken(sessionID uuid.UUID) (string, *models.RefreshToken, error) {
+	refreshToken, err := utils.GenerateRefreshToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate refresh token: %v", err)
+	}
+
+	token := &models.RefreshToken{
+		ID:        uuid.New(),
+		SessionID: sessionID,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.jwtConfig.RefreshTokenTTL),
+	}
+	return refreshToken, token, nil
+}
+
+func (s *UserService) issueTokenPair(user *models.User, sessionID uuid.UUID, refreshToken string) (models.LoginResponse, error) {
+	accessToken, err := utils.CreateToken(user.Email, user.ID, sessionID, s.jwtConfig.AccessTokenTTL)
+	if err != nil {
+		return models.LoginResponse{}, fmt.Errorf("failed to generate JWT token: %v", err)
+	}
+
+	return models.LoginResponse{
+		ID:           user.ID,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.jwtConfig.AccessTokenTTL.Seconds()),
+	}, nil
 }
 
 func (s *UserService) Login(credentials *models.LoginRequest) (models.LoginResponse, error) {
@@ -58,28 +110,75 @@ func (s *UserService) Login(credentials *models.LoginRequest) (models.LoginRespo
 		return models.LoginResponse{}, ErrInvalidCredentials
 	}
 
-	token, err := utils.CreateToken(user.Email, user.ID)
+	return s.startSession(user)
+}
+
+// RefreshTokens exchanges a refresh token for a new token pair. Every refresh
+// token can be used once; presenting a used token revokes its whole session.
+func (s *UserService) RefreshTokens(refreshToken string) (models.LoginResponse, error) {
+	stored, err := s.SessionRepo.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return models.LoginResponse{}, err
+	}
+	if stored == nil || stored.SessionRevoked || time.Now().After(stored.ExpiresAt) {
+		return models.LoginResponse{}, ErrInvalidRefreshToken
+	}
+	if stored.UsedAt != nil {
+		return models.LoginResponse{}, s.revokeReusedSession(stored.SessionID)
+	}
+
+	user, err := s.UserRepo.GetUserByID(stored.UserID)
 	if err != nil {
-		return models.LoginResponse{}, fmt.Errorf("failed to generate JWT token: %v", err)
+		return models.LoginResponse{}, err
+	} else if user == nil {
+		return models.LoginResponse{}, ErrInvalidRefreshToken
 	}
 
-	resp := models.LoginResponse{
-		Token: token,
-		ID:    user.ID,
+	nextRefreshToken, next, err := s.newRefreshToken(stored.SessionID)
+	if err != nil {
+		return models.LoginResponse{}, err
 	}
 
-	return resp, nil
+	err = s.SessionRepo.RotateRefreshToken(stored.ID, next)
+	if errors.Is(err, repository.ErrRefreshTokenReused) {
+		return models.LoginResponse{}, s.revokeReusedSession(stored.SessionID)
+	} else if err != nil {
+		return models.LoginResponse{}, err
+	}
+
+	return s.issueTokenPair(user, stored.SessionID, nextRefreshToken)
+}
+
+func (s *UserService) revokeReusedSession(sessionID uuid.UUID) error {
+	if err := s.SessionRepo.RevokeSession(sessionID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (s *UserService) Logout(sessionID uuid.UUID) error {
+	return s.SessionRepo.RevokeSession(sessionID)
 }
 
-func (s *UserService) GetAllUsers() ([]*models.User, error) {
-	return s.UserRepo.GetAllUsers()
+func (s *UserService) LogoutAll(userID uuid.UUID) error {
+	return s.SessionRepo.RevokeUserSessions(userID)
+}
+
+// IsSessionActive reports whether the session has not been revoked. It is used
+// by the auth middleware to reject access tokens of revoked sessions.
+func (s *UserService) IsSessionActive(sessionID uuid.UUID) (bool, error) {
+	return s.SessionRepo.IsSessionActive(sessionID)
+}
+
+func (s *UserService) GetVisibleUsers(userID uuid.UUID, page *models.PageQuery) ([]*models.User, int, error) {
+	return s.UserRepo.GetVisibleUsers(userID, page)
 }
 
 func (s *UserService) GetUserByID(userID uuid.UUID) (*models.User, error) {
 	return s.UserRepo.GetUserByID(userID)
 }
 
-func (s *UserService) UpdateUser(user *models.UpdateUser) error {
+func (s *UserService) UpdateUser(user *models.UpdateUser, actor *models.AuditActor) error {
 	if user.Password != "" {
 		hashedPassword, err := utils.HashPassword(user.Password)
 		if err != nil {
@@ -87,9 +186,10 @@ func (s *UserService) UpdateUser(user *models.UpdateUser) error {
 		}
 		user.Password = hashedPassword
 	}
-	return s.UserRepo.UpdateUser(user)
+
+	return s.UserRepo.UpdateUser(user, actor)
 }
 
-func (s *UserService) DeleteUser(userID uuid.UUID) error {
-	return s.UserRepo.DeleteUser(userID)
+func (s *UserService) DeleteUser(userID uuid.UUID, actor *models.AuditActor) error {
+	return s.UserRepo.DeleteUser(userID, actor)
 }
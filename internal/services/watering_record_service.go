@@ -0,0 +1,52 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+type WateringRecordService struct {
+	wateringRecordRepo *repository.WateringRecordRepository
+	animalRepo         *repository.AnimalRepository
+}
+
+func NewWateringRecordService(
+	wateringRecordRepo *repository.WateringRecordRepository,
+	animalRepo *repository.AnimalRepository,
+) *WateringRecordService {
+	return &WateringRecordService{
+		wateringRecordRepo: wateringRecordRepo,
+		animalRepo:         animalRepo,
+	}
+}
+
+func (s *WateringRecordService) CreateWateringRecord(record *models.WateringRecordWithoutTime, actor *models.AuditActor) error {
+	animal, err := s.animalRepo.GetAnimalByID(record.AnimalID)
+	if err != nil {
+		return err
+	} else if animal == nil {
+		return ErrAnimalNotFound
+	}
+
+	record.ID = uuid.New()
+
+	return s.wateringRecordRepo.CreateWateringRecord(record, actor)
+}
+
+func (s *WateringRecordService) GetWateringRecordByID(id uuid.UUID) (*models.WateringRecord, error) {
+	return s.wateringRecordRepo.GetWateringRecordByID(id)
+}
+
+func (s *WateringRecordService) GetWateringRecordsByAnimalID(filter *models.RecordFilter, page *models.PageQuery) ([]models.WateringRecord, int, error) {
+	return s.wateringRecordRepo.GetWateringRecordsByAnimalID(filter, page)
+}
+
+func (s *WateringRecordService) UpdateWateringRecord(record *models.WateringRecordWithoutTime, actor *models.AuditActor) error {
+	return s.wateringRecordRepo.UpdateWateringRecord(record, actor)
+}
+
+func (s *WateringRecordService) DeleteWateringRecord(id uuid.UUID, actor *models.AuditActor) error {
+	return s.wateringRecordRepo.DeleteWateringRecord(id, actor)
+}
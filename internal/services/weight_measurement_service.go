@@ -0,0 +1,234 @@
+package services
+
+import (
+	"farmish/internal/models"
+	"farmish/internal/repository"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WeightMeasurementService struct {
+	weightRepo *repository.WeightMeasurementRepository
+	animalRepo *repository.AnimalRepository
+}
+
+func NewWeightMeasurementService(weightRepo *repository.WeightMeasurementRepository,
+	animalRepo *repository.AnimalRepository) *WeightMeasurementService {
+	return &WeightMeasurementService{weightRepo: weightRepo, animalRepo: animalRepo}
+}
+
+func (s *WeightMeasurementService) CreateMeasurement(animalID uuid.UUID, req *models.WeightMeasurementReq, actor *models.AuditActor) (*models.WeightMeasurement, error) {
+	animal, err := s.animalRepo.GetAnimalByID(animalID)
+	if err != nil {
+		return nil, err
+	} else if animal == nil {
+		return nil, ErrAnimalNotFound
+	}
+
+	measurement := &models.WeightMeasurement{
+		ID:         uuid.New(),
+		AnimalID:   animalID,
+		Weight:     req.Weight,
+		MeasuredAt: time.Now(),
+		Notes:      req.Notes,
+	}
+	if req.MeasuredAt != nil {
+		measurement.MeasuredAt = *req.MeasuredAt
+	}
+
+	if err := s.weightRepo.CreateMeasurement(measurement, actor); err != nil {
+		return nil, err
+	}
+
+	return measurement, nil
+}
+
+func (s *WeightMeasurementService) DeleteMeasurement(animalID, measurementID uuid.UUID, actor *models.AuditActor) error {
+	return s.weightRepo.DeleteMeasurement(animalID, measurementID, actor)
+}
+
+// GetAnimalGrowth returns the weight history of an animal with its average
+// daily gain over the filtered period.
+func (s *WeightMeasurementService) GetAnimalGrowth(filter *models.GrowthFilter) (*models.AnimalGrowth, error) {
+	animal, err := s.animalRepo.GetAnimalByID(filter.AnimalID)
+	if err != nil {
+		return nil, err
+	} else if animal == nil {
+		return nil, ErrAnimalNotFound
+	}
+
+	measurements, err := s.weightRepo.GetMeasurements(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	growth := animalGrowth(measurements)
+	growth.AnimalID = animal.ID
+	growth.AnimalName = animal.Name
+	growth.AnimalType = animal.Type
+	growth.Measurements = measurements
+	if growth.Measurements == nil {
+		growth.Measurements = []models.WeightMeasurement{}
+	}
+
+	return &growth, nil
+}
+
+// GetTypeGrowth returns, per animal type, the average daily gain of the
+// animals and the average weight in each period of the given interval.
+func (s *WeightMeasurementService) GetTypeGrowth(filter *models.GrowthFilter, interval string) ([]models.TypeGrowth, error) {
+	measurements, err := s.weightRepo.GetMeasurements(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[string][]models.WeightMeasurement)
+	for _, measurement := range measurements {
+		byType[measurement.AnimalType] = append(byType[measurement.AnimalType], measurement)
+	}
+
+	result := make([]models.TypeGrowth, 0, len(byType))
+	for animalType, typeMeasurements := range byType {
+		growths := growthByAnimal(typeMeasurements)
+		result = append(result, models.TypeGrowth{
+			Type:             animalType,
+			Animals:          len(growths),
+			AverageDailyGain: averageDailyGain(growths),
+			Curve:            growthCurve(typeMeasurements, interval),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result, nil
+}
+
+// GetBelowAverageGrowth returns the animals whose average daily gain is lower
+// than the average of the animals of the same type on the farm.
+func (s *WeightMeasurementService) GetBelowAverageGrowth(filter *models.GrowthFilter) ([]models.AnimalGrowth, error) {
+	measurements, err := s.weightRepo.GetMeasurements(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	growths := growthByAnimal(measurements)
+
+	byType := make(map[string][]models.AnimalGrowth)
+	for _, growth := range growths {
+		byType[growth.AnimalType] = append(byType[growth.AnimalType], growth)
+	}
+
+	result := []models.AnimalGrowth{}
+	for _, growth := range growths {
+		herdAverage := averageDailyGain(byType[growth.AnimalType])
+		if growth.AverageDailyGain == nil || herdAverage == nil || *growth.AverageDailyGain >= *herdAverage {
+			continue
+		}
+		growth.HerdAverageDailyGain = herdAverage
+		result = append(result, growth)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return *result[i].AverageDailyGain-*result[i].HerdAverageDailyGain <
+			*result[j].AverageDailyGain-*result[j].HerdAverageDailyGain
+	})
+	return result, nil
+}
+
+// growthByAnimal summarizes measurements ordered by animal and time.
+func growthByAnimal(measurements []models.WeightMeasurement) []models.AnimalGrowth {
+	var growths []models.AnimalGrowth
+	for start := 0; start < len(measurements); {
+		end := start
+		for end < len(measurements) && measurements[end].AnimalID == measurements[start].AnimalID {
+			end++
+		}
+
+		growth := animalGrowth(measurements[start:end])
+		growth.AnimalID = measurements[start].AnimalID
+		growth.AnimalName = measurements[start].AnimalName
+		growth.AnimalType = measurements[start].AnimalType
+		growths = append(growths, growth)
+
+		start = end
+	}
+	return growths
+}
+
+// animalGrowth computes the gain between the first and the last of the
+// measurements of a single animal, ordered by time.
+func animalGrowth(measurements []models.WeightMeasurement) models.AnimalGrowth {
+	var growth models.AnimalGrowth
+	if len(measurements) == 0 {
+		return growth
+	}
+
+	first, last := measurements[0], measurements[len(measurements)-1]
+	growth.FirstWeight = first.Weight
+	growth.LastWeight = last.Weight
+	growth.Days = last.MeasuredAt.Sub(first.MeasuredAt).Hours() / 24
+	if growth.Days >= 1 {
+		gain := (last.Weight - first.Weight) / growth.Days
+		growth.AverageDailyGain = &gain
+	}
+	return growth
+}
+
+// averageDailyGain is the mean gain of the animals that have one.
+func averageDailyGain(growths []models.AnimalGrowth) *float64 {
+	var total float64
+	var count int
+	for _, growth := range growths {
+		if growth.AverageDailyGain != nil {
+			total += *growth.AverageDailyGain
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	average := total / float64(count)
+	return &average
+}
+
+func growthCurve(measurements []models.WeightMeasurement, interval string) []models.GrowthPoint {
+	totals := make(map[time.Time]*models.GrowthPoint)
+	for _, measurement := range measurements {
+		period := periodStart(measurement.MeasuredAt, interval)
+		point, ok := totals[period]
+		if !ok {
+			point = &models.GrowthPoint{PeriodStart: period}
+			totals[period] = point
+		}
+		point.AverageWeight += measurement.Weight
+		point.Measurements++
+	}
+
+	curve := make([]models.GrowthPoint, 0, len(totals))
+	for _, point := range totals {
+		point.AverageWeight /= float64(point.Measurements)
+		curve = append(curve, *point)
+	}
+	sort.Slice(curve, func(i, j int) bool { return curve[i].PeriodStart.Before(curve[j].PeriodStart) })
+	return curve
+}
+
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// periodStart truncates t to the start of its day, ISO week or month.
+func periodStart(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch interval {
+	case IntervalDay:
+		return day
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+}
@@ -7,12 +7,48 @@ CREATE TABLE users (
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE TABLE refresh_tokens (
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE farms (
     id UUID PRIMARY KEY,
     name VARCHAR(255) NOT NULL,
     location TEXT NOT NULL,
-    owner_id UUID UNIQUE NOT NULL REFERENCES users(id) ON DELETE CASCADE,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE RESTRICT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE farm_members (
+    farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'manager', 'worker', 'veterinarian')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (farm_id, user_id)
+);
+
+CREATE TABLE farm_invitations (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('manager', 'worker', 'veterinarian')),
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP
 );
 
 CREATE TABLE animals (
@@ -23,10 +59,78 @@ CREATE TABLE animals (
     weight FLOAT CHECK (weight > 0),
     health_status VARCHAR(50) DEFAULT 'Healthy',
     date_of_birth DATE,
+    sex VARCHAR(10) NOT NULL DEFAULT 'unknown' CHECK (sex IN ('male', 'female', 'unknown')),
+    sire_id UUID REFERENCES animals(id) ON DELETE SET NULL,
+    dam_id UUID REFERENCES animals(id) ON DELETE SET NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'dead', 'sold', 'culled')),
     last_fed TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     last_watered TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE animal_events (
+    id UUID PRIMARY KEY,
+    animal_id UUID NOT NULL REFERENCES animals(id) ON DELETE CASCADE,
+    event_type VARCHAR(20) NOT NULL CHECK (event_type IN ('sold', 'died', 'culled', 'transferred')),
+    occurred_at TIMESTAMP NOT NULL,
+    from_farm_id UUID REFERENCES farms(id) ON DELETE SET NULL,
+    to_farm_id UUID REFERENCES farms(id) ON DELETE SET NULL,
+    buyer VARCHAR(255),
+    price FLOAT CHECK (price >= 0),
+    cause TEXT,
+    notes TEXT,
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_animal_events_farm ON animal_events (from_farm_id, occurred_at);
+
+CREATE TABLE breeding_events (
+    id UUID PRIMARY KEY,
+    dam_id UUID NOT NULL REFERENCES animals(id) ON DELETE CASCADE,
+    sire_id UUID REFERENCES animals(id) ON DELETE SET NULL,
+    sire_description VARCHAR(255),
+    method VARCHAR(30) NOT NULL CHECK (method IN ('natural', 'artificial_insemination')),
+    bred_at TIMESTAMP NOT NULL,
+    expected_due_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'bred' CHECK (status IN ('bred', 'pregnant', 'open', 'delivered')),
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE pregnancy_checks (
+    id UUID PRIMARY KEY,
+    breeding_event_id UUID NOT NULL REFERENCES breeding_events(id) ON DELETE CASCADE,
+    checked_at TIMESTAMP NOT NULL,
+    result VARCHAR(20) NOT NULL CHECK (result IN ('pregnant', 'not_pregnant')),
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE births (
+    id UUID PRIMARY KEY,
+    breeding_event_id UUID NOT NULL UNIQUE REFERENCES breeding_events(id) ON DELETE CASCADE,
+    born_at TIMESTAMP NOT NULL,
+    live_count INT NOT NULL CHECK (live_count >= 0),
+    stillborn_count INT NOT NULL DEFAULT 0 CHECK (stillborn_count >= 0),
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE animals ADD COLUMN birth_id UUID REFERENCES births(id) ON DELETE SET NULL;
+
+CREATE TABLE weight_measurements (
+    id UUID PRIMARY KEY,
+    animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
+    weight FLOAT NOT NULL CHECK (weight > 0),
+    measured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 CREATE TABLE foods (
@@ -38,8 +142,8 @@ CREATE TABLE foods (
     quantity FLOAT CHECK (quantity >= 0),
     min_threshold FLOAT CHECK (min_threshold >= 0),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
-    UNIQUE (farm_id, food_id)
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
 );
 
 CREATE TABLE medicines (
@@ -50,30 +154,212 @@ CREATE TABLE medicines (
     unit_of_measure VARCHAR(20) NOT NULL,
     quantity FLOAT CHECK (quantity >= 0),
     min_threshold FLOAT CHECK (min_threshold >= 0),
+    meat_withdrawal_days INT NOT NULL DEFAULT 0 CHECK (meat_withdrawal_days >= 0),
+    milk_withdrawal_days INT NOT NULL DEFAULT 0 CHECK (milk_withdrawal_days >= 0),
+    egg_withdrawal_days INT NOT NULL DEFAULT 0 CHECK (egg_withdrawal_days >= 0),
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE medicine_batches (
+    id UUID PRIMARY KEY,
+    medicine_id UUID NOT NULL REFERENCES medicines(id) ON DELETE CASCADE,
+    lot_number VARCHAR(100) NOT NULL,
+    expiry_date DATE NOT NULL,
+    initial_quantity FLOAT NOT NULL CHECK (initial_quantity > 0),
+    quantity FLOAT NOT NULL CHECK (quantity >= 0),
+    expiring_alerted_at TIMESTAMP,
+    expired_alerted_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_medicine_batches_expiry ON medicine_batches (medicine_id, expiry_date) WHERE quantity > 0;
+
+CREATE TABLE stock_movements (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
+    food_id UUID REFERENCES foods(id) ON DELETE SET NULL,
+    medicine_id UUID REFERENCES medicines(id) ON DELETE SET NULL,
+    movement_type VARCHAR(20) NOT NULL CHECK (movement_type IN ('purchase', 'consumption', 'adjustment', 'waste', 'transfer')),
+    quantity FLOAT NOT NULL,
+    balance_after FLOAT NOT NULL,
+    unit_cost FLOAT CHECK (unit_cost >= 0),
+    reference_id UUID,
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (food_id IS NULL OR medicine_id IS NULL)
+);
+
+CREATE TABLE suppliers (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    contact_name VARCHAR(255),
+    phone_number VARCHAR(20),
+    email VARCHAR(255),
+    address TEXT,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE purchase_orders (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
+    supplier_id UUID REFERENCES suppliers(id) ON DELETE RESTRICT,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'ordered', 'partially_received', 'received', 'cancelled')),
+    expected_delivery DATE,
+    notes TEXT,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ordered_at TIMESTAMP,
+    received_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE purchase_order_items (
+    id UUID PRIMARY KEY,
+    order_id UUID REFERENCES purchase_orders(id) ON DELETE CASCADE,
+    food_id UUID REFERENCES foods(id) ON DELETE SET NULL,
+    medicine_id UUID REFERENCES medicines(id) ON DELETE SET NULL,
+    quantity FLOAT NOT NULL CHECK (quantity > 0),
+    unit_cost FLOAT NOT NULL CHECK (unit_cost >= 0),
+    received_quantity FLOAT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0),
+    CHECK (food_id IS NULL OR medicine_id IS NULL)
 );
 
 CREATE TABLE feeding_records (
     id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE SET NULL,
     animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
-    food_id UUID REFERENCES foods(id) ON DELETE CASCADE,
+    food_id UUID REFERENCES foods(id) ON DELETE RESTRICT,
     quantity FLOAT CHECK (quantity > 0),
     fed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     notes TEXT,
-    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
 );
 
+CREATE INDEX idx_feeding_records_animal ON feeding_records (animal_id, fed_at);
+CREATE INDEX idx_feeding_records_farm ON feeding_records (farm_id, fed_at);
+
+CREATE TABLE feeding_schedules (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
+    animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
+    animal_type VARCHAR(50),
+    food_id UUID REFERENCES foods(id) ON DELETE CASCADE,
+    quantity FLOAT CHECK (quantity > 0),
+    times_of_day TEXT[] NOT NULL,
+    days_of_week SMALLINT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((animal_id IS NULL) <> (animal_type IS NULL))
+);
+
+CREATE TABLE feeding_tasks (
+    id UUID PRIMARY KEY,
+    schedule_id UUID REFERENCES feeding_schedules(id) ON DELETE CASCADE,
+    animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
+    food_id UUID REFERENCES foods(id) ON DELETE CASCADE,
+    quantity FLOAT CHECK (quantity > 0),
+    due_at TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'skipped')),
+    feeding_record_id UUID REFERENCES feeding_records(id) ON DELETE SET NULL,
+    completed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (schedule_id, animal_id, due_at)
+);
+
+CREATE TABLE watering_records (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE SET NULL,
+    animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
+    quantity FLOAT CHECK (quantity >= 0),
+    watered_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    notes TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_watering_records_farm ON watering_records (farm_id, watered_at);
+
 CREATE TABLE medical_records (
     id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE SET NULL,
     animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
-    medicine_id UUID REFERENCES medicines(id) ON DELETE CASCADE,
+    medicine_id UUID REFERENCES medicines(id) ON DELETE RESTRICT,
     quantity FLOAT CHECK (quantity > 0),
     treatment_date TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     notes TEXT,
+    meat_withdrawal_until DATE,
+    milk_withdrawal_until DATE,
+    egg_withdrawal_until DATE,
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP
+);
+
+CREATE INDEX idx_medical_records_animal ON medical_records (animal_id, treatment_date);
+CREATE INDEX idx_medical_records_farm ON medical_records (farm_id, treatment_date);
+
+CREATE TABLE medical_record_batches (
+    medical_record_id UUID REFERENCES medical_records(id) ON DELETE CASCADE,
+    batch_id UUID REFERENCES medicine_batches(id) ON DELETE CASCADE,
+    quantity FLOAT NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (medical_record_id, batch_id)
+);
+
+CREATE TABLE production_records (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE SET NULL,
+    animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
+    animal_type VARCHAR(50),
+    product VARCHAR(20) NOT NULL CHECK (product IN ('milk', 'eggs', 'wool')),
+    quantity FLOAT NOT NULL CHECK (quantity > 0),
+    unit VARCHAR(20) NOT NULL,
+    produced_at TIMESTAMP NOT NULL,
+    notes TEXT,
+    recorded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP,
+    CHECK ((animal_id IS NULL) <> (animal_type IS NULL))
+);
+
+CREATE INDEX idx_production_records_farm ON production_records (farm_id, produced_at);
+
+CREATE TABLE treatment_plans (
+    id UUID PRIMARY KEY,
+    farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    animal_type VARCHAR(50) NOT NULL,
+    medicine_id UUID REFERENCES medicines(id) ON DELETE CASCADE,
+    quantity FLOAT CHECK (quantity > 0),
+    first_dose_age_days INT CHECK (first_dose_age_days >= 0),
+    dose_count INT NOT NULL CHECK (dose_count >= 1),
+    dose_interval_days INT NOT NULL DEFAULT 0 CHECK (dose_interval_days >= 0),
+    booster_interval_days INT NOT NULL DEFAULT 0 CHECK (booster_interval_days >= 0),
+    notes TEXT,
+    active BOOLEAN DEFAULT TRUE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
+CREATE TABLE treatment_events (
+    id UUID PRIMARY KEY,
+    plan_id UUID REFERENCES treatment_plans(id) ON DELETE CASCADE,
+    animal_id UUID REFERENCES animals(id) ON DELETE CASCADE,
+    dose_number INT NOT NULL,
+    due_date DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'skipped')),
+    medical_record_id UUID REFERENCES medical_records(id) ON DELETE SET NULL,
+    completed_at TIMESTAMP,
+    reminded_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (plan_id, animal_id, dose_number)
+);
+
 CREATE TABLE alerts (
     id UUID PRIMARY KEY,
     farm_id UUID REFERENCES farms(id) ON DELETE CASCADE,
@@ -82,3 +368,18 @@ CREATE TABLE alerts (
     is_read BOOLEAN DEFAULT FALSE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
+
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY,
+    user_id UUID,
+    farm_id UUID,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('create', 'update', 'delete', 'restore')),
+    changes JSONB NOT NULL DEFAULT '{}',
+    request_id VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_log_entity ON audit_log (entity_type, entity_id, created_at);
+CREATE INDEX idx_audit_log_farm ON audit_log (farm_id, created_at);
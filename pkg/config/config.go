@@ -0,0 +1,317 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds every setting of the API. Values are read from an optional YAML
+// file and then overridden by environment variables.
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	JWT       JWTConfig       `yaml:"jwt"`
+	Care      CareConfig      `yaml:"care"`
+	Stock     StockConfig     `yaml:"stock"`
+	Trash     TrashConfig     `yaml:"trash"`
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+	Log       LogConfig       `yaml:"log"`
+}
+
+type ServerConfig struct {
+	Addr        string   `yaml:"addr"`
+	PublicURL   string   `yaml:"public_url"`
+	CORSOrigins []string `yaml:"cors_origins"`
+	// ShutdownTimeout is how long requests in flight may take to finish once
+	// the server is asked to stop.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type DatabaseConfig struct {
+	DSN             string        `yaml:"dsn"`
+	Host            string        `yaml:"host"`
+	Port            int           `yaml:"port"`
+	User            string        `yaml:"user"`
+	Password        string        `yaml:"password"`
+	Name            string        `yaml:"name"`
+	SSLMode         string        `yaml:"sslmode"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+}
+
+type JWTConfig struct {
+	Secret          string        `yaml:"secret"`
+	PreviousSecrets []string      `yaml:"previous_secrets"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl"`
+}
+
+// CareConfig holds how often animals are expected to be looked after.
+type CareConfig struct {
+	FeedingInterval  time.Duration `yaml:"feeding_interval"`
+	WateringInterval time.Duration `yaml:"watering_interval"`
+}
+
+// StockConfig holds how inventory is watched.
+type StockConfig struct {
+	// ExpiryWarning is how long before expiry a medicine batch is reported.
+	ExpiryWarning time.Duration `yaml:"expiry_warning"`
+}
+
+// TrashConfig holds how long deleted items can be restored.
+type TrashConfig struct {
+	// Retention is how long deleted items are kept before they are purged.
+	Retention time.Duration `yaml:"retention"`
+}
+
+// SchedulerConfig holds how often the background jobs run.
+type SchedulerConfig struct {
+	FeedingTasksInterval    time.Duration `yaml:"feeding_tasks_interval"`
+	TreatmentEventsInterval time.Duration `yaml:"treatment_events_interval"`
+	MedicineBatchesInterval time.Duration `yaml:"medicine_batches_interval"`
+	PurgeInterval           time.Duration `yaml:"purge_interval"`
+}
+
+type LogConfig struct {
+	// Level is "debug" to run the router in debug mode, which also logs the
+	// routes and its warnings, or "info" for release mode.
+	Level string `yaml:"level"`
+}
+
+// Default returns the configuration used when nothing else is provided.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Addr:            ":8080",
+			PublicURL:       "http://localhost:8080",
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Host:            "localhost",
+			Port:            5432,
+			User:            "postgres",
+			Name:            "postgres",
+			SSLMode:         "disable",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+		},
+		JWT: JWTConfig{
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 30 * 24 * time.Hour,
+		},
+		Care: CareConfig{
+			FeedingInterval:  24 * time.Hour,
+			WateringInterval: 12 * time.Hour,
+		},
+		Stock: StockConfig{
+			ExpiryWarning: 30 * 24 * time.Hour,
+		},
+		Trash: TrashConfig{
+			Retention: 30 * 24 * time.Hour,
+		},
+		Scheduler: SchedulerConfig{
+			FeedingTasksInterval:    time.Hour,
+			TreatmentEventsInterval: time.Hour,
+			MedicineBatchesInterval: time.Hour,
+			PurgeInterval:           24 * time.Hour,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the YAML file at path (when
+// path is not empty) and the environment, in that order of precedence.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %v", err)
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file: %v", err)
+		}
+	}
+
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) applyEnv() error {
+	setString(&c.Server.Addr, "SERVER_ADDR")
+	setString(&c.Server.PublicURL, "SERVER_PUBLIC_URL")
+	setList(&c.Server.CORSOrigins, "CORS_ORIGINS")
+
+	setString(&c.Database.DSN, "DATABASE_URL")
+	setString(&c.Database.Host, "DB_HOST")
+	setString(&c.Database.User, "DB_USER")
+	setString(&c.Database.Password, "DB_PASSWORD")
+	setString(&c.Database.Name, "DB_NAME")
+	setString(&c.Database.SSLMode, "DB_SSLMODE")
+
+	setString(&c.JWT.Secret, "JWT_SECRET")
+	setList(&c.JWT.PreviousSecrets, "JWT_PREVIOUS_SECRETS")
+
+	setString(&c.Log.Level, "LOG_LEVEL")
+
+	return errors.Join(
+		setDuration(&c.Server.ShutdownTimeout, "SERVER_SHUTDOWN_TIMEOUT"),
+		setInt(&c.Database.Port, "DB_PORT"),
+		setInt(&c.Database.MaxOpenConns, "DB_MAX_OPEN_CONNS"),
+		setInt(&c.Database.MaxIdleConns, "DB_MAX_IDLE_CONNS"),
+		setDuration(&c.Database.ConnMaxLifetime, "DB_CONN_MAX_LIFETIME"),
+		setDuration(&c.JWT.AccessTokenTTL, "JWT_ACCESS_TOKEN_TTL"),
+		setDuration(&c.JWT.RefreshTokenTTL, "JWT_REFRESH_TOKEN_TTL"),
+		setDuration(&c.Care.FeedingInterval, "CARE_FEEDING_INTERVAL"),
+		setDuration(&c.Care.WateringInterval, "CARE_WATERING_INTERVAL"),
+		setDuration(&c.Scheduler.FeedingTasksInterval, "SCHEDULER_FEEDING_TASKS_INTERVAL"),
+		setDuration(&c.Stock.ExpiryWarning, "STOCK_EXPIRY_WARNING"),
+		setDuration(&c.Scheduler.TreatmentEventsInterval, "SCHEDULER_TREATMENT_EVENTS_INTERVAL"),
+		setDuration(&c.Scheduler.MedicineBatchesInterval, "SCHEDULER_MEDICINE_BATCHES_INTERVAL"),
+		setDuration(&c.Trash.Retention, "TRASH_RETENTION"),
+		setDuration(&c.Scheduler.PurgeInterval, "SCHEDULER_PURGE_INTERVAL"),
+	)
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Server.Addr == "" {
+		errs = append(errs, errors.New("server.addr is required"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+
+	errs = append(errs, c.Database.Validate())
+
+	if len(c.JWT.Secret) < 16 {
+		errs = append(errs, errors.New("jwt.secret must be at least 16 characters (set JWT_SECRET)"))
+	}
+	if c.JWT.AccessTokenTTL <= 0 || c.JWT.RefreshTokenTTL <= 0 {
+		errs = append(errs, errors.New("jwt token TTLs must be positive"))
+	}
+	if c.JWT.AccessTokenTTL >= c.JWT.RefreshTokenTTL {
+		errs = append(errs, errors.New("jwt.access_token_ttl must be shorter than jwt.refresh_token_ttl"))
+	}
+
+	if c.Care.FeedingInterval <= 0 || c.Care.WateringInterval <= 0 {
+		errs = append(errs, errors.New("care intervals must be positive"))
+	}
+	if c.Stock.ExpiryWarning < 0 {
+		errs = append(errs, errors.New("stock.expiry_warning cannot be negative"))
+	}
+	if c.Trash.Retention <= 0 {
+		errs = append(errs, errors.New("trash.retention must be positive"))
+	}
+	if c.Scheduler.FeedingTasksInterval <= 0 || c.Scheduler.TreatmentEventsInterval <= 0 ||
+		c.Scheduler.MedicineBatchesInterval <= 0 || c.Scheduler.PurgeInterval <= 0 {
+		errs = append(errs, errors.New("scheduler intervals must be positive"))
+	}
+
+	switch c.Log.Level {
+	case "debug", "info":
+	default:
+		errs = append(errs, fmt.Errorf("log.level %q must be debug or info", c.Log.Level))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *DatabaseConfig) Validate() error {
+	var errs []error
+
+	if d.DSN == "" && (d.Host == "" || d.User == "" || d.Name == "") {
+		errs = append(errs, errors.New("database.dsn or database.host, user and name are required"))
+	}
+	if d.MaxOpenConns < 0 || d.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("database pool sizes cannot be negative"))
+	}
+	if d.MaxOpenConns > 0 && d.MaxIdleConns > d.MaxOpenConns {
+		errs = append(errs, errors.New("database.max_idle_conns cannot exceed database.max_open_conns"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConnectionString returns the DSN, building a postgres URL from the individual
+// fields when no DSN is configured.
+func (d *DatabaseConfig) ConnectionString() string {
+	if d.DSN != "" {
+		return d.DSN
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(d.User, d.Password),
+		Host:     fmt.Sprintf("%s:%d", d.Host, d.Port),
+		Path:     "/" + d.Name,
+		RawQuery: url.Values{"sslmode": {d.SSLMode}}.Encode(),
+	}
+	return u.String()
+}
+
+func setString(dst *string, key string) {
+	if v, ok := os.LookupEnv(key); ok {
+		*dst = v
+	}
+}
+
+func setList(dst *[]string, key string) {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return
+	}
+
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
+
+func setInt(dst *int, key string) error {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("%s must be an integer: %v", key, err)
+	}
+	*dst = n
+	return nil
+}
+
+func setDuration(dst *time.Duration, key string) error {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("%s must be a duration such as 15m or 24h: %v", key, err)
+	}
+	*dst = d
+	return nil
+}
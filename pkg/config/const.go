@@ -1,3 +0,0 @@
-package config
-
-const SECRET_KEY = "85DEBFCCE1228CF7"
@@ -2,26 +2,20 @@ package config
 
 import (
 	"database/sql"
-	"fmt"
 
 	_ "github.com/lib/pq"
 )
 
-const (
-	host     = "localhost"
-	port     = 5432
-	user     = "postgres"
-	dbname   = "postgres"
-	password = "Aa2210057"
-)
-
-func ConnectPostgres() (*sql.DB, error) {
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable", host, port, user, password, dbname)
-	db, err := sql.Open("postgres", connStr)
+func ConnectPostgres(cfg DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("postgres", cfg.ConnectionString())
 	if err != nil {
 		return nil, err
 	}
 
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
 	err = db.Ping()
 	if err != nil {
 		return nil, err
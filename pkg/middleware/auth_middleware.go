@@ -8,12 +8,17 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+// SessionValidator reports whether a server-side session is still active.
+type SessionValidator interface {
+	IsSessionActive(sessionID uuid.UUID) (bool, error)
+}
+
+func AuthMiddleware(sessions SessionValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
-		println(authHeader)
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header missing or invalid"})
 			c.Abort()
@@ -29,14 +34,44 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok {
-			c.Set("userId", claims["userId"])
-		} else {
+		claims, ok := token.Claims.(jwt.MapClaims)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			c.Abort()
+			return
+		}
+
+		rawUserID, _ := claims["user_id"].(string)
+		userID, err := uuid.Parse(rawUserID)
+		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
 			c.Abort()
 			return
 		}
 
+		rawSessionID, _ := claims["sid"].(string)
+		sessionID, err := uuid.Parse(rawSessionID)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			c.Abort()
+			return
+		}
+
+		active, err := sessions.IsSessionActive(sessionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			c.Abort()
+			return
+		}
+		if !active {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Session has been revoked"})
+			c.Abort()
+			return
+		}
+
+		c.Set("userId", userID)
+		c.Set("sessionId", sessionID)
+
 		c.Next()
 	}
 }
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID that ties a request to its audit entries.
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID keeps the request ID sent by the client, or generates one, and
+// echoes it in the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 100 {
+			requestID = uuid.New().String()
+		}
+
+		c.Set("requestId", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
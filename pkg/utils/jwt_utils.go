@@ -1,7 +1,8 @@
 package utils
 
 import (
-	"farmish/pkg/config"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
 
@@ -9,15 +10,40 @@ import (
 	"github.com/google/uuid"
 )
 
-func CreateToken(email string, userID uuid.UUID) (string, error) {
+var (
+	signingKeyID string
+	signingKeys  = map[string][]byte{}
+)
+
+// ConfigureJWT sets the secret used to sign new tokens. Tokens signed with any
+// of the previous secrets are still accepted so secrets can be rotated without
+// logging everybody out.
+func ConfigureJWT(secret string, previousSecrets []string) {
+	signingKeys = map[string][]byte{}
+	for _, s := range previousSecrets {
+		signingKeys[keyID(s)] = []byte(s)
+	}
+
+	signingKeyID = keyID(secret)
+	signingKeys[signingKeyID] = []byte(secret)
+}
+
+func keyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:4])
+}
+
+func CreateToken(email string, userID, sessionID uuid.UUID, ttl time.Duration) (string, error) {
 	claims := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"email":   email,
 		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(),
+		"sid":     sessionID,
+		"exp":     time.Now().Add(ttl).Unix(),
 		"iat":     time.Now().Unix(),
 	})
+	claims.Header["kid"] = signingKeyID
 
-	tokenString, err := claims.SignedString([]byte(config.SECRET_KEY))
+	tokenString, err := claims.SignedString(signingKeys[signingKeyID])
 	if err != nil {
 		return "", err
 	}
@@ -27,8 +53,13 @@ func CreateToken(email string, userID uuid.UUID) (string, error) {
 
 func VerifyToken(tokenString string) (*jwt.Token, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(config.SECRET_KEY), nil
-	})
+		kid, _ := token.Header["kid"].(string)
+		key, ok := signingKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key")
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 
 	if err != nil {
 		return nil, err